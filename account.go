@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -15,11 +16,22 @@ type Account struct {
 	Created      *time.Time `json:"created,omitempty"`
 }
 
+// CaptchaKind the kind of captcha.
+type CaptchaKind string
+
+const (
+	// CaptchaImage the challenge is a PNG image (default).
+	CaptchaImage CaptchaKind = "image"
+	// CaptchaAudio the challenge is a WAV audio.
+	CaptchaAudio CaptchaKind = "audio"
+)
+
 // Captcha a captcha representation.
 type Captcha struct {
-	ID        string `json:"id,omitempty"`
-	Challenge string `json:"challenge,omitempty"`
-	Solution  string `json:"solution,omitempty"`
+	ID        string      `json:"id,omitempty"`
+	Kind      CaptchaKind `json:"kind,omitempty"`
+	Challenge string      `json:"challenge,omitempty"`
+	Solution  string      `json:"solution,omitempty"`
 }
 
 // Registration a registration representation.
@@ -38,6 +50,8 @@ type AccountService struct {
 }
 
 // Login Log in.
+// If the client uses a static token (the default), the obtained token is used by the next API calls.
+// A custom TokenProvider (ClientOptions.TokenProvider) is kept.
 // https://desec.readthedocs.io/en/latest/auth/account.html#log-in
 func (s *AccountService) Login(ctx context.Context, email, password string) (*Token, error) {
 	endpoint, err := s.client.createEndpoint("auth", "login")
@@ -45,34 +59,21 @@ func (s *AccountService) Login(ctx context.Context, email, password string) (*To
 		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, endpoint, Account{Email: email, Password: password})
+	token, _, err := do[Token](ctx, s.client, http.MethodPost, endpoint, Account{Email: email, Password: password})
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, handleError(resp)
+	if _, ok := s.client.tokenProvider.(StaticToken); ok {
+		s.client.tokenProvider = StaticToken(token.Value)
+		s.client.loginTokenID = token.ID
 	}
 
-	var token Token
-	err = handleResponse(resp, &token)
-	if err != nil {
-		return nil, err
-	}
-
-	s.client.token = token.Value
-
 	return &token, nil
 }
 
 // Logout log out (= delete current token).
+// If the client uses a static token (the default), the token is cleared. A custom TokenProvider is kept.
 // https://desec.readthedocs.io/en/latest/auth/account.html#log-out
 func (s *AccountService) Logout(ctx context.Context) error {
 	endpoint, err := s.client.createEndpoint("auth", "logout")
@@ -80,53 +81,39 @@ func (s *AccountService) Logout(ctx context.Context) error {
 		return fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, endpoint, nil)
+	_, _, err = do[struct{}](ctx, s.client, http.MethodPost, endpoint, nil)
 	if err != nil {
 		return err
 	}
 
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to call API: %w", err)
+	if _, ok := s.client.tokenProvider.(StaticToken); ok {
+		s.client.tokenProvider = StaticToken("")
+		s.client.loginTokenID = ""
 	}
 
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusNoContent {
-		return handleError(resp)
-	}
-
-	s.client.token = ""
-
 	return nil
 }
 
 // ObtainCaptcha Obtain a captcha.
 // https://desec.readthedocs.io/en/latest/auth/account.html#obtain-a-captcha
 func (s *AccountService) ObtainCaptcha(ctx context.Context) (*Captcha, error) {
+	return s.ObtainCaptchaOfKind(ctx, "")
+}
+
+// ObtainCaptchaOfKind Obtain a captcha of a kind (image or audio), an empty kind uses the default of the API (image).
+// https://desec.readthedocs.io/en/latest/auth/account.html#obtain-a-captcha
+func (s *AccountService) ObtainCaptchaOfKind(ctx context.Context, kind CaptchaKind) (*Captcha, error) {
 	endpoint, err := s.client.createEndpoint("captcha")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, handleError(resp)
+	var body any
+	if kind != "" {
+		body = Captcha{Kind: kind}
 	}
 
-	var captcha Captcha
-	err = handleResponse(resp, &captcha)
+	captcha, _, err := do[Captcha](ctx, s.client, http.MethodPost, endpoint, body)
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +122,7 @@ func (s *AccountService) ObtainCaptcha(ctx context.Context) (*Captcha, error) {
 }
 
 // Register register account.
+// The request must be confirmed with the link sent by email: a PendingConfirmationError is returned when the API accepts it.
 // https://desec.readthedocs.io/en/latest/auth/account.html#register-account
 func (s *AccountService) Register(ctx context.Context, registration Registration) error {
 	endpoint, err := s.client.createEndpoint("auth")
@@ -142,23 +130,7 @@ func (s *AccountService) Register(ctx context.Context, registration Registration
 		return fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, endpoint, registration)
-	if err != nil {
-		return err
-	}
-
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusAccepted {
-		return handleError(resp)
-	}
-
-	return nil
+	return waitForAccepted(ctx, s.client, endpoint, registration)
 }
 
 // RetrieveInformation retrieve account information.
@@ -169,24 +141,7 @@ func (s *AccountService) RetrieveInformation(ctx context.Context) (*Account, err
 		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, handleError(resp)
-	}
-
-	var account Account
-	err = handleResponse(resp, &account)
+	account, _, err := do[Account](ctx, s.client, http.MethodPost, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -195,6 +150,7 @@ func (s *AccountService) RetrieveInformation(ctx context.Context) (*Account, err
 }
 
 // PasswordReset password reset and password change.
+// The request must be confirmed with the link sent by email: a PendingConfirmationError is returned when the API accepts it.
 // https://desec.readthedocs.io/en/latest/auth/account.html#password-reset
 // https://desec.readthedocs.io/en/latest/auth/account.html#password-change
 func (s *AccountService) PasswordReset(ctx context.Context, email string, captcha Captcha) error {
@@ -203,26 +159,29 @@ func (s *AccountService) PasswordReset(ctx context.Context, email string, captch
 		return fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, endpoint, Registration{Email: email, Captcha: &captcha})
-	if err != nil {
-		return err
-	}
+	return waitForAccepted(ctx, s.client, endpoint, Registration{Email: email, Captcha: &captcha})
+}
 
-	resp, err := s.client.httpClient.Do(req)
+// ConfirmPasswordReset sets a new password with the code of the password reset email (second step of PasswordReset).
+// The code is the last part of the link: https://desec.io/confirm/reset-password/<code>/
+// https://desec.readthedocs.io/en/latest/auth/account.html#password-reset
+func (s *AccountService) ConfirmPasswordReset(ctx context.Context, code, newPassword string) error {
+	endpoint, err := s.client.createEndpoint("v", "reset-password", code)
 	if err != nil {
-		return fmt.Errorf("failed to call API: %w", err)
+		return fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	defer func() { _ = resp.Body.Close() }()
+	body := struct {
+		NewPassword string `json:"new_password"`
+	}{NewPassword: newPassword}
 
-	if resp.StatusCode != http.StatusAccepted {
-		return handleError(resp)
-	}
+	_, _, err = do[struct{}](ctx, s.client, http.MethodPost, endpoint, body)
 
-	return nil
+	return err
 }
 
 // ChangeEmail changes email address.
+// The request must be confirmed with the link sent by email: a PendingConfirmationError is returned when the API accepts it.
 // https://desec.readthedocs.io/en/latest/auth/account.html#change-email-address
 func (s *AccountService) ChangeEmail(ctx context.Context, email, password, newEmail string) error {
 	endpoint, err := s.client.createEndpoint("auth", "account", "change-email")
@@ -230,26 +189,11 @@ func (s *AccountService) ChangeEmail(ctx context.Context, email, password, newEm
 		return fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, endpoint, Registration{Email: email, Password: password, NewEmail: newEmail})
-	if err != nil {
-		return err
-	}
-
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusAccepted {
-		return handleError(resp)
-	}
-
-	return nil
+	return waitForAccepted(ctx, s.client, endpoint, Registration{Email: email, Password: password, NewEmail: newEmail})
 }
 
 // Delete deletes account.
+// The request must be confirmed with the link sent by email: a PendingConfirmationError is returned when the API accepts it.
 // https://desec.readthedocs.io/en/latest/auth/account.html#delete-account
 func (s *AccountService) Delete(ctx context.Context, email, password string) error {
 	endpoint, err := s.client.createEndpoint("auth", "account", "delete")
@@ -257,21 +201,22 @@ func (s *AccountService) Delete(ctx context.Context, email, password string) err
 		return fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, endpoint, Account{Email: email, Password: password})
+	return waitForAccepted(ctx, s.client, endpoint, Account{Email: email, Password: password})
+}
+
+// waitForAccepted sends a request (POST) that the API accepts (202) before a confirmation.
+// Returns a PendingConfirmationError if the operation is pending, the API error otherwise.
+func waitForAccepted(ctx context.Context, c *Client, endpoint *url.URL, body any) error {
+	pending, meta, err := do[PendingConfirmationError](ctx, c, http.MethodPost, endpoint, body)
 	if err != nil {
 		return err
 	}
 
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to call API: %w", err)
+	if meta.StatusCode != http.StatusAccepted {
+		return nil
 	}
 
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusAccepted {
-		return handleError(resp)
-	}
+	pending.Location = meta.Header.Get("Location")
 
-	return nil
+	return &pending
 }
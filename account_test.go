@@ -2,6 +2,7 @@ package desec
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -64,7 +65,9 @@ func TestAccountClient_Register(t *testing.T) {
 			return
 		}
 
+		rw.Header().Set("Location", "https://desec.io/api/v1/auth/account/")
 		rw.WriteHeader(http.StatusAccepted)
+		_, _ = rw.Write([]byte(`{"detail":"Welcome! Please check your mailbox."}`))
 	})
 
 	registration := Registration{
@@ -77,7 +80,15 @@ func TestAccountClient_Register(t *testing.T) {
 	}
 
 	err := client.Account.Register(context.Background(), registration)
-	require.NoError(t, err)
+
+	var pending *PendingConfirmationError
+	require.ErrorAs(t, err, &pending)
+
+	expected := &PendingConfirmationError{
+		Detail:   "Welcome! Please check your mailbox.",
+		Location: "https://desec.io/api/v1/auth/account/",
+	}
+	assert.Equal(t, expected, pending)
 }
 
 func TestAccountClient_Login(t *testing.T) {
@@ -119,7 +130,45 @@ func TestAccountClient_Login(t *testing.T) {
 	}
 	assert.Equal(t, expected, token)
 
-	assert.Equal(t, expected.Value, client.token)
+	assert.Equal(t, StaticToken(expected.Value), client.tokenProvider)
+}
+
+func TestAccountClient_Login_tokenProvider(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	opts := NewDefaultClientOptions()
+	opts.TokenProvider = TokenFunc(func(_ context.Context) (string, error) {
+		return "rotating", nil
+	})
+
+	client := New("", opts)
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("POST /auth/login/", func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(`{"id":"token-id","token":"secret"}`))
+	})
+
+	var authorization string
+
+	mux.HandleFunc("POST /auth/logout/", func(rw http.ResponseWriter, req *http.Request) {
+		authorization = req.Header.Get("Authorization")
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	token, err := client.Account.Login(context.Background(), "email@example.com", "secret")
+	require.NoError(t, err)
+
+	assert.Equal(t, "secret", token.Value)
+
+	err = client.Account.Logout(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "Token rotating", authorization)
+
+	_, ok := client.tokenProvider.(TokenFunc)
+	assert.True(t, ok)
 }
 
 func TestAccountClient_Logout(t *testing.T) {
@@ -142,7 +191,7 @@ func TestAccountClient_Logout(t *testing.T) {
 	err := client.Account.Logout(context.Background())
 	require.NoError(t, err)
 
-	assert.Empty(t, client.token)
+	assert.Equal(t, StaticToken(""), client.tokenProvider)
 }
 
 func TestAccountClient_RetrieveInformation(t *testing.T) {
@@ -207,6 +256,40 @@ func TestAccountClient_PasswordReset(t *testing.T) {
 	}
 
 	err := client.Account.PasswordReset(context.Background(), "email@example.com", captcha)
+	require.ErrorAs(t, err, new(*PendingConfirmationError))
+}
+
+func TestAccountClient_ConfirmPasswordReset(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/v/reset-password/code/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var body map[string]string
+
+		err := json.NewDecoder(req.Body).Decode(&body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if body["new_password"] != "secret" {
+			http.Error(rw, "invalid password", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"detail":"Success! Your password has been changed."}`))
+	})
+
+	err := client.Account.ConfirmPasswordReset(context.Background(), "code", "secret")
 	require.NoError(t, err)
 }
 
@@ -228,7 +311,7 @@ func TestAccountClient_ChangeEmail(t *testing.T) {
 	})
 
 	err := client.Account.ChangeEmail(context.Background(), "email@example.com", "secret", "newemail@example.com")
-	require.NoError(t, err)
+	require.ErrorAs(t, err, new(*PendingConfirmationError))
 }
 
 func TestAccountClient_Delete(t *testing.T) {
@@ -249,5 +332,5 @@ func TestAccountClient_Delete(t *testing.T) {
 	})
 
 	err := client.Account.Delete(context.Background(), "email@example.com", "secret")
-	require.NoError(t, err)
+	require.ErrorAs(t, err, new(*PendingConfirmationError))
 }
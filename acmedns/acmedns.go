@@ -0,0 +1,69 @@
+// Package acmedns implements the acme-dns HTTP API backed by deSEC,
+// to use deSEC with the ACME clients that only support acme-dns.
+//
+// https://github.com/joohoi/acme-dns#api
+package acmedns
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"sync"
+)
+
+// ErrAccountNotFound the account doesn't exist.
+var ErrAccountNotFound = errors.New("account not found")
+
+// Account an acme-dns account.
+type Account struct {
+	Username     string
+	PasswordHash []byte
+	// Subdomain the subname of the challenge RRSet, inside the domain of the Server.
+	Subdomain string
+	// AllowFrom the networks allowed to update the challenge, all the networks if empty.
+	AllowFrom []netip.Prefix
+	// TXT the current values of the challenge, the most recent last.
+	TXT []string
+}
+
+// Store persists the accounts.
+type Store interface {
+	// Get returns the account of a username, or ErrAccountNotFound.
+	Get(ctx context.Context, username string) (*Account, error)
+	// Save creates or updates an account.
+	Save(ctx context.Context, account *Account) error
+}
+
+// MemoryStore an in-memory Store, the accounts are lost on restart.
+type MemoryStore struct {
+	mu       sync.Mutex
+	accounts map[string]Account
+}
+
+// NewMemoryStore creates a new MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{accounts: make(map[string]Account)}
+}
+
+// Get returns the account of a username.
+func (s *MemoryStore) Get(_ context.Context, username string) (*Account, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	account, ok := s.accounts[username]
+	if !ok {
+		return nil, ErrAccountNotFound
+	}
+
+	return &account, nil
+}
+
+// Save creates or updates an account.
+func (s *MemoryStore) Save(_ context.Context, account *Account) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accounts[account.Username] = *account
+
+	return nil
+}
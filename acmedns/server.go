@@ -0,0 +1,340 @@
+package acmedns
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nrdcg/desec"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// txtLength the length of an ACME DNS-01 challenge value (base64url encoded SHA-256 digest).
+const txtLength = 43
+
+// maxTXTValues the number of challenge values kept by acme-dns (ex: for a certificate with a wildcard and the apex).
+const maxTXTValues = 2
+
+// Server serves the acme-dns API, the challenges are written in the RRSets of a deSEC domain.
+//
+// The ACME clients point the _acme-challenge CNAME of their domains to the fulldomain of their account.
+type Server struct {
+	// Client the deSEC API client.
+	Client *desec.Client
+
+	// Domain the deSEC domain hosting the challenges.
+	Domain string
+
+	// Store persists the accounts.
+	Store Store
+
+	// TTL of the challenge RRSets (default: the minimum TTL of the domain, chosen by the API).
+	TTL int
+
+	// Logger logs the errors of the deSEC API (default: log.Default()).
+	Logger *log.Logger
+
+	mu sync.Mutex
+}
+
+// NewServer creates a new Server.
+func NewServer(client *desec.Client, domainName string, store Store) *Server {
+	return &Server{
+		Client: client,
+		Domain: domainName,
+		Store:  store,
+	}
+}
+
+// Handler returns the HTTP handler of the acme-dns API: /register, /update, and /health.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /register", s.register)
+	mux.HandleFunc("POST /update", s.update)
+	mux.HandleFunc("GET /health", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	return mux
+}
+
+type registerRequest struct {
+	AllowFrom []string `json:"allowfrom"`
+}
+
+type registerResponse struct {
+	Username   string   `json:"username"`
+	Password   string   `json:"password"`
+	FullDomain string   `json:"fulldomain"`
+	Subdomain  string   `json:"subdomain"`
+	AllowFrom  []string `json:"allowfrom"`
+}
+
+type updateRequest struct {
+	Subdomain string `json:"subdomain"`
+	TXT       string `json:"txt"`
+}
+
+type updateResponse struct {
+	TXT string `json:"txt"`
+}
+
+func (s *Server) register(rw http.ResponseWriter, req *http.Request) {
+	var body registerRequest
+
+	if req.ContentLength != 0 {
+		err := json.NewDecoder(req.Body).Decode(&body)
+		if err != nil {
+			writeError(rw, http.StatusBadRequest, "malformed_json_payload")
+			return
+		}
+	}
+
+	allowFrom := make([]netip.Prefix, 0, len(body.AllowFrom))
+
+	for _, cidr := range body.AllowFrom {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			writeError(rw, http.StatusBadRequest, "invalid_allowfrom_cidr")
+			return
+		}
+
+		allowFrom = append(allowFrom, prefix.Masked())
+	}
+
+	account, password, err := newAccount(allowFrom)
+	if err != nil {
+		s.logf("failed to create account: %v", err)
+		writeError(rw, http.StatusInternalServerError, "error")
+
+		return
+	}
+
+	err = s.Store.Save(req.Context(), account)
+	if err != nil {
+		s.logf("failed to save account: %v", err)
+		writeError(rw, http.StatusInternalServerError, "error")
+
+		return
+	}
+
+	resp := registerResponse{
+		Username:   account.Username,
+		Password:   password,
+		FullDomain: account.Subdomain + "." + strings.TrimSuffix(s.Domain, "."),
+		Subdomain:  account.Subdomain,
+		AllowFrom:  make([]string, 0, len(allowFrom)),
+	}
+
+	for _, prefix := range allowFrom {
+		resp.AllowFrom = append(resp.AllowFrom, prefix.String())
+	}
+
+	writeJSON(rw, http.StatusCreated, resp)
+}
+
+func (s *Server) update(rw http.ResponseWriter, req *http.Request) {
+	account, err := s.authenticate(req)
+	if err != nil {
+		writeError(rw, http.StatusUnauthorized, "forbidden")
+		return
+	}
+
+	var body updateRequest
+
+	err = json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		writeError(rw, http.StatusBadRequest, "malformed_json_payload")
+		return
+	}
+
+	if body.Subdomain != account.Subdomain {
+		writeError(rw, http.StatusUnauthorized, "forbidden")
+		return
+	}
+
+	if len(body.TXT) != txtLength {
+		writeError(rw, http.StatusBadRequest, "bad_txt")
+		return
+	}
+
+	err = s.setChallenge(req.Context(), account, body.TXT)
+	if err != nil {
+		s.logf("failed to update the challenge of %s: %v", account.Subdomain, err)
+		writeError(rw, http.StatusInternalServerError, "error")
+
+		return
+	}
+
+	writeJSON(rw, http.StatusOK, updateResponse{TXT: body.TXT})
+}
+
+func (s *Server) authenticate(req *http.Request) (*Account, error) {
+	username := req.Header.Get("X-Api-User")
+	password := req.Header.Get("X-Api-Key")
+
+	if username == "" || password == "" {
+		return nil, errors.New("missing credentials")
+	}
+
+	account, err := s.Store.Get(req.Context(), username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bcrypt.CompareHashAndPassword(account.PasswordHash, []byte(password))
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed(account.AllowFrom, req.RemoteAddr) {
+		return nil, errors.New("address not allowed")
+	}
+
+	return account, nil
+}
+
+// setChallenge writes the challenge RRSet with the new value and the previous one.
+func (s *Server) setChallenge(ctx context.Context, account *Account, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The account is reloaded under the lock to not lose a concurrent update.
+	account, err := s.Store.Get(ctx, account.Username)
+	if err != nil {
+		return err
+	}
+
+	values := append(slices.Clone(account.TXT), value)
+	if len(values) > maxTXTValues {
+		values = values[len(values)-maxTXTValues:]
+	}
+
+	records := make([]string, 0, len(values))
+	for _, v := range values {
+		records = append(records, desec.TXTRecord(v))
+	}
+
+	rrSet := desec.RRSet{
+		SubName: account.Subdomain,
+		Type:    desec.RRTypeTXT,
+		TTL:     s.TTL,
+		Records: records,
+	}
+
+	// The challenge is time-sensitive: it goes before the background work of a shared client (desec.PriorityScheduler).
+	_, err = s.Client.Records.BulkUpdate(desec.WithPriority(ctx, desec.PriorityHigh), desec.FullResource, s.Domain, []desec.RRSet{rrSet})
+	if err != nil {
+		return err
+	}
+
+	account.TXT = values
+
+	return s.Store.Save(ctx, account)
+}
+
+func (s *Server) logf(format string, args ...any) {
+	logger := s.Logger
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	logger.Printf(format, args...)
+}
+
+func newAccount(allowFrom []netip.Prefix) (*Account, string, error) {
+	username, err := newUUID()
+	if err != nil {
+		return nil, "", err
+	}
+
+	subdomain, err := newUUID()
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret := make([]byte, 30)
+
+	_, err = rand.Read(secret)
+	if err != nil {
+		return nil, "", err
+	}
+
+	password := base64.RawURLEncoding.EncodeToString(secret)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, "", err
+	}
+
+	account := &Account{
+		Username:     username,
+		PasswordHash: hash,
+		Subdomain:    subdomain,
+		AllowFrom:    allowFrom,
+	}
+
+	return account, password, nil
+}
+
+// newUUID generates a random (version 4) UUID.
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+func allowed(allowFrom []netip.Prefix, remoteAddr string) bool {
+	if len(allowFrom) == 0 {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+
+	for _, prefix := range allowFrom {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeError(rw http.ResponseWriter, statusCode int, msg string) {
+	writeJSON(rw, statusCode, map[string]string{"error": msg})
+}
+
+func writeJSON(rw http.ResponseWriter, statusCode int, v any) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(statusCode)
+
+	_ = json.NewEncoder(rw).Encode(v)
+}
@@ -0,0 +1,188 @@
+package acmedns
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nrdcg/desec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T) (*httptest.Server, *[][]desec.RRSet) {
+	t.Helper()
+
+	apiMux := http.NewServeMux()
+	apiServer := httptest.NewServer(apiMux)
+	t.Cleanup(apiServer.Close)
+
+	api := desec.New("token", desec.NewDefaultClientOptions())
+	api.BaseURL = apiServer.URL
+
+	var received [][]desec.RRSet
+
+	apiMux.HandleFunc("PUT /domains/acme.example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		var rrSets []desec.RRSet
+
+		err := json.NewDecoder(req.Body).Decode(&rrSets)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		received = append(received, rrSets)
+
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	server := httptest.NewServer(NewServer(api, "acme.example.com", NewMemoryStore()).Handler())
+	t.Cleanup(server.Close)
+
+	return server, &received
+}
+
+func register(t *testing.T, serverURL, body string) registerResponse {
+	t.Helper()
+
+	resp, err := http.Post(serverURL+"/register", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var account registerResponse
+
+	err = json.NewDecoder(resp.Body).Decode(&account)
+	require.NoError(t, err)
+
+	return account
+}
+
+func update(t *testing.T, serverURL string, account registerResponse, subdomain, txt string) (int, string) {
+	t.Helper()
+
+	body := `{"subdomain":"` + subdomain + `","txt":"` + txt + `"}`
+
+	req, err := http.NewRequest(http.MethodPost, serverURL+"/update", strings.NewReader(body))
+	require.NoError(t, err)
+
+	req.Header.Set("X-Api-User", account.Username)
+	req.Header.Set("X-Api-Key", account.Password)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	var result map[string]string
+
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	require.NoError(t, err)
+
+	return resp.StatusCode, result["txt"] + result["error"]
+}
+
+func TestServer(t *testing.T) {
+	server, received := setupTest(t)
+
+	account := register(t, server.URL, "")
+
+	assert.Len(t, account.Username, 36)
+	assert.Len(t, account.Password, 40)
+	assert.Equal(t, account.Subdomain+".acme.example.com", account.FullDomain)
+	assert.Empty(t, account.AllowFrom)
+
+	txt1 := strings.Repeat("a", 43)
+	txt2 := strings.Repeat("b", 43)
+	txt3 := strings.Repeat("c", 43)
+
+	for _, txt := range []string{txt1, txt2, txt3} {
+		status, msg := update(t, server.URL, account, account.Subdomain, txt)
+		require.Equal(t, http.StatusOK, status, msg)
+		assert.Equal(t, txt, msg)
+	}
+
+	expected := [][]desec.RRSet{
+		{{SubName: account.Subdomain, Type: "TXT", Records: []string{`"` + txt1 + `"`}}},
+		{{SubName: account.Subdomain, Type: "TXT", Records: []string{`"` + txt1 + `"`, `"` + txt2 + `"`}}},
+		{{SubName: account.Subdomain, Type: "TXT", Records: []string{`"` + txt2 + `"`, `"` + txt3 + `"`}}},
+	}
+	assert.Equal(t, expected, *received)
+}
+
+func TestServer_update_errors(t *testing.T) {
+	server, received := setupTest(t)
+
+	account := register(t, server.URL, "")
+	restricted := register(t, server.URL, `{"allowfrom":["192.0.2.0/24"]}`)
+
+	assert.Equal(t, []string{"192.0.2.0/24"}, restricted.AllowFrom)
+
+	testCases := []struct {
+		desc      string
+		account   registerResponse
+		subdomain string
+		txt       string
+		status    int
+		msg       string
+	}{
+		{
+			desc:      "bad password",
+			account:   registerResponse{Username: account.Username, Password: "invalid"},
+			subdomain: account.Subdomain,
+			txt:       strings.Repeat("a", 43),
+			status:    http.StatusUnauthorized,
+			msg:       "forbidden",
+		},
+		{
+			desc:      "other subdomain",
+			account:   account,
+			subdomain: restricted.Subdomain,
+			txt:       strings.Repeat("a", 43),
+			status:    http.StatusUnauthorized,
+			msg:       "forbidden",
+		},
+		{
+			desc:      "not allowed address",
+			account:   restricted,
+			subdomain: restricted.Subdomain,
+			txt:       strings.Repeat("a", 43),
+			status:    http.StatusUnauthorized,
+			msg:       "forbidden",
+		},
+		{
+			desc:      "bad txt",
+			account:   account,
+			subdomain: account.Subdomain,
+			txt:       "short",
+			status:    http.StatusBadRequest,
+			msg:       "bad_txt",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			status, msg := update(t, server.URL, test.account, test.subdomain, test.txt)
+
+			assert.Equal(t, test.status, status)
+			assert.Equal(t, test.msg, msg)
+		})
+	}
+
+	assert.Empty(t, *received)
+}
+
+func TestServer_register_invalidCIDR(t *testing.T) {
+	server, _ := setupTest(t)
+
+	resp, err := http.Post(server.URL+"/register", "application/json", strings.NewReader(`{"allowfrom":["invalid"]}`))
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
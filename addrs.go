@@ -0,0 +1,71 @@
+package desec
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+)
+
+// RRSetFromAddrs creates an A or AAAA RRSet from IP addresses.
+// The type of the RRSet is inferred from the address family, all the addresses must have the same family.
+func RRSetFromAddrs(domainName, subName string, ttl int, addrs ...netip.Addr) (RRSet, error) {
+	if len(addrs) == 0 {
+		return RRSet{}, errors.New("no addresses")
+	}
+
+	recordType := addrType(addrs[0])
+
+	records := make([]string, 0, len(addrs))
+
+	for _, addr := range addrs {
+		if !addr.IsValid() {
+			return RRSet{}, errors.New("invalid address")
+		}
+
+		if addrType(addr) != recordType {
+			return RRSet{}, fmt.Errorf("mixed address families: %s is not a valid %s record", addr, recordType)
+		}
+
+		records = append(records, addr.Unmap().String())
+	}
+
+	return RRSet{
+		Domain:  domainName,
+		SubName: subName,
+		Type:    recordType,
+		Records: records,
+		TTL:     ttl,
+	}, nil
+}
+
+// Addrs returns the IP addresses of an A or AAAA RRSet.
+func (r RRSet) Addrs() ([]netip.Addr, error) {
+	if r.Type != "A" && r.Type != "AAAA" {
+		return nil, fmt.Errorf("unsupported type: %s", r.Type)
+	}
+
+	addrs := make([]netip.Addr, 0, len(r.Records))
+
+	for _, record := range r.Records {
+		addr, err := netip.ParseAddr(record)
+		if err != nil {
+			return nil, err
+		}
+
+		if addrType(addr) != r.Type {
+			return nil, fmt.Errorf("%s is not a valid %s record", addr, r.Type)
+		}
+
+		addrs = append(addrs, addr.Unmap())
+	}
+
+	return addrs, nil
+}
+
+func addrType(addr netip.Addr) string {
+	if addr.Unmap().Is4() {
+		return "A"
+	}
+
+	return "AAAA"
+}
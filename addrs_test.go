@@ -0,0 +1,100 @@
+package desec
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRRSetFromAddrs(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		addrs    []netip.Addr
+		expected RRSet
+	}{
+		{
+			desc:  "IPv4",
+			addrs: []netip.Addr{netip.MustParseAddr("10.10.10.10"), netip.MustParseAddr("::ffff:10.10.10.11")},
+			expected: RRSet{
+				Domain:  "example.dedyn.io",
+				SubName: "www",
+				Type:    "A",
+				Records: []string{"10.10.10.10", "10.10.10.11"},
+				TTL:     3600,
+			},
+		},
+		{
+			desc:  "IPv6",
+			addrs: []netip.Addr{netip.MustParseAddr("2001:db8::1")},
+			expected: RRSet{
+				Domain:  "example.dedyn.io",
+				SubName: "www",
+				Type:    "AAAA",
+				Records: []string{"2001:db8::1"},
+				TTL:     3600,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rrSet, err := RRSetFromAddrs("example.dedyn.io", "www", 3600, test.addrs...)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, rrSet)
+		})
+	}
+}
+
+func TestRRSetFromAddrs_errors(t *testing.T) {
+	_, err := RRSetFromAddrs("example.dedyn.io", "www", 3600)
+	require.Error(t, err)
+
+	_, err = RRSetFromAddrs("example.dedyn.io", "www", 3600, netip.MustParseAddr("10.10.10.10"), netip.MustParseAddr("2001:db8::1"))
+	require.Error(t, err)
+
+	_, err = RRSetFromAddrs("example.dedyn.io", "www", 3600, netip.Addr{})
+	require.Error(t, err)
+}
+
+func TestRRSet_Addrs(t *testing.T) {
+	rrSet := RRSet{Type: "AAAA", Records: []string{"2001:db8::1", "2001:db8::2"}}
+
+	addrs, err := rrSet.Addrs()
+	require.NoError(t, err)
+
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("2001:db8::1"), netip.MustParseAddr("2001:db8::2")}, addrs)
+}
+
+func TestRRSet_Addrs_errors(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		rrSet RRSet
+	}{
+		{
+			desc:  "unsupported type",
+			rrSet: RRSet{Type: "TXT", Records: []string{`"txt"`}},
+		},
+		{
+			desc:  "family mismatch",
+			rrSet: RRSet{Type: "A", Records: []string{"2001:db8::1"}},
+		},
+		{
+			desc:  "invalid address",
+			rrSet: RRSet{Type: "A", Records: []string{"foo"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := test.rrSet.Addrs()
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package desec
+
+import "strings"
+
+// wildcardLabel the wildcard label.
+const wildcardLabel = "*"
+
+// NewApexRRSet creates a RRSet at the zone apex.
+// The subname of the apex is empty in the RRSets, ApexZone ("@") is only used in the URLs.
+func NewApexRRSet(domainName, recordType string, ttl int, records ...string) RRSet {
+	return RRSet{
+		Domain:  domainName,
+		SubName: "",
+		Type:    recordType,
+		Records: records,
+		TTL:     ttl,
+	}
+}
+
+// NewWildcardRRSet creates a wildcard RRSet covering the names below the subname:
+// "*" for the zone apex ("" or ApexZone), "*.<subname>" otherwise.
+// A subname already starting with the wildcard label is kept unchanged.
+func NewWildcardRRSet(domainName, subName, recordType string, ttl int, records ...string) RRSet {
+	return RRSet{
+		Domain:  domainName,
+		SubName: wildcardSubName(subName),
+		Type:    recordType,
+		Records: records,
+		TTL:     ttl,
+	}
+}
+
+func wildcardSubName(subName string) string {
+	subName = normalizeSubName(subName)
+
+	switch {
+	case subName == "":
+		return wildcardLabel
+	case subName == wildcardLabel, strings.HasPrefix(subName, wildcardLabel+"."):
+		return subName
+	default:
+		return wildcardLabel + "." + subName
+	}
+}
@@ -0,0 +1,44 @@
+package desec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewApexRRSet(t *testing.T) {
+	rrSet := NewApexRRSet("example.com", "MX", 3600, "10 mx1.example.com.", "20 mx2.example.com.")
+
+	expected := RRSet{
+		Domain:  "example.com",
+		Type:    "MX",
+		TTL:     3600,
+		Records: []string{"10 mx1.example.com.", "20 mx2.example.com."},
+	}
+	assert.Equal(t, expected, rrSet)
+}
+
+func TestNewWildcardRRSet(t *testing.T) {
+	testCases := []struct {
+		subName  string
+		expected string
+	}{
+		{subName: "", expected: "*"},
+		{subName: ApexZone, expected: "*"},
+		{subName: "www", expected: "*.www"},
+		{subName: "a.b", expected: "*.a.b"},
+		{subName: "*", expected: "*"},
+		{subName: "*.www", expected: "*.www"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.subName, func(t *testing.T) {
+			rrSet := NewWildcardRRSet("example.com", test.subName, "A", 3600, "10.10.10.10")
+
+			assert.Equal(t, test.expected, rrSet.SubName)
+			assert.Equal(t, []string{"10.10.10.10"}, rrSet.Records)
+
+			assert.NoError(t, ValidateSubName(rrSet.SubName))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package desec
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditRecord the audit record of a mutating API call (POST, PUT, PATCH, DELETE).
+type AuditRecord struct {
+	Time     time.Time `json:"time"`
+	Method   string    `json:"method"`
+	Endpoint string    `json:"endpoint"`
+	// Domain the domain of the endpoint, if any.
+	Domain string `json:"domain,omitempty"`
+	// RRSet the key of the RRSet of the endpoint (subname/type), if any. Empty for the bulk operations.
+	RRSet string `json:"rrset,omitempty"`
+	// StatusCode the HTTP status code of the response, 0 if the call failed.
+	StatusCode int `json:"status_code,omitempty"`
+	// Error the error of the call, if any.
+	Error string `json:"error,omitempty"`
+	// Success is true if the API accepted the call (2xx).
+	Success bool `json:"success"`
+}
+
+// auditDoer emits an AuditRecord for each mutating API call.
+type auditDoer struct {
+	next httpDoer
+	emit func(AuditRecord)
+}
+
+func (d auditDoer) Do(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return d.next.Do(req)
+	}
+
+	record := AuditRecord{
+		Time:     time.Now().UTC(),
+		Method:   req.Method,
+		Endpoint: req.URL.Path,
+	}
+
+	record.Domain, record.RRSet = auditTarget(req.URL.Path)
+
+	resp, err := d.next.Do(req)
+
+	if resp != nil {
+		record.StatusCode = resp.StatusCode
+		record.Success = resp.StatusCode >= 200 && resp.StatusCode < 300
+	}
+
+	if err != nil {
+		record.Error = err.Error()
+	}
+
+	d.emit(record)
+
+	return resp, err
+}
+
+// auditTarget extracts the domain and the RRSet key from the path of an endpoint: domains/{domain}/rrsets/{subname}/{type}/.
+func auditTarget(path string) (string, string) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, part := range parts {
+		if part != "domains" || i+1 >= len(parts) {
+			continue
+		}
+
+		domainName := parts[i+1]
+
+		if len(parts) >= i+5 && parts[i+2] == "rrsets" {
+			return domainName, NewRRSetKey(parts[i+3], parts[i+4]).String()
+		}
+
+		return domainName, ""
+	}
+
+	return "", ""
+}
+
+// newAuditEmitter returns the function emitting the audit records to the writer (JSON lines) and the callback,
+// or nil if both are nil.
+func newAuditEmitter(w io.Writer, callback func(AuditRecord)) func(AuditRecord) {
+	if w == nil && callback == nil {
+		return nil
+	}
+
+	var mu sync.Mutex
+
+	return func(record AuditRecord) {
+		if w != nil {
+			mu.Lock()
+			_ = json.NewEncoder(w).Encode(record)
+			mu.Unlock()
+		}
+
+		if callback != nil {
+			callback(record)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package desec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_audit(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	buf := &bytes.Buffer{}
+
+	var records []AuditRecord
+
+	opts := NewDefaultClientOptions()
+	opts.AuditLog = buf
+	opts.OnAudit = func(record AuditRecord) {
+		records = append(records, record)
+	}
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/example.com/rrsets/www/A/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"subname":"www","type":"A","records":["10.0.0.1"]}`))
+	})
+
+	mux.HandleFunc("DELETE /domains/example.com/rrsets/www/A/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("PUT /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = rw.Write([]byte(`{"detail":"invalid"}`))
+	})
+
+	_, err := client.Records.Get(context.Background(), "example.com", "www", "A")
+	require.NoError(t, err)
+
+	err = client.Records.Delete(context.Background(), "example.com", "www", "A")
+	require.NoError(t, err)
+
+	_, err = client.Records.BulkUpdate(context.Background(), FullResource, "example.com", []RRSet{{SubName: "www", Type: "A", Records: []string{}}})
+	require.Error(t, err)
+
+	require.Len(t, records, 2)
+
+	for i := range records {
+		assert.False(t, records[i].Time.IsZero())
+		records[i].Time = records[0].Time
+	}
+
+	expected := []AuditRecord{
+		{
+			Time:       records[0].Time,
+			Method:     http.MethodDelete,
+			Endpoint:   "/domains/example.com/rrsets/www/A/",
+			Domain:     "example.com",
+			RRSet:      "www/A",
+			StatusCode: http.StatusNoContent,
+			Success:    true,
+		},
+		{
+			Time:       records[0].Time,
+			Method:     http.MethodPut,
+			Endpoint:   "/domains/example.com/rrsets/",
+			Domain:     "example.com",
+			StatusCode: http.StatusBadRequest,
+		},
+	}
+	assert.Equal(t, expected, records)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var line AuditRecord
+
+	err = json.Unmarshal([]byte(lines[0]), &line)
+	require.NoError(t, err)
+
+	assert.Equal(t, "www/A", line.RRSet)
+}
+
+func Test_auditTarget(t *testing.T) {
+	testCases := []struct {
+		path    string
+		domain  string
+		rrSetID string
+	}{
+		{path: "/api/v1/domains/", domain: "", rrSetID: ""},
+		{path: "/api/v1/domains/example.com/", domain: "example.com", rrSetID: ""},
+		{path: "/api/v1/domains/example.com/rrsets/", domain: "example.com", rrSetID: ""},
+		{path: "/api/v1/domains/example.com/rrsets/@/TXT/", domain: "example.com", rrSetID: "/TXT"},
+		{path: "/api/v1/auth/tokens/", domain: "", rrSetID: ""},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.path, func(t *testing.T) {
+			t.Parallel()
+
+			domainName, rrSetID := auditTarget(test.path)
+
+			assert.Equal(t, test.domain, domainName)
+			assert.Equal(t, test.rrSetID, rrSetID)
+		})
+	}
+}
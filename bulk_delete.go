@@ -0,0 +1,60 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// BulkDeleteReport the result of BulkDeleteWithReport.
+type BulkDeleteReport struct {
+	// Deleted the RRSets deleted.
+	Deleted []RRSet
+	// NotFound the RRSets that didn't exist.
+	NotFound []RRSet
+	// Rejected the RRSets rejected by the API: nothing has been deleted.
+	Rejected []BulkItemError
+}
+
+// BulkDeleteWithReport deletes RRSets in bulk, and reports the RRSets deleted, the RRSets that didn't exist,
+// and the RRSets rejected by the API.
+// The current RRSets of the domain are retrieved before the deletion, only the existing RRSets are deleted.
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#bulk-deletion-of-rrsets
+func (s *RecordsService) BulkDeleteWithReport(ctx context.Context, domainName string, rrSets []RRSet) (*BulkDeleteReport, error) {
+	current, err := s.GetAll(ctx, domainName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current state: %w", err)
+	}
+
+	index := NewZone(current)
+
+	report := &BulkDeleteReport{}
+
+	var existing []RRSet
+
+	for _, rrSet := range rrSets {
+		if _, ok := index.Get(rrSet.SubName, rrSet.Type); ok {
+			existing = append(existing, rrSet)
+		} else {
+			report.NotFound = append(report.NotFound, rrSet)
+		}
+	}
+
+	if len(existing) == 0 {
+		return report, nil
+	}
+
+	err = s.BulkDelete(ctx, domainName, existing)
+	if err != nil {
+		var bulkErr *BulkValidationError
+		if errors.As(err, &bulkErr) {
+			report.Rejected = bulkErr.Invalid
+		}
+
+		return report, err
+	}
+
+	report.Deleted = existing
+
+	return report, nil
+}
@@ -0,0 +1,85 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsService_BulkDeleteWithReport(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"subname":"","type":"NS","records":["ns1.desec.io."]},{"subname":"www","type":"A","records":["10.0.0.1"]},{"subname":"","type":"TXT","records":["\"foo\""]}]`))
+	})
+
+	var received []RRSet
+
+	mux.HandleFunc("PUT /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(&received)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	rrSets := []RRSet{
+		{SubName: "www", Type: "A"},
+		{SubName: "@", Type: "TXT"},
+		{SubName: "missing", Type: "A"},
+	}
+
+	report, err := client.Records.BulkDeleteWithReport(context.Background(), "example.com", rrSets)
+	require.NoError(t, err)
+
+	expected := &BulkDeleteReport{
+		Deleted:  []RRSet{rrSets[0], rrSets[1]},
+		NotFound: []RRSet{rrSets[2]},
+	}
+	assert.Equal(t, expected, report)
+
+	assert.Equal(t, []RRSet{{SubName: "www", Type: "A", Records: []string{}}, {SubName: "", Type: "TXT", Records: []string{}}}, received)
+}
+
+func TestRecordsService_BulkDeleteWithReport_rejected(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"subname":"","type":"NS","records":["ns1.desec.io."]},{"subname":"www","type":"A","records":["10.0.0.1"]}]`))
+	})
+
+	mux.HandleFunc("PUT /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = rw.Write([]byte(`[{"non_field_errors":["You cannot delete the apex NS RRset."]},{}]`))
+	})
+
+	rrSets := []RRSet{
+		{SubName: "", Type: "NS"},
+		{SubName: "www", Type: "A"},
+	}
+
+	report, err := client.Records.BulkDeleteWithReport(context.Background(), "example.com", rrSets)
+	require.ErrorAs(t, err, new(*BulkValidationError))
+
+	require.Len(t, report.Rejected, 1)
+	assert.Equal(t, "NS", report.Rejected[0].RRSet.Type)
+	assert.Empty(t, report.Deleted)
+	assert.Empty(t, report.NotFound)
+}
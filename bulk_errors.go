@@ -0,0 +1,119 @@
+package desec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// BulkItemError the validation errors of a RRSet of a bulk request.
+type BulkItemError struct {
+	// Index the position of the RRSet in the bulk request.
+	Index int
+	RRSet RRSet
+	// Errors the error messages by field (ex: "records", "non_field_errors").
+	Errors map[string][]string
+}
+
+// BulkValidationError the API rejected a bulk request (400) because of invalid RRSets: none of the RRSets has been written.
+// The valid RRSets can be resubmitted without the invalid ones.
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#bulk-operations
+type BulkValidationError struct {
+	// Valid the RRSets without errors.
+	Valid []RRSet
+	// Invalid the RRSets with errors.
+	Invalid []BulkItemError
+}
+
+func (e BulkValidationError) Error() string {
+	var msgs []string
+
+	for _, item := range e.Invalid {
+		var fields []string
+		for field, errs := range item.Errors {
+			fields = append(fields, fmt.Sprintf("%s: %s", field, strings.Join(errs, " ")))
+		}
+
+		slices.Sort(fields)
+
+		msgs = append(msgs, fmt.Sprintf("#%d %s/%s: %s", item.Index, item.RRSet.SubName, item.RRSet.Type, strings.Join(fields, ", ")))
+	}
+
+	return fmt.Sprintf("%d invalid RRSets out of %d: %s", len(e.Invalid), len(e.Invalid)+len(e.Valid), strings.Join(msgs, "; "))
+}
+
+// handleBulkError returns a BulkValidationError if the API rejected some RRSets of a bulk request.
+// The API answers with an array of errors, one for each RRSet of the request.
+func handleBulkError(resp *http.Response, rrSets []RRSet) error {
+	if resp.StatusCode != http.StatusBadRequest {
+		return handleError(resp)
+	}
+
+	return withRequestContext(resp, func(resp *http.Response) error {
+		return readBulkError(resp, rrSets)
+	})
+}
+
+func readBulkError(resp *http.Response, rrSets []RRSet) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			err:        fmt.Errorf("failed to read response body: %w", err),
+		}
+	}
+
+	bulkErr, ok := parseBulkError(body, rrSets)
+	if !ok {
+		return &APIError{StatusCode: resp.StatusCode, err: fmt.Errorf("body: %s", string(body))}
+	}
+
+	return &APIError{StatusCode: resp.StatusCode, err: bulkErr}
+}
+
+func parseBulkError(body []byte, rrSets []RRSet) (*BulkValidationError, bool) {
+	var items []map[string]json.RawMessage
+
+	err := json.Unmarshal(body, &items)
+	if err != nil || len(items) != len(rrSets) {
+		return nil, false
+	}
+
+	bulkErr := &BulkValidationError{}
+
+	for i, item := range items {
+		if len(item) == 0 {
+			bulkErr.Valid = append(bulkErr.Valid, rrSets[i])
+			continue
+		}
+
+		itemErr := BulkItemError{Index: i, RRSet: rrSets[i], Errors: make(map[string][]string, len(item))}
+
+		for field, raw := range item {
+			itemErr.Errors[field] = errorMessages(raw)
+		}
+
+		bulkErr.Invalid = append(bulkErr.Invalid, itemErr)
+	}
+
+	if len(bulkErr.Invalid) == 0 {
+		return nil, false
+	}
+
+	return bulkErr, true
+}
+
+// errorMessages decodes the messages of a field: a list of strings, or any other JSON value kept as is.
+func errorMessages(raw json.RawMessage) []string {
+	var msgs []string
+
+	err := json.Unmarshal(raw, &msgs)
+	if err == nil {
+		return msgs
+	}
+
+	return []string{string(raw)}
+}
@@ -0,0 +1,78 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsService_BulkCreate_validationError(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = rw.Write([]byte(`[{},{"records":["Record content malformed: invalid"],"ttl":["Ensure this value is greater than or equal to 3600."]},{},{"non_field_errors":{"0":["nested"]}}]`))
+	})
+
+	rrSets := []RRSet{
+		{SubName: "a", Type: "A", TTL: 3600, Records: []string{"10.0.0.1"}},
+		{SubName: "b", Type: "A", TTL: 60, Records: []string{"invalid"}},
+		{SubName: "c", Type: "A", TTL: 3600, Records: []string{"10.0.0.3"}},
+		{SubName: "d", Type: "A", TTL: 3600, Records: []string{"10.0.0.4"}},
+	}
+
+	_, err := client.Records.BulkCreate(context.Background(), "example.com", rrSets)
+
+	var bulkErr *BulkValidationError
+	require.ErrorAs(t, err, &bulkErr)
+
+	assert.Equal(t, []RRSet{rrSets[0], rrSets[2]}, bulkErr.Valid)
+
+	expected := []BulkItemError{
+		{
+			Index: 1,
+			RRSet: rrSets[1],
+			Errors: map[string][]string{
+				"records": {"Record content malformed: invalid"},
+				"ttl":     {"Ensure this value is greater than or equal to 3600."},
+			},
+		},
+		{
+			Index:  3,
+			RRSet:  rrSets[3],
+			Errors: map[string][]string{"non_field_errors": {`{"0":["nested"]}`}},
+		},
+	}
+	assert.Equal(t, expected, bulkErr.Invalid)
+
+	assert.EqualError(t, err, `400: 2 invalid RRSets out of 4: `+
+		`#1 b/A: records: Record content malformed: invalid, ttl: Ensure this value is greater than or equal to 3600.; `+
+		`#3 d/A: non_field_errors: {"0":["nested"]}`)
+}
+
+func TestRecordsService_BulkUpdate_rawError(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = rw.Write([]byte(`{"detail":"JSON parse error"}`))
+	})
+
+	_, err := client.Records.BulkUpdate(context.Background(), FullResource, "example.com", []RRSet{{SubName: "a", Type: "A", Records: []string{}}})
+	require.EqualError(t, err, `400: body: {"detail":"JSON parse error"}`)
+	require.NotErrorAs(t, err, new(*BulkValidationError))
+}
@@ -0,0 +1,62 @@
+package desec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// CanonicalRecord returns the canonical form of a record value, to compare it with the values normalized by the API:
+// the hostnames are lowercased, the IPv6 addresses are compressed, the CAA tags are lowercased and their values quoted,
+// and the TXT strings are quoted.
+//
+//	CanonicalRecord("AAAA", "2001:0db8:0000::0001") // "2001:db8::1"
+//	CanonicalRecord("CAA", "0 ISSUE letsencrypt.org") // `0 issue "letsencrypt.org"`
+func CanonicalRecord(recordType, record string) (string, error) {
+	rr, err := dns.NewRR(fmt.Sprintf(". 0 IN %s %s", recordType, record))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse record %q: %w", record, err)
+	}
+
+	if rr == nil {
+		return "", fmt.Errorf("empty record: %q", record)
+	}
+
+	canonicalizeRR(rr)
+
+	return rdata(rr), nil
+}
+
+// canonicalizeRR normalizes the fields of a resource record not normalized by miekg/dns.
+func canonicalizeRR(rr dns.RR) {
+	switch v := rr.(type) {
+	case *dns.CNAME:
+		v.Target = strings.ToLower(v.Target)
+	case *dns.DNAME:
+		v.Target = strings.ToLower(v.Target)
+	case *dns.NS:
+		v.Ns = strings.ToLower(v.Ns)
+	case *dns.PTR:
+		v.Ptr = strings.ToLower(v.Ptr)
+	case *dns.MX:
+		v.Mx = strings.ToLower(v.Mx)
+	case *dns.SRV:
+		v.Target = strings.ToLower(v.Target)
+	case *dns.SVCB:
+		v.Target = strings.ToLower(v.Target)
+	case *dns.HTTPS:
+		v.Target = strings.ToLower(v.Target)
+	case *dns.NAPTR:
+		v.Replacement = strings.ToLower(v.Replacement)
+	case *dns.KX:
+		v.Exchanger = strings.ToLower(v.Exchanger)
+	case *dns.AFSDB:
+		v.Hostname = strings.ToLower(v.Hostname)
+	case *dns.RP:
+		v.Mbox = strings.ToLower(v.Mbox)
+		v.Txt = strings.ToLower(v.Txt)
+	case *dns.CAA:
+		v.Tag = strings.ToLower(v.Tag)
+	}
+}
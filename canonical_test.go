@@ -0,0 +1,58 @@
+package desec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalRecord(t *testing.T) {
+	testCases := []struct {
+		recordType string
+		record     string
+		expected   string
+	}{
+		{recordType: "A", record: "10.0.0.1", expected: "10.0.0.1"},
+		{recordType: "AAAA", record: "2001:0db8:0000:0000:0000:0000:0000:0001", expected: "2001:db8::1"},
+		{recordType: "CNAME", record: "WWW.Example.COM.", expected: "www.example.com."},
+		{recordType: "MX", record: "10 Mail.Example.com.", expected: "10 mail.example.com."},
+		{recordType: "SRV", record: "0 5 443 Host.Example.com.", expected: "0 5 443 host.example.com."},
+		{recordType: "HTTPS", record: "1 Host.Example.com. alpn=h2", expected: `1 host.example.com. alpn="h2"`},
+		{recordType: "CAA", record: "0 ISSUE letsencrypt.org", expected: `0 issue "letsencrypt.org"`},
+		{recordType: "CAA", record: `0 issue "letsencrypt.org"`, expected: `0 issue "letsencrypt.org"`},
+		{recordType: "TXT", record: "foo", expected: `"foo"`},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.recordType+" "+test.record, func(t *testing.T) {
+			t.Parallel()
+
+			record, err := CanonicalRecord(test.recordType, test.record)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, record)
+		})
+	}
+}
+
+func TestCanonicalRecord_error(t *testing.T) {
+	_, err := CanonicalRecord("AAAA", "invalid")
+	require.Error(t, err)
+}
+
+func TestDiffZone_canonical(t *testing.T) {
+	current := []RRSet{
+		{SubName: "", Type: "AAAA", TTL: 3600, Records: []string{"2001:db8::1"}},
+		{SubName: "", Type: "CAA", TTL: 3600, Records: []string{`0 issue "letsencrypt.org"`}},
+		{SubName: "www", Type: "CNAME", TTL: 3600, Records: []string{"example.com."}},
+	}
+
+	desired := []RRSet{
+		{SubName: "", Type: "AAAA", TTL: 3600, Records: []string{"2001:0db8:0000::0001"}},
+		{SubName: "", Type: "CAA", TTL: 3600, Records: []string{"0 ISSUE letsencrypt.org"}},
+		{SubName: "www", Type: "CNAME", TTL: 3600, Records: []string{"Example.COM."}},
+	}
+
+	assert.Empty(t, DiffZone("example.com", current, desired))
+}
@@ -0,0 +1,186 @@
+package desec
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// CaptchaSolver solves a captcha.
+type CaptchaSolver interface {
+	Solve(ctx context.Context, captcha *Captcha) (string, error)
+}
+
+// CaptchaSolverFunc a function implementing CaptchaSolver.
+type CaptchaSolverFunc func(ctx context.Context, captcha *Captcha) (string, error)
+
+// Solve implements CaptchaSolver.
+func (f CaptchaSolverFunc) Solve(ctx context.Context, captcha *Captcha) (string, error) {
+	return f(ctx, captcha)
+}
+
+// CaptchaKindSolver a CaptchaSolver that selects the kind of the captcha to solve (default: image).
+type CaptchaKindSolver interface {
+	CaptchaSolver
+	CaptchaKind() CaptchaKind
+}
+
+// InteractiveCaptchaSolver saves the captcha (image or audio) in a file, and reads the solution typed by the user.
+type InteractiveCaptchaSolver struct {
+	// In the input of the solution (default: os.Stdin).
+	In io.Reader
+	// Out the output of the instructions (default: os.Stdout).
+	Out io.Writer
+	// File the file where the captcha is saved (default: temporary file).
+	File string
+	// Kind the kind of captcha (default: CaptchaImage).
+	Kind CaptchaKind
+}
+
+// CaptchaKind implements CaptchaKindSolver.
+func (s *InteractiveCaptchaSolver) CaptchaKind() CaptchaKind {
+	if s.Kind == "" {
+		return CaptchaImage
+	}
+
+	return s.Kind
+}
+
+// Solve implements CaptchaSolver.
+func (s *InteractiveCaptchaSolver) Solve(_ context.Context, captcha *Captcha) (string, error) {
+	in, out := s.In, s.Out
+	if in == nil {
+		in = os.Stdin
+	}
+
+	if out == nil {
+		out = os.Stdout
+	}
+
+	kind := captcha.Kind
+	if kind == "" {
+		kind = s.CaptchaKind()
+	}
+
+	filename, err := saveCaptcha(captcha, kind, s.File)
+	if err != nil {
+		return "", err
+	}
+
+	_, _ = fmt.Fprintf(out, "The captcha %s is saved in %s\nCaptcha solution: ", kind, filename)
+
+	solution, err := readLine(in)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the captcha solution: %w", err)
+	}
+
+	if solution == "" {
+		return "", errors.New("empty captcha solution")
+	}
+
+	return solution, nil
+}
+
+// saveCaptcha writes the captcha (base64 PNG or WAV), and returns the name of the file.
+func saveCaptcha(captcha *Captcha, kind CaptchaKind, filename string) (string, error) {
+	image, err := base64.StdEncoding.DecodeString(captcha.Challenge)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode captcha: %w", err)
+	}
+
+	if filename != "" {
+		return filename, os.WriteFile(filename, image, 0o600)
+	}
+
+	pattern := "desec-captcha-*.png"
+	if kind == CaptchaAudio {
+		pattern = "desec-captcha-*.wav"
+	}
+
+	file, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	_, err = file.Write(image)
+	if err != nil {
+		return "", err
+	}
+
+	return file.Name(), nil
+}
+
+// readLine reads a line byte by byte: the reader can be shared with other readers.
+func readLine(r io.Reader) (string, error) {
+	var sb strings.Builder
+
+	buf := make([]byte, 1)
+
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				break
+			}
+
+			sb.WriteByte(buf[0])
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return strings.TrimSpace(sb.String()), nil
+}
+
+// RegisterWithSolver registers an account, the captcha is obtained and solved by the solver.
+// https://desec.readthedocs.io/en/latest/auth/account.html#register-account
+func (s *AccountService) RegisterWithSolver(ctx context.Context, email, password string, solver CaptchaSolver) error {
+	captcha, err := s.solveCaptcha(ctx, solver)
+	if err != nil {
+		return err
+	}
+
+	return s.Register(ctx, Registration{Email: email, Password: password, Captcha: captcha})
+}
+
+// PasswordResetWithSolver requests a password reset, the captcha is obtained and solved by the solver.
+// https://desec.readthedocs.io/en/latest/auth/account.html#password-reset
+func (s *AccountService) PasswordResetWithSolver(ctx context.Context, email string, solver CaptchaSolver) error {
+	captcha, err := s.solveCaptcha(ctx, solver)
+	if err != nil {
+		return err
+	}
+
+	return s.PasswordReset(ctx, email, *captcha)
+}
+
+func (s *AccountService) solveCaptcha(ctx context.Context, solver CaptchaSolver) (*Captcha, error) {
+	var kind CaptchaKind
+	if k, ok := solver.(CaptchaKindSolver); ok {
+		kind = k.CaptchaKind()
+	}
+
+	captcha, err := s.ObtainCaptchaOfKind(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	solution, err := solver.Solve(ctx, captcha)
+	if err != nil {
+		return nil, fmt.Errorf("failed to solve captcha: %w", err)
+	}
+
+	return &Captcha{ID: captcha.ID, Solution: solution}, nil
+}
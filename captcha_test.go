@@ -0,0 +1,149 @@
+package desec
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountService_RegisterWithSolver(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/captcha/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"id":"captcha-id","challenge":"` + base64.StdEncoding.EncodeToString([]byte("png")) + `"}`))
+	})
+
+	var registration Registration
+
+	mux.HandleFunc("/auth/", func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(&registration)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusAccepted)
+	})
+
+	solver := CaptchaSolverFunc(func(_ context.Context, captcha *Captcha) (string, error) {
+		return "solution-of-" + captcha.ID, nil
+	})
+
+	err := client.Account.RegisterWithSolver(context.Background(), "email@example.com", "secret", solver)
+	require.ErrorAs(t, err, new(*PendingConfirmationError))
+
+	expected := Registration{
+		Email:    "email@example.com",
+		Password: "secret",
+		Captcha:  &Captcha{ID: "captcha-id", Solution: "solution-of-captcha-id"},
+	}
+	assert.Equal(t, expected, registration)
+}
+
+func TestInteractiveCaptchaSolver_Solve(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "captcha.png")
+
+	out := new(bytes.Buffer)
+
+	solver := &InteractiveCaptchaSolver{
+		In:   strings.NewReader("12H45\nnext line\n"),
+		Out:  out,
+		File: file,
+	}
+
+	solution, err := solver.Solve(context.Background(), &Captcha{ID: "id", Challenge: base64.StdEncoding.EncodeToString([]byte("png"))})
+	require.NoError(t, err)
+
+	assert.Equal(t, "12H45", solution)
+	assert.Contains(t, out.String(), file)
+
+	image, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	assert.Equal(t, "png", string(image))
+}
+
+func TestInteractiveCaptchaSolver_Solve_empty(t *testing.T) {
+	solver := &InteractiveCaptchaSolver{
+		In:   strings.NewReader("\n"),
+		Out:  new(bytes.Buffer),
+		File: filepath.Join(t.TempDir(), "captcha.png"),
+	}
+
+	_, err := solver.Solve(context.Background(), &Captcha{ID: "id"})
+	require.Error(t, err)
+}
+
+func TestAccountService_ObtainCaptchaOfKind(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var bodies []string
+
+	mux.HandleFunc("POST /captcha/", func(rw http.ResponseWriter, req *http.Request) {
+		var body bytes.Buffer
+		_, _ = body.ReadFrom(req.Body)
+
+		bodies = append(bodies, body.String())
+
+		_, _ = rw.Write([]byte(`{"id":"captcha-id","kind":"audio","challenge":"d2F2"}`))
+	})
+
+	captcha, err := client.Account.ObtainCaptchaOfKind(context.Background(), CaptchaAudio)
+	require.NoError(t, err)
+
+	assert.Equal(t, &Captcha{ID: "captcha-id", Kind: CaptchaAudio, Challenge: "d2F2"}, captcha)
+
+	_, err = client.Account.ObtainCaptcha(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, bodies, 2)
+	assert.JSONEq(t, `{"kind":"audio"}`, bodies[0])
+	assert.Empty(t, bodies[1])
+}
+
+func TestInteractiveCaptchaSolver_Solve_audio(t *testing.T) {
+	out := new(bytes.Buffer)
+
+	solver := &InteractiveCaptchaSolver{
+		In:   strings.NewReader("12H45\n"),
+		Out:  out,
+		Kind: CaptchaAudio,
+	}
+
+	solution, err := solver.Solve(context.Background(), &Captcha{ID: "id", Kind: CaptchaAudio, Challenge: base64.StdEncoding.EncodeToString([]byte("wav"))})
+	require.NoError(t, err)
+
+	assert.Equal(t, "12H45", solution)
+
+	filename, _, ok := strings.Cut(strings.TrimPrefix(out.String(), "The captcha audio is saved in "), "\n")
+	require.True(t, ok)
+
+	t.Cleanup(func() { _ = os.Remove(filename) })
+
+	assert.Equal(t, ".wav", filepath.Ext(filename))
+
+	audio, err := os.ReadFile(filename)
+	require.NoError(t, err)
+
+	assert.Equal(t, "wav", string(audio))
+}
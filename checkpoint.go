@@ -0,0 +1,136 @@
+package desec
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Checkpoint the state of an interrupted BulkCreateChunked.
+type Checkpoint struct {
+	Domain string `json:"domain"`
+	// Fingerprint identifies the RRSets and the chunk size of the import.
+	Fingerprint string `json:"fingerprint"`
+	// Chunks the number of applied chunks.
+	Chunks int `json:"chunks"`
+}
+
+// CheckpointStore stores the checkpoint of an import (BulkCreateChunkedOptions.Checkpoints).
+// A store holds the checkpoint of a single import.
+type CheckpointStore interface {
+	// Load returns the checkpoint, nil if there is none.
+	Load(ctx context.Context) (*Checkpoint, error)
+	// Save saves the checkpoint.
+	Save(ctx context.Context, checkpoint Checkpoint) error
+	// Clear removes the checkpoint, once the import is complete.
+	Clear(ctx context.Context) error
+}
+
+// FileCheckpointStore stores the checkpoint in a JSON file.
+type FileCheckpointStore struct {
+	Path string
+}
+
+// NewFileCheckpointStore creates a new FileCheckpointStore.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{Path: path}
+}
+
+// Load implements CheckpointStore.
+func (s *FileCheckpointStore) Load(_ context.Context) (*Checkpoint, error) {
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var checkpoint Checkpoint
+
+	err = json.Unmarshal(data, &checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint: %w", err)
+	}
+
+	return &checkpoint, nil
+}
+
+// Save implements CheckpointStore.
+// The file is replaced atomically, an interruption during the save keeps the previous checkpoint.
+func (s *FileCheckpointStore) Save(_ context.Context, checkpoint Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	_, err = file.Write(data)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	err = os.Rename(file.Name(), s.Path)
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// Clear implements CheckpointStore.
+func (s *FileCheckpointStore) Clear(_ context.Context) error {
+	err := os.Remove(s.Path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// importFingerprint identifies the RRSets and the chunk size of an import:
+// a checkpoint is only resumed by the same import.
+func importFingerprint(rrSets []RRSet, chunkSize int) (string, error) {
+	hash := sha256.New()
+
+	_, _ = fmt.Fprintf(hash, "%d\n", chunkSize)
+
+	err := json.NewEncoder(hash).Encode(rrSets)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute import fingerprint: %w", err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// resumeChunks returns the number of chunks already applied by an interrupted import.
+func resumeChunks(ctx context.Context, store CheckpointStore, domainName, fingerprint string) (int, error) {
+	checkpoint, err := store.Load(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if checkpoint == nil || checkpoint.Domain != domainName || checkpoint.Fingerprint != fingerprint {
+		return 0, nil
+	}
+
+	return checkpoint.Chunks, nil
+}
@@ -0,0 +1,114 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsService_BulkCreateChunked_checkpoints(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var (
+		received []string
+		fail     = true
+	)
+
+	mux.HandleFunc("POST /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		var rrSets []RRSet
+
+		err := json.NewDecoder(req.Body).Decode(&rrSets)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if fail && len(received) == 2 {
+			http.Error(rw, "interrupted", http.StatusInternalServerError)
+			return
+		}
+
+		for _, rrSet := range rrSets {
+			received = append(received, rrSet.SubName)
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(rw).Encode(rrSets)
+	})
+
+	var rrSets []RRSet
+	for i := range 5 {
+		rrSets = append(rrSets, RRSet{SubName: fmt.Sprintf("host%d", i), Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}})
+	}
+
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "import.json"))
+
+	opts := &BulkCreateChunkedOptions{ChunkSize: 2, Checkpoints: store}
+
+	_, err := client.Records.BulkCreateChunked(context.Background(), "example.com", rrSets, opts)
+	require.Error(t, err)
+
+	checkpoint, err := store.Load(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, checkpoint)
+
+	assert.Equal(t, "example.com", checkpoint.Domain)
+	assert.Equal(t, 1, checkpoint.Chunks)
+
+	fail = false
+
+	var progress []Progress
+
+	opts.Progress = func(p Progress) { progress = append(progress, p) }
+
+	created, err := client.Records.BulkCreateChunked(context.Background(), "example.com", rrSets, opts)
+	require.NoError(t, err)
+
+	assert.Len(t, created, 3)
+	assert.Equal(t, []string{"host0", "host1", "host2", "host3", "host4"}, received)
+
+	expected := []Progress{
+		{Done: 4, Total: 5, Chunk: 2, Chunks: 3},
+		{Done: 5, Total: 5, Chunk: 3, Chunks: 3},
+	}
+	assert.Equal(t, expected, progress)
+
+	checkpoint, err = store.Load(context.Background())
+	require.NoError(t, err)
+
+	assert.Nil(t, checkpoint)
+}
+
+func TestResumeChunks_otherImport(t *testing.T) {
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "import.json"))
+
+	err := store.Save(context.Background(), Checkpoint{Domain: "example.com", Fingerprint: "abc", Chunks: 3})
+	require.NoError(t, err)
+
+	chunks, err := resumeChunks(context.Background(), store, "example.com", "abc")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, chunks)
+
+	chunks, err = resumeChunks(context.Background(), store, "example.com", "def")
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, chunks)
+
+	chunks, err = resumeChunks(context.Background(), store, "example.org", "abc")
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, chunks)
+}
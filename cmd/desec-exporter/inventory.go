@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/nrdcg/desec"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "desec"
+
+// inventory collects the inventory of a deSEC account.
+// The API is called periodically (update), not on each scrape, to preserve the rate limits.
+type inventory struct {
+	client *desec.Client
+	dnssec bool
+
+	domains       prometheus.Gauge
+	rrSets        *prometheus.GaugeVec
+	dnssecOK      *prometheus.GaugeVec
+	tokens        prometheus.Gauge
+	updateSuccess prometheus.Gauge
+	lastUpdate    prometheus.Gauge
+
+	mu sync.Mutex
+}
+
+func newInventory(client *desec.Client, dnssec bool) *inventory {
+	return &inventory{
+		client: client,
+		dnssec: dnssec,
+		domains: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "domains",
+			Help:      "Number of domains.",
+		}),
+		rrSets: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "rrsets",
+			Help:      "Number of RRSets, by domain and type.",
+		}, []string{"domain", "type"}),
+		dnssecOK: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "dnssec_ok",
+			Help:      "1 if the DNSSEC chain of the domain is valid, 0 otherwise.",
+		}, []string{"domain"}),
+		tokens: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "tokens",
+			Help:      "Number of tokens.",
+		}),
+		updateSuccess: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "inventory_update_success",
+			Help:      "1 if the last inventory update succeeded, 0 otherwise.",
+		}),
+		lastUpdate: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "inventory_last_update_timestamp_seconds",
+			Help:      "Timestamp of the last successful inventory update.",
+		}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (i *inventory) Describe(ch chan<- *prometheus.Desc) {
+	i.domains.Describe(ch)
+	i.rrSets.Describe(ch)
+	i.dnssecOK.Describe(ch)
+	i.tokens.Describe(ch)
+	i.updateSuccess.Describe(ch)
+	i.lastUpdate.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (i *inventory) Collect(ch chan<- prometheus.Metric) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.domains.Collect(ch)
+	i.rrSets.Collect(ch)
+	i.dnssecOK.Collect(ch)
+	i.tokens.Collect(ch)
+	i.updateSuccess.Collect(ch)
+	i.lastUpdate.Collect(ch)
+}
+
+// run updates the inventory periodically until the context is canceled.
+func (i *inventory) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := i.update(ctx)
+		if err != nil {
+			log.Printf("inventory update: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (i *inventory) update(ctx context.Context) error {
+	snapshot, err := i.fetch(ctx)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if err != nil {
+		i.updateSuccess.Set(0)
+		return err
+	}
+
+	i.domains.Set(float64(len(snapshot.domains)))
+	i.tokens.Set(float64(snapshot.tokens))
+
+	i.rrSets.Reset()
+
+	for domain, types := range snapshot.rrSets {
+		for rrType, count := range types {
+			i.rrSets.WithLabelValues(domain, rrType).Set(float64(count))
+		}
+	}
+
+	i.dnssecOK.Reset()
+
+	for domain, ok := range snapshot.dnssecOK {
+		i.dnssecOK.WithLabelValues(domain).Set(boolToFloat(ok))
+	}
+
+	i.updateSuccess.Set(1)
+	i.lastUpdate.SetToCurrentTime()
+
+	return nil
+}
+
+type snapshot struct {
+	domains  []desec.Domain
+	rrSets   map[string]map[string]int
+	dnssecOK map[string]bool
+	tokens   int
+}
+
+func (i *inventory) fetch(ctx context.Context) (*snapshot, error) {
+	domains, err := desec.NewDomainCache(i.client.Domains, 0).Domains(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &snapshot{
+		domains:  domains,
+		rrSets:   make(map[string]map[string]int),
+		dnssecOK: make(map[string]bool),
+	}
+
+	for _, domain := range domains {
+		rrSets, err := i.client.Records.GetAll(ctx, domain.Name, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		types := make(map[string]int)
+		for _, rrSet := range rrSets {
+			types[rrSet.Type]++
+		}
+
+		result.rrSets[domain.Name] = types
+
+		if !i.dnssec {
+			continue
+		}
+
+		report, err := i.client.Domains.VerifyDNSSEC(ctx, domain.Name)
+		if err != nil {
+			log.Printf("DNSSEC verification of %s: %v", domain.Name, err)
+			result.dnssecOK[domain.Name] = false
+
+			continue
+		}
+
+		result.dnssecOK[domain.Name] = report.OK()
+	}
+
+	tokens, err := i.client.Tokens.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result.tokens = len(tokens)
+
+	return result, nil
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nrdcg/desec"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventory_update(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"name":"example.com"},{"name":"example.org"}]`))
+	})
+
+	mux.HandleFunc("/domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"subname":"","type":"A","records":["10.10.10.10"]},{"subname":"www","type":"A","records":["10.10.10.10"]},{"subname":"","type":"NS","records":["ns1.desec.io."]}]`))
+	})
+
+	mux.HandleFunc("/domains/example.org/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"subname":"","type":"NS","records":["ns1.desec.io."]}]`))
+	})
+
+	mux.HandleFunc("/auth/tokens/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"id":"1","name":"a"},{"id":"2","name":"b"},{"id":"3","name":"c"}]`))
+	})
+
+	client := desec.New("token", desec.NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	inv := newInventory(client, false)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(inv)
+
+	err := inv.update(context.Background())
+	require.NoError(t, err)
+
+	expected := `
+# HELP desec_domains Number of domains.
+# TYPE desec_domains gauge
+desec_domains 2
+# HELP desec_inventory_update_success 1 if the last inventory update succeeded, 0 otherwise.
+# TYPE desec_inventory_update_success gauge
+desec_inventory_update_success 1
+# HELP desec_rrsets Number of RRSets, by domain and type.
+# TYPE desec_rrsets gauge
+desec_rrsets{domain="example.com",type="A"} 2
+desec_rrsets{domain="example.com",type="NS"} 1
+desec_rrsets{domain="example.org",type="NS"} 1
+# HELP desec_tokens Number of tokens.
+# TYPE desec_tokens gauge
+desec_tokens 3
+`
+
+	err = testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"desec_domains", "desec_inventory_update_success", "desec_rrsets", "desec_tokens")
+	require.NoError(t, err)
+}
+
+func TestInventory_update_error(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "error", http.StatusBadRequest)
+	})
+
+	client := desec.New("token", desec.NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	inv := newInventory(client, false)
+
+	err := inv.update(context.Background())
+	require.Error(t, err)
+
+	require.InDelta(t, 0, testutil.ToFloat64(inv.updateSuccess), 0)
+}
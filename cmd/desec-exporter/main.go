@@ -0,0 +1,72 @@
+// Command desec-exporter exposes the inventory of a deSEC account as Prometheus metrics:
+// domain counts, RRSet counts per type, DNSSEC status, and token counts.
+//
+// The client is configured through the environment (DESEC_TOKEN, see desec.NewFromEnv).
+//
+//	desec-exporter -listen :9776 -interval 5m -dnssec
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/nrdcg/desec"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+func main() {
+	listen := flag.String("listen", ":9776", "address of the metrics server")
+	interval := flag.Duration("interval", 5*time.Minute, "interval between two inventory updates")
+	dnssec := flag.Bool("dnssec", false, "verify the DNSSEC chain of the domains")
+	flag.Parse()
+
+	err := run(*listen, *interval, *dnssec)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(listen string, interval time.Duration, dnssec bool) error {
+	client, err := desec.NewFromEnv()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	registry := prometheus.NewRegistry()
+
+	inv := newInventory(client, dnssec)
+	registry.MustRegister(inv)
+
+	go inv.run(ctx, interval)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	server := &http.Server{
+		Addr:              listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background())
+	}()
+
+	err = server.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/nrdcg/desec"
+)
+
+const accountUsage = `Usage: desec account <subcommand> [flags]
+
+Subcommands:
+  register  register an account (interactive captcha), log in, and store the token in the keyring
+`
+
+func (a *app) account(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(a.stderr, accountUsage)
+		return errUsage
+	}
+
+	switch args[0] {
+	case "register":
+		return a.accountRegister(ctx, args[1:])
+	default:
+		_, _ = fmt.Fprintf(a.stderr, "Unknown subcommand: %s\n\n%s", args[0], accountUsage)
+		return errUsage
+	}
+}
+
+func (a *app) accountRegister(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("account register")
+	email := fs.String("email", "", "email address (required)")
+	captchaFile := fs.String("captcha-file", "", "file where the captcha is saved (default: temporary file)")
+	captchaKind := fs.String("captcha-kind", string(desec.CaptchaImage), "kind of captcha: image (PNG) or audio (WAV)")
+
+	err := parseFlags(fs, args, "email")
+	if err != nil {
+		return err
+	}
+
+	kind := desec.CaptchaKind(*captchaKind)
+	if kind != desec.CaptchaImage && kind != desec.CaptchaAudio {
+		return fmt.Errorf("unknown captcha kind: %s", kind)
+	}
+
+	// The password is not a flag: it would be visible in the process list.
+	password := os.Getenv("DESEC_PASSWORD")
+	if password == "" {
+		password, err = a.readPassword("Password: ")
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := a.newAnonymousClient()
+	if err != nil {
+		return err
+	}
+
+	solver := &desec.InteractiveCaptchaSolver{In: a.stdinReader(), Out: a.stdout, File: *captchaFile, Kind: kind}
+
+	err = client.Account.RegisterWithSolver(ctx, *email, password, solver)
+	if err != nil && !errors.As(err, new(*desec.PendingConfirmationError)) {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(a.stdout, "A confirmation link has been sent to %s.\n", *email)
+
+	a.prompt("Press Enter once the registration is confirmed...")
+
+	token, err := client.Account.Login(ctx, *email, password)
+	if err != nil {
+		return err
+	}
+
+	err = a.tokenStore.Set(token.Value)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(a.stdout, "Registered and logged in, the token is stored in the keyring.")
+
+	return nil
+}
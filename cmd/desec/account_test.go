@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nrdcg/desec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_accountRegister(t *testing.T) {
+	a, mux, stdout := setupApp(t)
+
+	store := &memoryTokenStore{}
+	a.tokenStore = store
+
+	mux.HandleFunc("/captcha/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"id":"captcha-id","kind":"image","challenge":"` + base64.StdEncoding.EncodeToString([]byte("png")) + `"}`))
+	})
+
+	var registration desec.Registration
+
+	mux.HandleFunc("/auth/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "" {
+			http.Error(rw, "unexpected token", http.StatusBadRequest)
+			return
+		}
+
+		err := json.NewDecoder(req.Body).Decode(&registration)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusAccepted)
+	})
+
+	mux.HandleFunc("/auth/login/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"id":"token-id","token":"secret"}`))
+	})
+
+	captchaFile := filepath.Join(t.TempDir(), "captcha.png")
+
+	a.stdin = strings.NewReader("s3cret\nabcde\n\n")
+
+	err := a.run(context.Background(), []string{"account", "register", "-email", "email@example.com", "-captcha-file", captchaFile})
+	require.NoError(t, err)
+
+	image, err := os.ReadFile(captchaFile)
+	require.NoError(t, err)
+
+	assert.Equal(t, "png", string(image))
+
+	expected := desec.Registration{
+		Email:    "email@example.com",
+		Password: "s3cret",
+		Captcha:  &desec.Captcha{ID: "captcha-id", Solution: "abcde"},
+	}
+	assert.Equal(t, expected, registration)
+
+	assert.Equal(t, "secret", store.token)
+	assert.Contains(t, stdout.String(), "Registered and logged in, the token is stored in the keyring.")
+	assert.NotContains(t, stdout.String(), "secret")
+}
+
+func TestApp_accountRegister_audio(t *testing.T) {
+	a, mux, stdout := setupApp(t)
+
+	store := &memoryTokenStore{}
+	a.tokenStore = store
+
+	var kind desec.CaptchaKind
+
+	mux.HandleFunc("/captcha/", func(rw http.ResponseWriter, req *http.Request) {
+		var captcha desec.Captcha
+		_ = json.NewDecoder(req.Body).Decode(&captcha)
+
+		kind = captcha.Kind
+
+		_, _ = rw.Write([]byte(`{"id":"captcha-id","kind":"audio","challenge":"` + base64.StdEncoding.EncodeToString([]byte("wav")) + `"}`))
+	})
+
+	mux.HandleFunc("/auth/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusAccepted)
+	})
+
+	mux.HandleFunc("/auth/login/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"id":"token-id","token":"secret"}`))
+	})
+
+	// The captcha is saved in a temporary file.
+	t.Setenv("TMPDIR", t.TempDir())
+
+	a.stdin = strings.NewReader("s3cret\nabcde\n\n")
+
+	err := a.run(context.Background(), []string{"account", "register", "-email", "email@example.com", "-captcha-kind", "audio"})
+	require.NoError(t, err)
+
+	assert.Equal(t, desec.CaptchaAudio, kind)
+	assert.Regexp(t, `The captcha audio is saved in \S+\.wav`, stdout.String())
+	assert.Equal(t, "secret", store.token)
+
+	err = a.run(context.Background(), []string{"account", "register", "-email", "email@example.com", "-captcha-kind", "video"})
+	require.EqualError(t, err, "unknown captcha kind: video")
+}
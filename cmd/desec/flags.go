@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// stringsFlag a repeatable string flag.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func (a *app) newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+
+	return fs
+}
+
+// parseFlags parses the flags and checks the required ones.
+func parseFlags(fs *flag.FlagSet, args []string, required ...string) error {
+	err := fs.Parse(args)
+	if err != nil {
+		return errUsage
+	}
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	for _, name := range required {
+		if !set[name] {
+			_, _ = fmt.Fprintf(fs.Output(), "Missing required flag: -%s\n", name)
+			fs.Usage()
+
+			return errUsage
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/nrdcg/desec"
+	"github.com/nrdcg/desec/keyring"
+)
+
+// tokenStore stores the token of the CLI (keyring.Store).
+type tokenStore interface {
+	desec.TokenProvider
+	Set(token string) error
+	Delete() error
+}
+
+// login logs in with an email and a password, and stores the token in the keyring of the OS.
+func (a *app) login(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("login")
+	email := fs.String("email", "", "email address (required)")
+
+	err := parseFlags(fs, args, "email")
+	if err != nil {
+		return err
+	}
+
+	// The password is not a flag: it would be visible in the process list.
+	password := os.Getenv("DESEC_PASSWORD")
+	if password == "" {
+		password, err = a.readPassword("Password: ")
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := a.newAnonymousClient()
+	if err != nil {
+		return err
+	}
+
+	token, err := client.Account.Login(ctx, *email, password)
+	if err != nil {
+		return err
+	}
+
+	err = a.tokenStore.Set(token.Value)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(a.stdout, "Logged in, the token is stored in the keyring.")
+
+	return nil
+}
+
+// logout revokes the token stored in the keyring, and removes it from the keyring.
+func (a *app) logout(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("logout")
+
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	token, err := a.tokenStore.Token(ctx)
+	if errors.Is(err, keyring.ErrNotFound) {
+		_, _ = fmt.Fprintln(a.stdout, "Not logged in.")
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	client, err := a.newAnonymousClient()
+	if err != nil {
+		return err
+	}
+
+	err = client.Account.Logout(desec.WithRequestToken(ctx, token))
+	if err != nil {
+		return err
+	}
+
+	err = a.tokenStore.Delete()
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(a.stdout, "Logged out.")
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nrdcg/desec"
+	"github.com/nrdcg/desec/keyring"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryTokenStore a tokenStore in memory.
+type memoryTokenStore struct {
+	token string
+}
+
+func (s *memoryTokenStore) Token(_ context.Context) (string, error) {
+	if s.token == "" {
+		return "", keyring.ErrNotFound
+	}
+
+	return s.token, nil
+}
+
+func (s *memoryTokenStore) Set(token string) error {
+	s.token = token
+	return nil
+}
+
+func (s *memoryTokenStore) Delete() error {
+	s.token = ""
+	return nil
+}
+
+func TestApp_login(t *testing.T) {
+	a, mux, stdout := setupApp(t)
+
+	store := &memoryTokenStore{}
+	a.tokenStore = store
+
+	var credentials desec.Account
+
+	mux.HandleFunc("POST /auth/login/", func(rw http.ResponseWriter, req *http.Request) {
+		_ = json.NewDecoder(req.Body).Decode(&credentials)
+		_, _ = rw.Write([]byte(`{"id":"abc","token":"secret"}`))
+	})
+
+	var authorization string
+
+	mux.HandleFunc("POST /auth/logout/", func(rw http.ResponseWriter, req *http.Request) {
+		authorization = req.Header.Get("Authorization")
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	a.stdin = strings.NewReader("pass\n")
+
+	err := a.run(context.Background(), []string{"login", "-email", "user@example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, desec.Account{Email: "user@example.com", Password: "pass"}, credentials)
+	assert.Equal(t, "secret", store.token)
+	assert.Equal(t, "Password: Logged in, the token is stored in the keyring.\n", stdout.String())
+
+	stdout.Reset()
+
+	err = a.run(context.Background(), []string{"logout"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Token secret", authorization)
+	assert.Empty(t, store.token)
+	assert.Equal(t, "Logged out.\n", stdout.String())
+
+	stdout.Reset()
+
+	err = a.run(context.Background(), []string{"logout"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Not logged in.\n", stdout.String())
+}
@@ -0,0 +1,157 @@
+// Command desec manages deSEC resources from the command line.
+//
+// The client is configured through the environment (DESEC_TOKEN, see desec.NewFromEnv).
+// Without DESEC_TOKEN, the token stored in the keyring of the OS by `desec login` is used.
+//
+//	desec login -email user@example.com
+//	desec records list -domain example.com -output zone
+//	desec records set -domain example.com -subname www -type A -ttl 3600 -record 10.10.10.10
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/nrdcg/desec"
+	"github.com/nrdcg/desec/keyring"
+	"golang.org/x/term"
+)
+
+const usage = `Usage: desec <command> <subcommand> [flags]
+
+Commands:
+  login    log in, the token is stored in the keyring
+  logout   log out, the token is revoked and removed from the keyring
+  account  register
+  records  list, get, create, set, delete
+  token    create, list, revoke
+  zone     sync
+`
+
+// errUsage is returned when the command line is invalid, the usage is printed.
+var errUsage = errors.New("invalid usage")
+
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	input *bufio.Reader
+
+	// newClient creates an authenticated client.
+	newClient func() (*desec.Client, error)
+	// newAnonymousClient creates a client without token (registration).
+	newAnonymousClient func() (*desec.Client, error)
+
+	// tokenStore stores the token of login.
+	tokenStore tokenStore
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	store := keyring.New(keyring.DefaultUser)
+
+	a := &app{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		newClient: func() (*desec.Client, error) {
+			return desec.NewFromEnvWithFallback(store)
+		},
+		newAnonymousClient: desec.NewAnonymousFromEnv,
+		tokenStore:         store,
+	}
+
+	err := a.run(ctx, os.Args[1:])
+	if err != nil {
+		if !errors.Is(err, errUsage) {
+			_, _ = fmt.Fprintln(os.Stderr, "Error:", err)
+		}
+
+		os.Exit(1)
+	}
+}
+
+func (a *app) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(a.stderr, usage)
+		return errUsage
+	}
+
+	switch args[0] {
+	case "login":
+		return a.login(ctx, args[1:])
+	case "logout":
+		return a.logout(ctx, args[1:])
+	case "account":
+		return a.account(ctx, args[1:])
+	case "records":
+		return a.records(ctx, args[1:])
+	case "token":
+		return a.token(ctx, args[1:])
+	case "zone":
+		return a.zone(ctx, args[1:])
+	case "help", "-h", "-help", "--help":
+		_, _ = fmt.Fprint(a.stdout, usage)
+		return nil
+	default:
+		_, _ = fmt.Fprintf(a.stderr, "Unknown command: %s\n\n%s", args[0], usage)
+		return errUsage
+	}
+}
+
+// prompt prints a question and reads a line of the standard input.
+func (a *app) prompt(question string) string {
+	_, _ = fmt.Fprint(a.stdout, question)
+
+	answer, _ := a.stdinReader().ReadString('\n')
+
+	return strings.TrimSpace(answer)
+}
+
+// readPassword prompts for a password, without echo if the standard input is a terminal.
+func (a *app) readPassword(question string) (string, error) {
+	file, ok := a.stdin.(*os.File)
+	if !ok || !term.IsTerminal(int(file.Fd())) {
+		return a.prompt(question), nil
+	}
+
+	_, _ = fmt.Fprint(a.stdout, question)
+
+	password, err := term.ReadPassword(int(file.Fd()))
+
+	_, _ = fmt.Fprintln(a.stdout)
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+
+	return string(password), nil
+}
+
+// stdinReader returns the buffered standard input, shared by all the readers.
+func (a *app) stdinReader() *bufio.Reader {
+	if a.input == nil {
+		a.input = bufio.NewReader(a.stdin)
+	}
+
+	return a.input
+}
+
+// confirm asks a yes/no question, the default is no.
+func (a *app) confirm(question string) bool {
+	switch strings.ToLower(a.prompt(question + " [y/N] ")) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nrdcg/desec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupApp(t *testing.T) (*app, *http.ServeMux, *bytes.Buffer) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	stdout := new(bytes.Buffer)
+
+	a := &app{
+		stdin:  strings.NewReader(""),
+		stdout: stdout,
+		stderr: new(bytes.Buffer),
+		newClient: func() (*desec.Client, error) {
+			client := desec.New("token", desec.NewDefaultClientOptions())
+			client.BaseURL = server.URL
+
+			return client, nil
+		},
+		newAnonymousClient: func() (*desec.Client, error) {
+			client := desec.New("", desec.NewDefaultClientOptions())
+			client.BaseURL = server.URL
+
+			return client, nil
+		},
+	}
+
+	return a, mux, stdout
+}
+
+func TestApp_run_usage(t *testing.T) {
+	a, _, _ := setupApp(t)
+
+	err := a.run(context.Background(), nil)
+	require.ErrorIs(t, err, errUsage)
+
+	err = a.run(context.Background(), []string{"unknown"})
+	require.ErrorIs(t, err, errUsage)
+
+	err = a.run(context.Background(), []string{"records", "get", "-type", "A"})
+	require.ErrorIs(t, err, errUsage)
+
+	assert.Contains(t, a.stderr.(*bytes.Buffer).String(), "Missing required flag: -domain")
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/nrdcg/desec"
+)
+
+// Output formats.
+const (
+	outputJSON  = "json"
+	outputZone  = "zone"
+	outputTable = "table"
+)
+
+func printRRSets(w io.Writer, format, domainName string, rrSets []desec.RRSet) error {
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(rrSets)
+
+	case outputZone:
+		zone, err := desec.FormatZonefile(domainName, rrSets)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(w, zone)
+
+		return err
+
+	case outputTable:
+		return desec.WriteTable(w, rrSets)
+
+	default:
+		return fmt.Errorf("unknown output format: %s", format)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/nrdcg/desec"
+)
+
+// defaultTTL the TTL of the created RRSets when the flag is not set.
+const defaultTTL = 3600
+
+const recordsUsage = `Usage: desec records <subcommand> [flags]
+
+Subcommands:
+  list    list the RRSets of a domain
+  get     get a RRSet
+  create  create a RRSet
+  set     create or replace a RRSet
+  delete  delete a RRSet
+`
+
+func (a *app) records(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(a.stderr, recordsUsage)
+		return errUsage
+	}
+
+	switch args[0] {
+	case "list":
+		return a.recordsList(ctx, args[1:])
+	case "get":
+		return a.recordsGet(ctx, args[1:])
+	case "create":
+		return a.recordsWrite(ctx, "create", args[1:])
+	case "set":
+		return a.recordsWrite(ctx, "set", args[1:])
+	case "delete":
+		return a.recordsDelete(ctx, args[1:])
+	default:
+		_, _ = fmt.Fprintf(a.stderr, "Unknown subcommand: %s\n\n%s", args[0], recordsUsage)
+		return errUsage
+	}
+}
+
+func (a *app) recordsList(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("records list")
+	domainName := fs.String("domain", "", "domain name (required)")
+	recordType := fs.String("type", "", "filter on the record type")
+	subName := fs.String("subname", "", "filter on the subname")
+	output := fs.String("output", outputJSON, "output format: json, zone, or table")
+
+	err := parseFlags(fs, args, "domain")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.newClient()
+	if err != nil {
+		return err
+	}
+
+	query := desec.NewRRSetQuery()
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "type":
+			query.Type(*recordType)
+		case "subname":
+			query.SubName(*subName)
+		}
+	})
+
+	rrSets, err := client.Records.GetAll(ctx, *domainName, query)
+	if err != nil {
+		return err
+	}
+
+	return printRRSets(a.stdout, *output, *domainName, rrSets)
+}
+
+func (a *app) recordsGet(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("records get")
+	domainName := fs.String("domain", "", "domain name (required)")
+	subName := fs.String("subname", "", "subname (empty for the zone apex)")
+	recordType := fs.String("type", "", "record type (required)")
+	output := fs.String("output", outputJSON, "output format: json, zone, or table")
+
+	err := parseFlags(fs, args, "domain", "type")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.newClient()
+	if err != nil {
+		return err
+	}
+
+	rrSet, err := client.Records.Get(ctx, *domainName, *subName, *recordType)
+	if err != nil {
+		return err
+	}
+
+	return printRRSets(a.stdout, *output, *domainName, []desec.RRSet{*rrSet})
+}
+
+func (a *app) recordsWrite(ctx context.Context, name string, args []string) error {
+	fs := a.newFlagSet("records " + name)
+	domainName := fs.String("domain", "", "domain name (required)")
+	subName := fs.String("subname", "", "subname (empty for the zone apex)")
+	recordType := fs.String("type", "", "record type (required)")
+	ttl := fs.Int("ttl", defaultTTL, "TTL")
+	output := fs.String("output", outputJSON, "output format: json, zone, or table")
+
+	var records stringsFlag
+	fs.Var(&records, "record", "record content, can be repeated (required)")
+
+	err := parseFlags(fs, args, "domain", "type", "record")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.newClient()
+	if err != nil {
+		return err
+	}
+
+	rrSet := desec.RRSet{
+		Domain:  *domainName,
+		SubName: *subName,
+		Type:    *recordType,
+		TTL:     *ttl,
+		Records: records,
+	}
+
+	var result *desec.RRSet
+
+	if name == "create" {
+		result, err = client.Records.Create(ctx, rrSet)
+	} else {
+		var results []desec.RRSet
+
+		results, err = client.Records.BulkUpdate(ctx, desec.FullResource, *domainName, []desec.RRSet{rrSet})
+		if err == nil && len(results) > 0 {
+			result = &results[0]
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return printRRSets(a.stdout, *output, *domainName, []desec.RRSet{*result})
+}
+
+func (a *app) recordsDelete(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("records delete")
+	domainName := fs.String("domain", "", "domain name (required)")
+	subName := fs.String("subname", "", "subname (empty for the zone apex)")
+	recordType := fs.String("type", "", "record type (required)")
+
+	err := parseFlags(fs, args, "domain", "type")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.newClient()
+	if err != nil {
+		return err
+	}
+
+	return client.Records.Delete(ctx, *domainName, *subName, *recordType)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/nrdcg/desec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_recordsList(t *testing.T) {
+	a, mux, stdout := setupApp(t)
+
+	mux.HandleFunc("/domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("type") != "A" || req.URL.Query().Has("subname") {
+			http.Error(rw, "invalid filter: "+req.URL.RawQuery, http.StatusBadRequest)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`[{"subname":"www","type":"A","ttl":3600,"records":["10.10.10.10"]},{"subname":"","type":"A","ttl":3600,"records":["10.10.10.11"]}]`))
+	})
+
+	err := a.run(context.Background(), []string{"records", "list", "-domain", "example.com", "-type", "A", "-output", "zone"})
+	require.NoError(t, err)
+
+	expected := "$ORIGIN example.com.\n" +
+		"example.com.\t3600\tIN\tA\t10.10.10.11\n" +
+		"www.example.com.\t3600\tIN\tA\t10.10.10.10\n"
+
+	assert.Equal(t, expected, stdout.String())
+}
+
+func TestApp_recordsGet(t *testing.T) {
+	a, mux, stdout := setupApp(t)
+
+	mux.HandleFunc("/domains/example.com/rrsets/www/A/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"subname":"www","type":"A","ttl":3600,"records":["10.10.10.10"]}`))
+	})
+
+	err := a.run(context.Background(), []string{"records", "get", "-domain", "example.com", "-subname", "www", "-type", "A"})
+	require.NoError(t, err)
+
+	var rrSets []desec.RRSet
+	err = json.Unmarshal(stdout.Bytes(), &rrSets)
+	require.NoError(t, err)
+
+	require.Len(t, rrSets, 1)
+	assert.Equal(t, []string{"10.10.10.10"}, rrSets[0].Records)
+}
+
+func TestApp_recordsSet(t *testing.T) {
+	a, mux, _ := setupApp(t)
+
+	var received []desec.RRSet
+
+	mux.HandleFunc("/domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut {
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		err := json.NewDecoder(req.Body).Decode(&received)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(rw).Encode(received)
+	})
+
+	err := a.run(context.Background(), []string{
+		"records", "set", "-domain", "example.com", "-subname", "www", "-type", "A", "-ttl", "60",
+		"-record", "10.10.10.10", "-record", "10.10.10.11",
+	})
+	require.NoError(t, err)
+
+	expected := []desec.RRSet{{Domain: "example.com", SubName: "www", Type: "A", TTL: 60, Records: []string{"10.10.10.10", "10.10.10.11"}}}
+	assert.Equal(t, expected, received)
+}
+
+func TestApp_recordsDelete(t *testing.T) {
+	a, mux, _ := setupApp(t)
+
+	var deleted bool
+
+	mux.HandleFunc("/domains/example.com/rrsets/@/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted = true
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := a.run(context.Background(), []string{"records", "delete", "-domain", "example.com", "-type", "TXT"})
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+}
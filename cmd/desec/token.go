@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nrdcg/desec"
+)
+
+const tokenUsage = `Usage: desec token <subcommand> [flags]
+
+Subcommands:
+  create  create a token, optionally restricted by a policy
+  list    list the tokens
+  revoke  delete a token
+`
+
+func (a *app) token(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(a.stderr, tokenUsage)
+		return errUsage
+	}
+
+	switch args[0] {
+	case "create":
+		return a.tokenCreate(ctx, args[1:])
+	case "list":
+		return a.tokenList(ctx, args[1:])
+	case "revoke":
+		return a.tokenRevoke(ctx, args[1:])
+	default:
+		_, _ = fmt.Fprintf(a.stderr, "Unknown subcommand: %s\n\n%s", args[0], tokenUsage)
+		return errUsage
+	}
+}
+
+func (a *app) tokenCreate(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("token create")
+	name := fs.String("name", "", "token name")
+	domainName := fs.String("domain", "", "restrict the token to a domain")
+	subName := fs.String("subname", "", "restrict the token to a subname")
+	recordType := fs.String("type", "", "restrict the token to a record type")
+	write := fs.Bool("write", false, "allow the token to write the RRSets matching the policy")
+
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.newClient()
+	if err != nil {
+		return err
+	}
+
+	token, err := client.Tokens.Create(ctx, *name)
+	if err != nil {
+		return err
+	}
+
+	if *domainName != "" || *subName != "" || *recordType != "" {
+		err = createTokenPolicies(ctx, client, token.ID, *domainName, *subName, *recordType, *write)
+		if err != nil {
+			return fmt.Errorf("token %s created, but the policies failed: %w", token.ID, err)
+		}
+	}
+
+	return a.printJSON(token)
+}
+
+// createTokenPolicies creates the default policy (read-only, required by the API), and the policy restricting the token.
+func createTokenPolicies(ctx context.Context, client *desec.Client, tokenID, domainName, subName, recordType string, write bool) error {
+	_, err := client.TokenPolicies.Create(ctx, tokenID, desec.TokenPolicy{})
+	if err != nil {
+		return err
+	}
+
+	policy := desec.TokenPolicy{WritePermission: write}
+
+	if domainName != "" {
+		policy.Domain = desec.Pointer(domainName)
+	}
+
+	if subName != "" {
+		policy.SubName = desec.Pointer(subName)
+	}
+
+	if recordType != "" {
+		policy.Type = desec.Pointer(recordType)
+	}
+
+	_, err = client.TokenPolicies.Create(ctx, tokenID, policy)
+
+	return err
+}
+
+func (a *app) tokenList(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("token list")
+
+	err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.newClient()
+	if err != nil {
+		return err
+	}
+
+	tokens, err := client.Tokens.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	return a.printJSON(tokens)
+}
+
+func (a *app) tokenRevoke(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("token revoke")
+	id := fs.String("id", "", "token ID (required)")
+
+	err := parseFlags(fs, args, "id")
+	if err != nil {
+		return err
+	}
+
+	client, err := a.newClient()
+	if err != nil {
+		return err
+	}
+
+	return client.Tokens.Delete(ctx, *id)
+}
+
+func (a *app) printJSON(v any) error {
+	encoder := json.NewEncoder(a.stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/nrdcg/desec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_tokenCreate(t *testing.T) {
+	a, mux, stdout := setupApp(t)
+
+	mux.HandleFunc("/auth/tokens/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = rw.Write([]byte(`{"id":"token-id","name":"acme","token":"secret"}`))
+	})
+
+	var policies []desec.TokenPolicy
+
+	mux.HandleFunc("/auth/tokens/token-id/policies/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		var policy desec.TokenPolicy
+
+		err := json.NewDecoder(req.Body).Decode(&policy)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		policies = append(policies, policy)
+
+		rw.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(rw).Encode(policy)
+	})
+
+	err := a.run(context.Background(), []string{"token", "create", "--name", "acme", "--domain", "example.org", "--type", "TXT", "--write"})
+	require.NoError(t, err)
+
+	expected := []desec.TokenPolicy{
+		{},
+		{Domain: desec.Pointer("example.org"), Type: desec.Pointer("TXT"), WritePermission: true},
+	}
+	assert.Equal(t, expected, policies)
+
+	var token desec.Token
+	err = json.Unmarshal(stdout.Bytes(), &token)
+	require.NoError(t, err)
+
+	assert.Equal(t, "secret", token.Value)
+}
+
+func TestApp_tokenList(t *testing.T) {
+	a, mux, stdout := setupApp(t)
+
+	mux.HandleFunc("/auth/tokens/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"id":"1","name":"a"},{"id":"2","name":"b"}]`))
+	})
+
+	err := a.run(context.Background(), []string{"token", "list"})
+	require.NoError(t, err)
+
+	var tokens []desec.Token
+	err = json.Unmarshal(stdout.Bytes(), &tokens)
+	require.NoError(t, err)
+
+	assert.Len(t, tokens, 2)
+}
+
+func TestApp_tokenRevoke(t *testing.T) {
+	a, mux, _ := setupApp(t)
+
+	var deleted bool
+
+	mux.HandleFunc("/auth/tokens/token-id/", func(rw http.ResponseWriter, req *http.Request) {
+		deleted = req.Method == http.MethodDelete
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	err := a.run(context.Background(), []string{"token", "revoke", "-id", "token-id"})
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nrdcg/desec"
+)
+
+const zoneUsage = `Usage: desec zone <subcommand> [flags]
+
+Subcommands:
+  diff  print the differences between the live zone and a zone file or a zone spec (unified diff)
+  sync  reconcile a zone file or a zone spec with the live zone
+`
+
+func (a *app) zone(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		_, _ = fmt.Fprint(a.stderr, zoneUsage)
+		return errUsage
+	}
+
+	switch args[0] {
+	case "diff":
+		return a.zoneDiff(ctx, args[1:])
+	case "sync":
+		return a.zoneSync(ctx, args[1:])
+	default:
+		_, _ = fmt.Fprintf(a.stderr, "Unknown subcommand: %s\n\n%s", args[0], zoneUsage)
+		return errUsage
+	}
+}
+
+func (a *app) zoneDiff(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("zone diff")
+	file := fs.String("file", "", "zone file, or zone spec (.yaml, .yml, .json) (required)")
+	domainName := fs.String("domain", "", "domain name (required)")
+
+	err := parseFlags(fs, args, "file", "domain")
+	if err != nil {
+		return err
+	}
+
+	desired, err := readZonefile(*domainName, *file)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.newClient()
+	if err != nil {
+		return err
+	}
+
+	live, err := client.Records.GetAll(ctx, *domainName, nil)
+	if err != nil {
+		return err
+	}
+
+	diff, err := desec.UnifiedZoneDiff(*domainName, live, desired)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprint(a.stdout, diff)
+
+	return nil
+}
+
+func (a *app) zoneSync(ctx context.Context, args []string) error {
+	fs := a.newFlagSet("zone sync")
+	file := fs.String("file", "", "zone file, or zone spec (.yaml, .yml, .json) (required)")
+	domainName := fs.String("domain", "", "domain name (required)")
+	dryRun := fs.Bool("dry-run", false, "print the plan without applying it")
+	yes := fs.Bool("yes", false, "apply without confirmation")
+	keep := fs.Bool("keep-unmanaged", false, "keep the live RRSets absent from the zone file")
+
+	err := parseFlags(fs, args, "file", "domain")
+	if err != nil {
+		return err
+	}
+
+	desired, err := readZonefile(*domainName, *file)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.newClient()
+	if err != nil {
+		return err
+	}
+
+	opts := &desec.SyncOptions{KeepUnmanaged: *keep}
+
+	// The approved plan is applied as is: the live zone is not read again.
+	plan, err := client.Records.Plan(ctx, *domainName, desired, opts)
+	if err != nil {
+		return err
+	}
+
+	err = plan.Render(a.stdout, false)
+	if err != nil {
+		return err
+	}
+
+	if plan.IsEmpty() || *dryRun {
+		return nil
+	}
+
+	if !*yes && !a.confirm("Apply these changes?") {
+		_, _ = fmt.Fprintln(a.stdout, "Canceled.")
+		return nil
+	}
+
+	err = client.Records.ApplyPlan(ctx, plan, opts)
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(a.stdout, "%d change(s) applied.\n", len(plan.Changes()))
+
+	return nil
+}
+
+func readZonefile(domainName, filename string) ([]desec.RRSet, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yaml", ".yml", ".json":
+		spec, err := desec.LoadZoneSpec(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		if spec.Domain != "" && !strings.EqualFold(strings.TrimSuffix(spec.Domain, "."), strings.TrimSuffix(domainName, ".")) {
+			return nil, fmt.Errorf("the zone spec is for %s, not %s", spec.Domain, domainName)
+		}
+
+		return spec.RRSets, nil
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	return desec.ParseZonefile(domainName, file)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nrdcg/desec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupZoneSync(t *testing.T) (*app, *[]desec.RRSet, *bytes.Buffer, string) {
+	t.Helper()
+
+	a, mux, stdout := setupApp(t)
+
+	var (
+		received []desec.RRSet
+		gets     int
+	)
+
+	mux.HandleFunc("/domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			gets++
+
+			if gets > 1 {
+				// The live zone changed after the first read: the approved plan must not include this RRSet.
+				_, _ = rw.Write([]byte(`[{"subname":"","type":"NS","ttl":3600,"records":["ns1.desec.io."]},{"subname":"late","type":"A","ttl":3600,"records":["10.10.10.12"]}]`))
+				return
+			}
+
+			_, _ = rw.Write([]byte(`[{"subname":"","type":"NS","ttl":3600,"records":["ns1.desec.io."]},{"subname":"old","type":"A","ttl":3600,"records":["10.10.10.10"]}]`))
+
+		case http.MethodPut:
+			err := json.NewDecoder(req.Body).Decode(&received)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			_, _ = rw.Write([]byte(`[]`))
+		}
+	})
+
+	file := filepath.Join(t.TempDir(), "zone.db")
+
+	err := os.WriteFile(file, []byte("$TTL 3600\nwww IN A 10.10.10.11\n"), 0o600)
+	require.NoError(t, err)
+
+	return a, &received, stdout, file
+}
+
+func TestApp_zoneSync_dryRun(t *testing.T) {
+	a, received, out, file := setupZoneSync(t)
+
+	err := a.run(context.Background(), []string{"zone", "sync", "--file", file, "--domain", "example.com", "--dry-run"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "- old.example.com A 3600 [10.10.10.10]\n+ www.example.com A 3600 [10.10.10.11]\n\nPlan: 1 to create, 0 to update, 1 to delete.\n", out.String())
+	assert.Nil(t, *received)
+}
+
+func TestApp_zoneSync_confirm(t *testing.T) {
+	a, received, out, file := setupZoneSync(t)
+
+	a.stdin = strings.NewReader("y\n")
+
+	err := a.run(context.Background(), []string{"zone", "sync", "-file", file, "-domain", "example.com"})
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "2 change(s) applied.")
+
+	require.Len(t, *received, 2)
+	assert.Equal(t, "old", (*received)[0].SubName)
+	assert.Equal(t, "www", (*received)[1].SubName)
+}
+
+func TestApp_zoneSync_canceled(t *testing.T) {
+	a, received, out, file := setupZoneSync(t)
+
+	a.stdin = strings.NewReader("n\n")
+
+	err := a.run(context.Background(), []string{"zone", "sync", "-file", file, "-domain", "example.com"})
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "Canceled.")
+	assert.Nil(t, *received)
+}
+
+func TestApp_zoneSync_spec(t *testing.T) {
+	a, received, out, _ := setupZoneSync(t)
+
+	file := filepath.Join(t.TempDir(), "zone.yaml")
+
+	err := os.WriteFile(file, []byte("domain: example.com\nrrsets:\n  - subname: www\n    type: A\n    records: [10.10.10.11]\n"), 0o600)
+	require.NoError(t, err)
+
+	err = a.run(context.Background(), []string{"zone", "sync", "-file", file, "-domain", "example.com", "-dry-run"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "- old.example.com A 3600 [10.10.10.10]\n+ www.example.com A 3600 [10.10.10.11]\n\nPlan: 1 to create, 0 to update, 1 to delete.\n", out.String())
+	assert.Nil(t, *received)
+}
+
+func TestApp_zoneDiff(t *testing.T) {
+	a, received, out, file := setupZoneSync(t)
+
+	err := a.run(context.Background(), []string{"zone", "diff", "--file", file, "--domain", "example.com"})
+	require.NoError(t, err)
+
+	expected := `--- live/example.com
++++ desired/example.com
+@@ -1 +1 @@
+-old.example.com.	3600	IN	A	10.10.10.10
++www.example.com.	3600	IN	A	10.10.10.11
+`
+	assert.Equal(t, expected, out.String())
+	assert.Nil(t, *received)
+}
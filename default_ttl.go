@@ -0,0 +1,53 @@
+package desec
+
+import "context"
+
+// fallbackTTL the TTL of the RRSets that must have a TTL when no default TTL applies:
+// created by the helpers (AddValues, Delegate) without ClientOptions.DefaultTTL, or parsed (zone files, zone specs).
+const fallbackTTL = 3600
+
+type defaultTTLKey struct{}
+
+// WithDefaultTTL returns a context that overrides the default TTL of the client (ClientOptions.DefaultTTL).
+//
+//	rrSet, err := client.Records.Create(desec.WithDefaultTTL(ctx, 300), rrSet)
+func WithDefaultTTL(ctx context.Context, ttl int) context.Context {
+	return context.WithValue(ctx, defaultTTLKey{}, ttl)
+}
+
+// effectiveDefaultTTL returns the default TTL of the call (WithDefaultTTL, ClientOptions.DefaultTTL), or fallbackTTL.
+func (c *Client) effectiveDefaultTTL(ctx context.Context) int {
+	ttl := c.defaultTTL
+	if t, ok := ctx.Value(defaultTTLKey{}).(int); ok {
+		ttl = t
+	}
+
+	if ttl <= 0 {
+		return fallbackTTL
+	}
+
+	return ttl
+}
+
+// applyDefaultTTL sets the default TTL to the RRSet without TTL.
+// The default TTL is raised to the minimum TTL of the domain if needed.
+func (c *Client) applyDefaultTTL(ctx context.Context, domainName string, rrSet *RRSet) error {
+	ttl := c.defaultTTL
+	if t, ok := ctx.Value(defaultTTLKey{}).(int); ok {
+		ttl = t
+	}
+
+	// Without records, the RRSet is deleted and the TTL is not relevant.
+	if ttl <= 0 || rrSet.TTL != 0 || len(rrSet.Records) == 0 {
+		return nil
+	}
+
+	minimumTTL, err := c.domainMinimumTTL(ctx, domainName)
+	if err != nil {
+		return err
+	}
+
+	rrSet.TTL = max(ttl, minimumTTL)
+
+	return nil
+}
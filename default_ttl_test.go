@@ -0,0 +1,88 @@
+package desec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_defaultTTL(t *testing.T) {
+	client, received, domainCalls := setupMinimumTTL(t, MinimumTTLDefault)
+	client.defaultTTL = 7200
+
+	_, err := client.Records.BulkCreate(context.Background(), "example.com", []RRSet{
+		{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}},
+		{SubName: "b", Type: "A", Records: []string{"10.0.0.2"}, TTL: 60},
+		{SubName: "c", Type: "A", Records: []string{}},
+	})
+	require.NoError(t, err)
+
+	expected := []RRSet{
+		{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}, TTL: 7200},
+		{SubName: "b", Type: "A", Records: []string{"10.0.0.2"}, TTL: 60},
+		{SubName: "c", Type: "A", Records: []string{}},
+	}
+	assert.Equal(t, expected, *received)
+	assert.Equal(t, 1, *domainCalls)
+}
+
+func TestClient_defaultTTL_minimum(t *testing.T) {
+	client, received, _ := setupMinimumTTL(t, MinimumTTLDefault)
+
+	ctx := WithDefaultTTL(context.Background(), 300)
+
+	_, err := client.Records.BulkUpdate(ctx, FullResource, "example.com", []RRSet{
+		{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}},
+	})
+	require.NoError(t, err)
+
+	expected := []RRSet{{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}, TTL: 3600}}
+	assert.Equal(t, expected, *received)
+}
+
+func TestClient_defaultTTL_onlyFields(t *testing.T) {
+	client, received, domainCalls := setupMinimumTTL(t, MinimumTTLDefault)
+	client.defaultTTL = 7200
+
+	_, err := client.Records.BulkUpdate(context.Background(), OnlyFields, "example.com", []RRSet{
+		{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}},
+	})
+	require.NoError(t, err)
+
+	expected := []RRSet{{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}}}
+	assert.Equal(t, expected, *received)
+	assert.Equal(t, 0, *domainCalls)
+}
+
+func TestClient_defaultTTL_stream(t *testing.T) {
+	client, received, domainCalls := setupMinimumTTL(t, MinimumTTLDefault)
+	client.defaultTTL = 7200
+
+	seq := func(yield func(RRSet) bool) {
+		for _, rrSet := range []RRSet{
+			{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}},
+			{SubName: "b", Type: "A", Records: []string{"10.0.0.2"}, TTL: 60},
+		} {
+			if !yield(rrSet) {
+				return
+			}
+		}
+	}
+
+	_, err := client.Records.BulkCreateStream(context.Background(), "example.com", seq)
+	require.NoError(t, err)
+
+	expected := []RRSet{
+		{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}, TTL: 7200},
+		{SubName: "b", Type: "A", Records: []string{"10.0.0.2"}, TTL: 60},
+	}
+	assert.Equal(t, expected, *received)
+	assert.Equal(t, 1, *domainCalls)
+
+	_, err = client.Records.BulkUpdateStream(context.Background(), OnlyFields, "example.com", seq)
+	require.NoError(t, err)
+
+	assert.Zero(t, (*received)[0].TTL)
+}
@@ -0,0 +1,103 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DelegationOptions the options of Delegate.
+// When the child zone is not hosted by deSEC, the nameservers (and the DS records for DNSSEC) must be provided.
+type DelegationOptions struct {
+	// Nameservers the nameservers of the child zone (default: the apex NS RRSet of the child zone on deSEC).
+	Nameservers []string
+
+	// DS the DS records of the child zone (default: the DS records of the keys of the child zone on deSEC).
+	DS []string
+
+	// TTL of the NS and DS RRSets (default: ClientOptions.DefaultTTL, WithDefaultTTL, or 3600).
+	TTL int
+}
+
+// Delegation the RRSets of a delegation created by Delegate.
+type Delegation struct {
+	Parent string
+	Child  string
+	// RRSets the NS and DS RRSets created in the parent zone.
+	RRSets []RRSet
+}
+
+// Verify checks that the NS and DS RRSets of the delegation are visible on the resolvers of the checker.
+// The child zone must be served by its nameservers.
+func (d *Delegation) Verify(ctx context.Context, checker *PropagationChecker) ([]*PropagationReport, error) {
+	var reports []*PropagationReport
+
+	for _, rrSet := range d.RRSets {
+		report, err := checker.Check(ctx, rrSet)
+		if err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// Delegate creates in a parent zone the NS and DS RRSets required to delegate a child zone.
+// Without options, the child zone must be hosted by deSEC: its nameservers and its DS records are retrieved from the API.
+func (s *DomainsService) Delegate(ctx context.Context, parentName, childName string, opts *DelegationOptions) (*Delegation, error) {
+	if opts == nil {
+		opts = &DelegationOptions{}
+	}
+
+	subName, err := SubNameFromOwner(parentName, childName)
+	if err != nil {
+		return nil, err
+	}
+
+	if subName == "" {
+		return nil, errors.New("the child zone must be a subdomain of the parent zone")
+	}
+
+	nameservers := opts.Nameservers
+	ds := opts.DS
+
+	if len(nameservers) == 0 {
+		nsRRSet, err := s.client.Records.Get(ctx, childName, "", RRTypeNS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the nameservers of %s: %w", childName, err)
+		}
+
+		nameservers = nsRRSet.Records
+
+		if len(ds) == 0 {
+			child, err := s.Get(ctx, childName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the keys of %s: %w", childName, err)
+			}
+
+			for _, key := range child.Keys {
+				ds = append(ds, key.DS...)
+			}
+		}
+	}
+
+	ttl := opts.TTL
+	if ttl == 0 {
+		ttl = s.client.effectiveDefaultTTL(ctx)
+	}
+
+	rrSets := []RRSet{{Domain: parentName, SubName: subName, Type: RRTypeNS, TTL: ttl, Records: nameservers}}
+
+	if len(ds) > 0 {
+		rrSets = append(rrSets, RRSet{Domain: parentName, SubName: subName, Type: RRTypeDS, TTL: ttl, Records: ds})
+	}
+
+	_, err = s.client.Records.BulkUpdate(ctx, FullResource, parentName, rrSets)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Delegation{Parent: parentName, Child: childName, RRSets: rrSets}, nil
+}
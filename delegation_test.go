@@ -0,0 +1,105 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDS = "6006 13 2 f34b75e16d3e2d0f0fd4a2bc7d0f2a3e3b0c4d5e6f708192a3b4c5d6e7f80912"
+
+func TestDomainsService_Delegate(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/sub.example.com/rrsets/@/NS/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"domain":"sub.example.com","subname":"","type":"NS","records":["ns1.desec.io.","ns2.desec.org."]}`))
+	})
+
+	mux.HandleFunc("GET /domains/sub.example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"name":"sub.example.com","keys":[{"dnskey":"257 3 13 WFRl60...","ds":["` + testDS + `"],"flags":257}]}`))
+	})
+
+	var received []RRSet
+
+	mux.HandleFunc("PUT /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(&received)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	delegation, err := client.Domains.Delegate(context.Background(), "example.com", "sub.example.com", nil)
+	require.NoError(t, err)
+
+	expected := []RRSet{
+		{Domain: "example.com", SubName: "sub", Type: "NS", TTL: 3600, Records: []string{"ns1.desec.io.", "ns2.desec.org."}},
+		{Domain: "example.com", SubName: "sub", Type: "DS", TTL: 3600, Records: []string{testDS}},
+	}
+	assert.Equal(t, expected, received)
+	assert.Equal(t, expected, delegation.RRSets)
+
+	dnsServer := startDNSServer(t,
+		"sub.example.com. 3600 IN NS ns1.desec.io.",
+		"sub.example.com. 3600 IN NS ns2.desec.org.",
+		"sub.example.com. 3600 IN DS "+testDS,
+	)
+
+	checker := &PropagationChecker{Resolvers: []string{dnsServer}, Timeout: time.Second}
+
+	reports, err := delegation.Verify(context.Background(), checker)
+	require.NoError(t, err)
+
+	require.Len(t, reports, 2)
+
+	for _, report := range reports {
+		assert.True(t, report.Propagated(), report.RRSet.Type)
+	}
+}
+
+func TestDomainsService_Delegate_external(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var received []RRSet
+
+	mux.HandleFunc("PUT /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(&received)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	opts := &DelegationOptions{Nameservers: []string{"ns1.example.net."}, TTL: 7200}
+
+	_, err := client.Domains.Delegate(context.Background(), "example.com", "a.b.example.com.", opts)
+	require.NoError(t, err)
+
+	expected := []RRSet{
+		{Domain: "example.com", SubName: "a.b", Type: "NS", TTL: 7200, Records: []string{"ns1.example.net."}},
+	}
+	assert.Equal(t, expected, received)
+
+	_, err = client.Domains.Delegate(context.Background(), "example.com", "example.com", opts)
+	require.EqualError(t, err, "the child zone must be a subdomain of the parent zone")
+}
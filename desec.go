@@ -8,11 +8,18 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"github.com/hashicorp/go-retryablehttp"
 )
 
-const defaultBaseURL = "https://desec.io/api/v1/"
+// DefaultAPIVersion the version of the API used by default.
+const DefaultAPIVersion = "v1"
+
+const (
+	defaultAPIURL  = "https://desec.io/api/"
+	defaultBaseURL = defaultAPIURL + DefaultAPIVersion + "/"
+)
 
 type httpDoer interface {
 	Do(req *http.Request) (*http.Response, error)
@@ -27,11 +34,92 @@ type ClientOptions struct {
 	// HTTPClient HTTP client used to communicate with the API.
 	HTTPClient *http.Client
 
+	// APIVersion the version of the API (ex: "v2").
+	// If set, the BaseURL of the client is the root of the API (https://desec.io/api/) and the version is added to the endpoints.
+	// Defaults to DefaultAPIVersion, included in the default BaseURL.
+	APIVersion string
+
+	// DialContext dials the connections to the API (ex: SOCKS proxy, Unix socket, custom DNS resolution).
+	// It replaces the dialer of the transport of HTTPClient, which must be an *http.Transport (or nil):
+	// with another transport, the requests fail with ErrDialerTransport.
+	DialContext DialContextFunc
+
+	// MaxResponseSize the maximum size (in bytes) of the response bodies, a larger body is rejected (ResponseTooLargeError).
+	// 0 uses DefaultMaxResponseSize, a negative value disables the limit.
+	MaxResponseSize int64
+
 	// Maximum number of retries
 	RetryMax int
 
+	// RetryPolicy decides whether a failed API call must be retried.
+	// Defaults to DefaultRetryPolicy.
+	RetryPolicy RetryPolicy
+
 	// Customer logger instance. Can be either Logger or LeveledLogger
 	Logger interface{}
+
+	// TokenProvider provides the token for each request.
+	// If set, it takes precedence over the token given to New.
+	TokenProvider TokenProvider
+
+	// StrictDecoding rejects the responses containing fields unknown to the library (UnknownFieldError).
+	// Useful to detect when the API adds fields that are silently dropped.
+	StrictDecoding bool
+
+	// DeduplicateGETs coalesces identical in-flight GET requests (same URL and token) into a single API call.
+	// Useful to reduce the quota consumption when many goroutines request the same resource (ex: ACME bursts).
+	// The context of the first caller is used for the shared API call.
+	DeduplicateGETs bool
+
+	// Hooks callbacks called during the lifecycle of the requests.
+	Hooks Hooks
+
+	// DecodeIDN converts punycode names (domains, subnames) to Unicode in the responses.
+	// Unicode names are always converted to punycode in the requests.
+	DecodeIDN bool
+
+	// ConflictRetries number of retries of the read-modify-write helpers (AddValues, RemoveValues)
+	// when a concurrent write of the RRSet is detected (ConflictError): the RRSet changed before the write,
+	// or the response of the write doesn't contain the written records.
+	// The API has no conditional writes: the detection is best-effort.
+	// 0 disables the detection.
+	ConflictRetries int
+
+	// Resolver the DNS resolver (host:port) used by the DNS based helpers (VerifyDNSSEC).
+	// Defaults to the first of DefaultResolvers.
+	Resolver string
+
+	// MinimumTTL defines how the TTLs lower than the minimum TTL of the domain are handled.
+	// Can be overridden per call with WithMinimumTTLMode.
+	MinimumTTL MinimumTTLMode
+
+	// DefaultTTL the TTL set to the created or replaced RRSets without TTL, raised to the minimum TTL of the domain if needed.
+	// 0 keeps the TTL unset: the API applies its own default.
+	// Can be overridden per call with WithDefaultTTL.
+	DefaultTTL int
+
+	// CheckDomainQuota checks the domain quota of the account (AccountService.Quota) before creating a domain,
+	// to return a QuotaExceededError without calling the creation endpoint.
+	CheckDomainQuota bool
+
+	// InspectInvalidToken looks up the state (is_valid, last_used) of a token rejected by the API (401),
+	// added to the TokenInvalidError (best effort: a failure of the lookup is ignored).
+	// A rejected token can't inspect itself: the lookup must use another token
+	// (ex: TokensService.GetAll with WithRequestToken and a token with the perm_manage_tokens permission).
+	// The lookup is not inspected again if it is also rejected, provided it uses the context it receives.
+	InspectInvalidToken func(ctx context.Context) (*Token, error)
+
+	// RateLimiter limits the rate of the HTTP requests, retries included (optional).
+	// If it implements RateLimitObserver, it receives the rate limits communicated by the API (ex: AdaptiveLimiter).
+	// A PriorityScheduler serves the waiting API calls by priority (WithPriority).
+	RateLimiter RateLimiter
+
+	// AuditLog receives an audit record (JSON line) for each mutating API call (POST, PUT, PATCH, DELETE).
+	AuditLog io.Writer
+
+	// OnAudit is called with the audit record of each mutating API call.
+	// The callback must be safe for concurrent use.
+	OnAudit func(AuditRecord)
 }
 
 // NewDefaultClientOptions creates a new ClientOptions with default values.
@@ -48,9 +136,44 @@ type Client struct {
 	// Base URL for API requests.
 	BaseURL string
 
+	// APIVersion the version of the API (ex: "v2"), added to all the endpoints after PathPrefix.
+	// Empty if BaseURL already targets a version of the API.
+	APIVersion string
+
+	// PathPrefix a path prefix added to all the endpoints, after BaseURL (ex: behind an API gateway rewriting the paths).
+	PathPrefix string
+
+	// PathPrefixes the path prefixes of the endpoints, keyed by the first segment of their path
+	// ("domains", "auth", "captcha", "v"), added after PathPrefix and APIVersion.
+	// Ex: {"domains": "dns"} sends the records requests to <BaseURL>/dns/domains/example.com/rrsets/.
+	PathPrefixes map[string]string
+
 	httpClient httpDoer
 
-	token string
+	// streamClient is used for the requests with a streamed body: they cannot be replayed, so they are not retried.
+	streamClient httpDoer
+
+	tokenProvider TokenProvider
+
+	// loginTokenID the ID of the token obtained by AccountService.Login, kept by TokensService.Prune.
+	loginTokenID string
+
+	decodeIDN      bool
+	strictDecoding bool
+
+	conflictRetries int
+
+	resolver string
+
+	minimumTTLMode MinimumTTLMode
+	defaultTTL     int
+
+	checkDomainQuota bool
+
+	inspectInvalidToken func(ctx context.Context) (*Token, error)
+
+	responsible *ttlCache[string, Domain]
+	minimumTTLs *ttlCache[string, int]
 
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
@@ -65,15 +188,79 @@ type Client struct {
 // New creates a new Client.
 func New(token string, opts ClientOptions) *Client {
 	// https://github.com/desec-io/desec-stack/blob/main/docs/rate-limits.rst
+	if opts.DialContext != nil {
+		opts.HTTPClient = withDialer(opts.HTTPClient, opts.DialContext)
+	}
+
+	if opts.RateLimiter != nil {
+		opts.HTTPClient = withRateLimiter(opts.HTTPClient, opts.RateLimiter)
+	}
+
 	retryClient := retryablehttp.NewClient()
 	retryClient.RetryMax = opts.RetryMax
 	retryClient.HTTPClient = opts.HTTPClient
 	retryClient.Logger = opts.Logger
 
+	retryPolicy := opts.RetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = DefaultRetryPolicy
+	}
+
+	retryClient.CheckRetry = throttleRetryPolicy(methodRetryPolicy(retryPolicy))
+	retryClient.ErrorHandler = throttleErrorHandler
+	setupRetryHooks(retryClient, opts.Hooks)
+
+	var doer httpDoer = methodDoer{next: retryClient.StandardClient()}
+	doer = withResponseLimit(doer, opts.MaxResponseSize)
+	if opts.DeduplicateGETs {
+		doer = &singleflightDoer{next: doer}
+	}
+
+	doer = hooksDoer{next: doer, hooks: opts.Hooks}
+
+	streamHTTPClient := opts.HTTPClient
+	if streamHTTPClient == nil {
+		streamHTTPClient = http.DefaultClient
+	}
+
+	var streamDoer httpDoer = hooksDoer{next: withResponseLimit(streamHTTPClient, opts.MaxResponseSize), hooks: opts.Hooks}
+
+	if emit := newAuditEmitter(opts.AuditLog, opts.OnAudit); emit != nil {
+		doer = auditDoer{next: doer, emit: emit}
+		streamDoer = auditDoer{next: streamDoer, emit: emit}
+	}
+
 	client := &Client{
-		httpClient: retryClient.StandardClient(),
-		BaseURL:    defaultBaseURL,
-		token:      token,
+		httpClient:     responseRecorder{next: doer},
+		streamClient:   responseRecorder{next: streamDoer},
+		BaseURL:        defaultBaseURL,
+		decodeIDN:      opts.DecodeIDN,
+		strictDecoding: opts.StrictDecoding,
+
+		conflictRetries: opts.ConflictRetries,
+		resolver:        opts.Resolver,
+		minimumTTLMode:  opts.MinimumTTL,
+		defaultTTL:      opts.DefaultTTL,
+
+		checkDomainQuota:    opts.CheckDomainQuota,
+		inspectInvalidToken: opts.InspectInvalidToken,
+	}
+
+	if opts.APIVersion != "" {
+		client.BaseURL = defaultAPIURL
+		client.APIVersion = opts.APIVersion
+	}
+
+	if client.resolver == "" {
+		client.resolver = DefaultResolvers[0]
+	}
+
+	client.responsible = newTTLCache[string, Domain](responsibleCacheTTL)
+	client.minimumTTLs = newTTLCache[string, int](minimumTTLCacheTTL)
+
+	client.tokenProvider = opts.TokenProvider
+	if client.tokenProvider == nil {
+		client.tokenProvider = StaticToken(token)
 	}
 
 	client.common.client = client
@@ -102,9 +289,14 @@ func (c *Client) newRequest(ctx context.Context, method string, endpoint fmt.Str
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	token, err := requestToken(ctx, c.tokenProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
 	req.Header.Set("Content-Type", "application/json")
-	if c.token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Token %s", c.token))
+	if token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Token %s", token))
 	}
 
 	return req, nil
@@ -116,13 +308,37 @@ func (c *Client) createEndpoint(parts ...string) (*url.URL, error) {
 		return nil, err
 	}
 
-	endpoint := base.JoinPath(parts...)
+	// The parts are converted in a copy: the slice of the caller must not be modified.
+	parts = slices.Clone(parts)
+
+	for i, part := range parts {
+		parts[i], err = toASCII(part)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var prefixes []string
+
+	if c.PathPrefix != "" {
+		prefixes = append(prefixes, c.PathPrefix)
+	}
+
+	if c.APIVersion != "" {
+		prefixes = append(prefixes, c.APIVersion)
+	}
+
+	if len(parts) > 0 && c.PathPrefixes[parts[0]] != "" {
+		prefixes = append(prefixes, c.PathPrefixes[parts[0]])
+	}
+
+	endpoint := base.JoinPath(slices.Concat(prefixes, parts)...)
 	endpoint.Path += "/"
 
 	return endpoint, nil
 }
 
-func handleResponse(resp *http.Response, respData interface{}) error {
+func (c *Client) handleResponse(resp *http.Response, respData interface{}) error {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return &APIError{
@@ -135,6 +351,10 @@ func handleResponse(resp *http.Response, respData interface{}) error {
 		return nil
 	}
 
+	if c.strictDecoding {
+		return decodeStrict(body, respData)
+	}
+
 	err = json.Unmarshal(body, respData)
 	if err != nil {
 		return fmt.Errorf("failed to umarshal response body: %w", err)
@@ -144,13 +364,22 @@ func handleResponse(resp *http.Response, respData interface{}) error {
 }
 
 func handleError(resp *http.Response) error {
+	return withRequestContext(resp, readAPIError)
+}
+
+func readAPIError(resp *http.Response) error {
 	switch resp.StatusCode {
 	case http.StatusNotFound:
 		return readError(resp, &NotFoundError{})
+	case http.StatusUnauthorized:
+		return readError(resp, &TokenInvalidError{})
+	case http.StatusForbidden:
+		return readForbiddenError(resp)
+	case http.StatusBadRequest:
+		return readBadRequestError(resp)
+	case http.StatusTooManyRequests:
+		return readThrottledError(resp)
 	default:
 		return readRawError(resp)
 	}
 }
-
-// Pointer creates pointer of string.
-func Pointer[T string](v T) *T { return &v }
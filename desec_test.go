@@ -0,0 +1,254 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_strictDecoding(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		strict   bool
+		expected string
+	}{
+		{
+			desc:   "lenient",
+			strict: false,
+		},
+		{
+			desc:     "strict",
+			strict:   true,
+			expected: "new_field",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			t.Cleanup(server.Close)
+
+			opts := NewDefaultClientOptions()
+			opts.StrictDecoding = test.strict
+
+			client := New("token", opts)
+			client.BaseURL = server.URL
+
+			mux.HandleFunc("/domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+				_, _ = rw.Write([]byte(`{"name":"example.com","new_field":"value"}`))
+			})
+
+			domain, err := client.Domains.Get(context.Background(), "example.com")
+
+			if test.expected == "" {
+				require.NoError(t, err)
+				assert.Equal(t, "example.com", domain.Name)
+
+				return
+			}
+
+			var unknownErr *UnknownFieldError
+			require.ErrorAs(t, err, &unknownErr)
+
+			assert.Equal(t, test.expected, unknownErr.Field)
+		})
+	}
+}
+
+func TestClient_strictDecoding_pointer(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	opts := NewDefaultClientOptions()
+	opts.StrictDecoding = true
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/example.com/rrsets/www/A/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"subname":"www","type":"A","records":["10.10.10.10"],"ttl":3600,"new_field":"value"}`))
+	})
+
+	rrSet := RRSet{SubName: "www", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}}
+
+	_, err := client.Records.Update(context.Background(), "example.com", "www", "A", rrSet)
+
+	var unknownErr *UnknownFieldError
+	require.ErrorAs(t, err, &unknownErr)
+
+	assert.Equal(t, "new_field", unknownErr.Field)
+
+	_, err = client.Records.Replace(context.Background(), "example.com", "www", "A", rrSet)
+	require.ErrorAs(t, err, &unknownErr)
+}
+
+func TestClient_tokenInvalid(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnauthorized)
+		_, _ = rw.Write([]byte(`{"detail":"Invalid token."}`))
+	})
+
+	_, err := client.Domains.Get(context.Background(), "example.com")
+	require.ErrorIs(t, err, ErrTokenInvalid)
+
+	var tokenErr *TokenInvalidError
+	require.ErrorAs(t, err, &tokenErr)
+
+	assert.Equal(t, "Invalid token.", tokenErr.Detail)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
+
+func TestClient_paginationRequired(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Link", `<`+server.URL+`/domains/?cursor=>; rel="first"`)
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = rw.Write([]byte(`{"detail":"Pagination required."}`))
+	})
+
+	_, _, err := client.Domains.getAll(context.Background(), nil)
+
+	var paginationErr *PaginationRequiredError
+	require.ErrorAs(t, err, &paginationErr)
+
+	assert.Equal(t, "Pagination required.", paginationErr.Detail)
+	assert.Equal(t, &Cursors{First: ""}, paginationErr.Cursors)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+}
+
+func TestClient_badRequest(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = rw.Write([]byte(`{"name":["Invalid domain name."]}`))
+	})
+
+	_, err := client.Domains.Create(context.Background(), "example.com")
+	require.EqualError(t, err, `400: body: {"name":["Invalid domain name."]}`)
+	require.NotErrorAs(t, err, new(*PaginationRequiredError))
+}
+
+func TestClient_apiErrorContext(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("X-Request-Id", "abc123")
+		rw.Header().Set("Date", "Tue, 06 May 2025 12:00:00 GMT")
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"detail":"Not found.","padding":"` + strings.Repeat("x", 1000) + `"}`))
+	})
+
+	_, err := client.Records.GetAll(context.Background(), "example.com", &RRSetFilter{Type: "A", SubName: IgnoreFilter})
+	require.ErrorAs(t, err, new(*NotFoundError))
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, http.MethodGet, apiErr.Method)
+	assert.Equal(t, server.URL+"/domains/example.com/rrsets/?type=A", apiErr.URL)
+	assert.Equal(t, "abc123", apiErr.RequestID)
+	assert.Equal(t, time.Date(2025, time.May, 6, 12, 0, 0, 0, time.UTC), apiErr.Date)
+	assert.Len(t, apiErr.Body, 512)
+	assert.True(t, strings.HasPrefix(apiErr.Body, `{"detail":"Not found."`))
+
+	assert.EqualError(t, err, "404: Not found.")
+}
+
+func TestClient_createEndpoint_pathPrefixes(t *testing.T) {
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = "https://gateway.example.com/"
+	client.PathPrefix = "desec/api/v1"
+	client.PathPrefixes = map[string]string{"domains": "dns"}
+
+	endpoint, err := client.createEndpoint("domains", "example.com", "rrsets")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://gateway.example.com/desec/api/v1/dns/domains/example.com/rrsets/", endpoint.String())
+
+	endpoint, err = client.createEndpoint("auth", "tokens")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://gateway.example.com/desec/api/v1/auth/tokens/", endpoint.String())
+}
+
+func TestClient_createEndpoint_keepParts(t *testing.T) {
+	client := New("token", NewDefaultClientOptions())
+
+	parts := []string{"domains", "bücher.example", "rrsets"}
+
+	endpoint, err := client.createEndpoint(parts...)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://desec.io/api/v1/domains/xn--bcher-kva.example/rrsets/", endpoint.String())
+	assert.Equal(t, []string{"domains", "bücher.example", "rrsets"}, parts)
+}
+
+func TestClient_createEndpoint_apiVersion(t *testing.T) {
+	client := New("token", NewDefaultClientOptions())
+
+	endpoint, err := client.createEndpoint("domains")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://desec.io/api/v1/domains/", endpoint.String())
+
+	opts := NewDefaultClientOptions()
+	opts.APIVersion = "v2"
+
+	client = New("token", opts)
+
+	endpoint, err = client.createEndpoint("domains")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://desec.io/api/v2/domains/", endpoint.String())
+
+	client.BaseURL = "https://staging.example.com/api/"
+	client.PathPrefixes = map[string]string{"domains": "dns"}
+
+	endpoint, err = client.createEndpoint("domains", "example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://staging.example.com/api/v2/dns/domains/example.com/", endpoint.String())
+}
@@ -0,0 +1,140 @@
+package desectest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/nrdcg/desec"
+)
+
+// Environment variables used by Run.
+const (
+	// EnvTestDomain an existing domain used by the tests: its RRSets are restored after the tests.
+	// If not set, a throwaway domain is created.
+	EnvTestDomain = "DESEC_TEST_DOMAIN"
+	// EnvTestParent the parent of the throwaway domains (default: dedyn.io).
+	EnvTestParent = "DESEC_TEST_PARENT"
+)
+
+const defaultTestParent = "dedyn.io"
+
+// Live a live test environment.
+type Live struct {
+	// Client the API client, configured with the environment variables (desec.NewFromEnv).
+	Client *desec.Client
+
+	// Domain the name of the domain dedicated to the test.
+	Domain string
+
+	mu     sync.Mutex
+	tokens []string
+}
+
+// CreateToken creates a token deleted at the end of the test.
+func (l *Live) CreateToken(ctx context.Context, name string) (*desec.Token, error) {
+	token, err := l.Client.Tokens.Create(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	l.tokens = append(l.tokens, token.ID)
+	l.mu.Unlock()
+
+	return token, nil
+}
+
+// Run runs a test against the live API, it's skipped if DESEC_TOKEN is not set.
+// The test uses a throwaway domain with a random name, deleted at the end of the test,
+// or the domain of DESEC_TEST_DOMAIN, whose RRSets are restored at the end of the test.
+// The tokens created with Live.CreateToken are deleted at the end of the test.
+func Run(t *testing.T, fn func(t *testing.T, live *Live)) {
+	t.Helper()
+
+	if os.Getenv(desec.EnvToken) == "" {
+		t.Skipf("%s is not set", desec.EnvToken)
+	}
+
+	client, err := desec.NewFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	live := &Live{Client: client}
+
+	t.Cleanup(func() { live.deleteTokens(t) })
+
+	if domainName := os.Getenv(EnvTestDomain); domainName != "" {
+		live.Domain = domainName
+		live.snapshot(t)
+	} else {
+		live.createDomain(t)
+	}
+
+	fn(t, live)
+}
+
+func (l *Live) createDomain(t *testing.T) {
+	t.Helper()
+
+	parent := os.Getenv(EnvTestParent)
+	if parent == "" {
+		parent = defaultTestParent
+	}
+
+	suffix := make([]byte, 6)
+
+	_, err := rand.Read(suffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	domain, err := l.Client.Domains.Create(context.Background(), "desectest-"+hex.EncodeToString(suffix)+"."+parent)
+	if err != nil {
+		t.Fatalf("failed to create the test domain: %v", err)
+	}
+
+	l.Domain = domain.Name
+
+	t.Cleanup(func() {
+		err := l.Client.Domains.Delete(context.Background(), l.Domain)
+		if err != nil {
+			t.Errorf("failed to delete the test domain %s: %v", l.Domain, err)
+		}
+	})
+}
+
+// snapshot saves the RRSets of the domain, and restores them at the end of the test.
+func (l *Live) snapshot(t *testing.T) {
+	t.Helper()
+
+	rrSets, err := l.Client.Records.GetAll(context.Background(), l.Domain, nil)
+	if err != nil {
+		t.Fatalf("failed to get the RRSets of the test domain: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_, err := l.Client.Records.Sync(context.Background(), l.Domain, rrSets, nil)
+		if err != nil {
+			t.Errorf("failed to restore the RRSets of the test domain %s: %v", l.Domain, err)
+		}
+	})
+}
+
+func (l *Live) deleteTokens(t *testing.T) {
+	t.Helper()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, tokenID := range l.tokens {
+		err := l.Client.Tokens.Delete(context.Background(), tokenID)
+		if err != nil {
+			t.Errorf("failed to delete the test token %s: %v", tokenID, err)
+		}
+	}
+}
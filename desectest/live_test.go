@@ -0,0 +1,137 @@
+package desectest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nrdcg/desec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_skip(t *testing.T) {
+	t.Setenv(desec.EnvToken, "")
+
+	var called bool
+
+	t.Run("live", func(t *testing.T) {
+		Run(t, func(t *testing.T, live *Live) {
+			called = true
+		})
+	})
+
+	assert.False(t, called)
+}
+
+func TestRun_throwawayDomain(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Setenv(desec.EnvToken, "token")
+	t.Setenv(desec.EnvBaseURL, server.URL)
+	t.Setenv(EnvTestDomain, "")
+	t.Setenv(EnvTestParent, "example.com")
+
+	var mu sync.Mutex
+
+	var calls []string
+
+	record := func(call string) {
+		mu.Lock()
+		calls = append(calls, call)
+		mu.Unlock()
+	}
+
+	mux.HandleFunc("POST /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		var domain desec.Domain
+
+		_ = json.NewDecoder(req.Body).Decode(&domain)
+
+		record("create domain")
+
+		rw.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(rw).Encode(domain)
+	})
+
+	mux.HandleFunc("DELETE /domains/{name}/", func(rw http.ResponseWriter, req *http.Request) {
+		record("delete domain " + req.PathValue("name"))
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("POST /auth/tokens/", func(rw http.ResponseWriter, req *http.Request) {
+		record("create token")
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = rw.Write([]byte(`{"id":"token-id"}`))
+	})
+
+	mux.HandleFunc("DELETE /auth/tokens/{id}/", func(rw http.ResponseWriter, req *http.Request) {
+		record("delete token " + req.PathValue("id"))
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	var domainName string
+
+	t.Run("live", func(t *testing.T) {
+		Run(t, func(t *testing.T, live *Live) {
+			domainName = live.Domain
+
+			_, err := live.CreateToken(context.Background(), "test")
+			require.NoError(t, err)
+		})
+	})
+
+	assert.True(t, strings.HasPrefix(domainName, "desectest-"))
+	assert.True(t, strings.HasSuffix(domainName, ".example.com"))
+
+	expected := []string{"create domain", "create token", "delete domain " + domainName, "delete token token-id"}
+	assert.Equal(t, expected, calls)
+}
+
+func TestRun_existingDomain(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Setenv(desec.EnvToken, "token")
+	t.Setenv(desec.EnvBaseURL, server.URL)
+	t.Setenv(EnvTestDomain, "example.com")
+
+	var gets int
+
+	mux.HandleFunc("GET /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		gets++
+
+		if gets == 1 {
+			_, _ = rw.Write([]byte(`[{"subname":"","type":"NS","ttl":3600,"records":["ns1.desec.io."]},{"subname":"www","type":"A","ttl":3600,"records":["10.0.0.1"]}]`))
+			return
+		}
+
+		// State modified by the test.
+		_, _ = rw.Write([]byte(`[{"subname":"","type":"NS","ttl":3600,"records":["ns1.desec.io."]},{"subname":"www","type":"A","ttl":3600,"records":["10.0.0.3"]},{"subname":"test","type":"A","ttl":3600,"records":["10.0.0.2"]}]`))
+	})
+
+	var restored []desec.RRSet
+
+	mux.HandleFunc("PUT /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		_ = json.NewDecoder(req.Body).Decode(&restored)
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	t.Run("live", func(t *testing.T) {
+		Run(t, func(t *testing.T, live *Live) {
+			assert.Equal(t, "example.com", live.Domain)
+		})
+	})
+
+	expected := []desec.RRSet{
+		{SubName: "test", Type: "A", TTL: 3600, Records: []string{}},
+		{SubName: "www", Type: "A", TTL: 3600, Records: []string{"10.0.0.1"}},
+	}
+	assert.Equal(t, expected, restored)
+}
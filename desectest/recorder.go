@@ -0,0 +1,230 @@
+// Package desectest provides helpers to test the integrations of the deSEC API client.
+package desectest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sync"
+)
+
+// Mode the mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay replays the interactions of the cassette, without calling the API.
+	ModeReplay Mode = iota
+	// ModeRecord calls the API and records the interactions into the cassette.
+	ModeRecord
+)
+
+// redacted the replacement of the secrets in the cassettes.
+const redacted = "[REDACTED]"
+
+// secretFields matches the JSON fields containing secrets (tokens, passwords).
+var secretFields = regexp.MustCompile(`("(?:token|password|new_password)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// Interaction a recorded HTTP request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest a recorded HTTP request.
+// The headers are not recorded: they contain the token.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse a recorded HTTP response.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Cassette the recorded interactions, stored as a JSON file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder an http.RoundTripper recording the API interactions into a cassette file, and replaying them.
+// The secrets (tokens, passwords) are scrubbed from the recorded bodies.
+//
+//	recorder, err := desectest.NewRecorder("fixtures/create_domain.json", desectest.ModeReplay)
+//	opts := desec.NewDefaultClientOptions()
+//	opts.HTTPClient = recorder.HTTPClient()
+//	client := desec.New(os.Getenv("DESEC_TOKEN"), opts)
+//	// ...
+//	err = recorder.Save() // in record mode.
+type Recorder struct {
+	// Transport the transport used to call the API in record mode (default: http.DefaultTransport).
+	Transport http.RoundTripper
+
+	// Scrub modifies the interactions before recording them, ex: to remove personal data.
+	Scrub func(*Interaction)
+
+	path string
+	mode Mode
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder creates a new Recorder.
+// In replay mode, the cassette file is loaded.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode}
+
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	err = json.Unmarshal(data, &r.cassette)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cassette: %w", err)
+	}
+
+	r.used = make([]bool, len(r.cassette.Interactions))
+
+	return r, nil
+}
+
+// HTTPClient returns an HTTP client using the Recorder.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+
+	if req.Body != nil {
+		var err error
+
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		_ = req.Body.Close()
+
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, reqBody)
+	}
+
+	return r.replay(req)
+}
+
+func (r *Recorder) record(req *http.Request, reqBody []byte) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Body:   scrub(string(reqBody)),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       scrub(string(respBody)),
+		},
+	}
+
+	if r.Scrub != nil {
+		r.Scrub(&interaction)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay returns the response of the first unused interaction matching the method and the URL of the request.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || interaction.Request.Method != req.Method || interaction.Request.URL != req.URL.String() {
+			continue
+		}
+
+		r.used[i] = true
+
+		return &http.Response{
+			Status:        http.StatusText(interaction.Response.StatusCode),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction for %s %s", req.Method, req.URL)
+}
+
+// Save writes the recorded interactions into the cassette file (record mode only).
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return errors.New("the recorder is not in record mode")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+
+	return os.WriteFile(r.path, data, 0o600)
+}
+
+func scrub(body string) string {
+	return secretFields.ReplaceAllString(body, `$1"`+redacted+`"`)
+}
@@ -0,0 +1,84 @@
+package desectest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nrdcg/desec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("POST /auth/tokens/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = rw.Write([]byte(`{"id":"3a6b94b5-d20e-40bd-a7cc-521f5c79fab3","name":"test","token":"4pnk7u-NHvrEkFzrhFDRTjGFyX_S"}`))
+	})
+
+	mux.HandleFunc("GET /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"name":"example.com"}]`))
+	})
+
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := NewRecorder(cassette, ModeRecord)
+	require.NoError(t, err)
+
+	token, domains := exercise(t, recorder, server.URL)
+
+	assert.Equal(t, "4pnk7u-NHvrEkFzrhFDRTjGFyX_S", token.Value)
+	assert.Equal(t, []desec.Domain{{Name: "example.com"}}, domains)
+
+	require.NoError(t, recorder.Save())
+
+	data, err := os.ReadFile(cassette)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(data), "4pnk7u-NHvrEkFzrhFDRTjGFyX_S")
+	assert.NotContains(t, string(data), "Authorization")
+
+	server.Close()
+
+	recorder, err = NewRecorder(cassette, ModeReplay)
+	require.NoError(t, err)
+
+	token, domains = exercise(t, recorder, server.URL)
+
+	assert.Equal(t, "[REDACTED]", token.Value)
+	assert.Equal(t, []desec.Domain{{Name: "example.com"}}, domains)
+
+	_, err = newClient(recorder, server.URL).Domains.Get(context.Background(), "example.org")
+	require.ErrorContains(t, err, "no recorded interaction for GET "+server.URL+"/domains/example.org/")
+}
+
+func exercise(t *testing.T, recorder *Recorder, baseURL string) (*desec.Token, []desec.Domain) {
+	t.Helper()
+
+	client := newClient(recorder, baseURL)
+
+	token, err := client.Tokens.Create(context.Background(), "test")
+	require.NoError(t, err)
+
+	domains, err := client.Domains.GetAll(context.Background())
+	require.NoError(t, err)
+
+	return token, domains
+}
+
+func newClient(recorder *Recorder, baseURL string) *desec.Client {
+	opts := desec.NewDefaultClientOptions()
+	opts.HTTPClient = recorder.HTTPClient()
+	opts.RetryMax = 0
+
+	client := desec.New("secret", opts)
+	client.BaseURL = baseURL
+
+	return client
+}
@@ -0,0 +1,88 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// ErrDialerTransport the dial function (ClientOptions.DialContext) can't be used with the transport of the HTTP client,
+// which is not an *http.Transport: the requests fail with this error instead of silently ignoring the dial function.
+var ErrDialerTransport = errors.New("DialContext requires an *http.Transport")
+
+// DialContextFunc dials a network connection (ClientOptions.DialContext).
+// It has the signature of net.Dialer.DialContext and proxy.ContextDialer.DialContext (golang.org/x/net/proxy, ex: SOCKS5).
+type DialContextFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// UnixSocketDialer returns a DialContextFunc that connects to a Unix socket, whatever the address of the request.
+// Useful for the test harnesses and the local proxies.
+func UnixSocketDialer(path string) DialContextFunc {
+	var dialer net.Dialer
+
+	return func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "unix", path)
+	}
+}
+
+// ResolveDialer returns a DialContextFunc that connects to the address resolved by the resolve function
+// (ex: to pin the address of desec.io), the hostname is still used for TLS.
+func ResolveDialer(resolve func(ctx context.Context, host string) (string, error)) DialContextFunc {
+	var dialer net.Dialer
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		ip, err := resolve(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		return dialer.DialContext(ctx, network, net.JoinHostPort(ip, port))
+	}
+}
+
+// withDialer returns a copy of the HTTP client, with a transport using the dial function.
+// The transport must be an *http.Transport (or nil), otherwise the requests of the client fail with ErrDialerTransport.
+func withDialer(client *http.Client, dial DialContextFunc) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var transport *http.Transport
+
+	switch t := client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		rejected := *client
+		rejected.Transport = rejectTransport{err: ErrDialerTransport}
+
+		return &rejected
+	}
+
+	transport.DialContext = dial
+
+	dialed := *client
+	dialed.Transport = transport
+
+	return &dialed
+}
+
+// rejectTransport a transport that fails all the requests with the error of a misconfiguration.
+type rejectTransport struct {
+	err error
+}
+
+func (t rejectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	return nil, t.err
+}
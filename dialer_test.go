@@ -0,0 +1,97 @@
+package desec
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_unixSocketDialer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "desec.sock")
+
+	listener, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"name":"example.com"}]`))
+	})
+
+	server := &http.Server{Handler: mux}
+	t.Cleanup(func() { _ = server.Close() })
+
+	go func() { _ = server.Serve(listener) }()
+
+	opts := NewDefaultClientOptions()
+	opts.DialContext = UnixSocketDialer(path)
+
+	client := New("token", opts)
+	client.BaseURL = "http://desec.invalid/"
+
+	domains, err := client.Domains.GetAll(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []Domain{{Name: "example.com"}}, domains)
+}
+
+func TestClient_resolveDialer(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("GET /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	var resolved []string
+
+	opts := NewDefaultClientOptions()
+	opts.DialContext = ResolveDialer(func(_ context.Context, host string) (string, error) {
+		resolved = append(resolved, host)
+		return serverURL.Hostname(), nil
+	})
+
+	client := New("token", opts)
+	client.BaseURL = "http://desec.invalid:" + serverURL.Port() + "/"
+
+	_, err = client.Domains.GetAll(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"desec.invalid"}, resolved)
+}
+
+type countingTransport struct {
+	calls int
+}
+
+func (t *countingTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	t.calls++
+
+	return nil, net.ErrClosed
+}
+
+func TestClient_dialer_unsupportedTransport(t *testing.T) {
+	transport := &countingTransport{}
+
+	opts := NewDefaultClientOptions()
+	opts.HTTPClient = &http.Client{Transport: transport}
+	opts.DialContext = UnixSocketDialer(filepath.Join(t.TempDir(), "desec.sock"))
+
+	client := New("token", opts)
+
+	_, err := client.Domains.GetAll(context.Background())
+	require.ErrorIs(t, err, ErrDialerTransport)
+
+	assert.Zero(t, transport.calls)
+}
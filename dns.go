@@ -0,0 +1,104 @@
+package desec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// RRSetToRRs converts a RRSet to miekg/dns resource records.
+// The owner name is built from the domain and the subname of the RRSet.
+func RRSetToRRs(rrSet RRSet) ([]dns.RR, error) {
+	if rrSet.Domain == "" {
+		return nil, errors.New("missing domain")
+	}
+
+	owner := OwnerName(rrSet.Domain, rrSet.SubName)
+
+	rrs := make([]dns.RR, 0, len(rrSet.Records))
+
+	for _, record := range rrSet.Records {
+		rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", owner, rrSet.TTL, rrSet.Type, record))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse record %q: %w", record, err)
+		}
+
+		if rr == nil {
+			return nil, fmt.Errorf("empty record: %q", record)
+		}
+
+		rrs = append(rrs, rr)
+	}
+
+	return rrs, nil
+}
+
+// RRSetFromRRs converts miekg/dns resource records to a RRSet.
+// All the resource records must have the same owner name, type, and must belong to the domain.
+// The TTL of the RRSet is the TTL of the first resource record.
+func RRSetFromRRs(domainName string, rrs []dns.RR) (*RRSet, error) {
+	if len(rrs) == 0 {
+		return nil, errors.New("no resource records")
+	}
+
+	first := rrs[0].Header()
+
+	subName, err := SubNameFromOwner(domainName, first.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	rrSet := &RRSet{
+		Name:    dns.Fqdn(first.Name),
+		Domain:  domainName,
+		SubName: subName,
+		Type:    dns.TypeToString[first.Rrtype],
+		TTL:     int(first.Ttl),
+		Records: make([]string, 0, len(rrs)),
+	}
+
+	for _, rr := range rrs {
+		hdr := rr.Header()
+
+		if !strings.EqualFold(dns.Fqdn(hdr.Name), rrSet.Name) {
+			return nil, fmt.Errorf("owner name mismatch: %s != %s", hdr.Name, rrSet.Name)
+		}
+
+		if hdr.Rrtype != first.Rrtype {
+			return nil, fmt.Errorf("type mismatch: %s != %s", dns.TypeToString[hdr.Rrtype], rrSet.Type)
+		}
+
+		rrSet.Records = append(rrSet.Records, strings.TrimPrefix(rr.String(), hdr.String()))
+	}
+
+	return rrSet, nil
+}
+
+// OwnerName returns the fully qualified owner name for a subname of a domain.
+func OwnerName(domainName, subName string) string {
+	subName = normalizeSubName(subName)
+	if subName == "" {
+		return dns.Fqdn(domainName)
+	}
+
+	return dns.Fqdn(subName + "." + strings.TrimSuffix(domainName, "."))
+}
+
+// SubNameFromOwner returns the subname of an owner name inside a domain.
+func SubNameFromOwner(domainName, owner string) (string, error) {
+	owner = strings.ToLower(dns.Fqdn(owner))
+	domain := strings.ToLower(dns.Fqdn(domainName))
+
+	if owner == domain {
+		return "", nil
+	}
+
+	subName, found := strings.CutSuffix(owner, "."+domain)
+	if !found {
+		return "", fmt.Errorf("%s is not inside %s", owner, domainName)
+	}
+
+	return subName, nil
+}
@@ -0,0 +1,121 @@
+package desec
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRRSetToRRs(t *testing.T) {
+	rrSet := RRSet{
+		Domain:  "example.dedyn.io",
+		SubName: "_acme-challenge",
+		Type:    "TXT",
+		Records: []string{`"foo"`, `"bar"`},
+		TTL:     300,
+	}
+
+	rrs, err := RRSetToRRs(rrSet)
+	require.NoError(t, err)
+
+	require.Len(t, rrs, 2)
+
+	assert.Equal(t, "_acme-challenge.example.dedyn.io.\t300\tIN\tTXT\t\"foo\"", rrs[0].String())
+	assert.Equal(t, "_acme-challenge.example.dedyn.io.\t300\tIN\tTXT\t\"bar\"", rrs[1].String())
+}
+
+func TestRRSetToRRs_apex(t *testing.T) {
+	rrSet := RRSet{
+		Domain:  "example.dedyn.io",
+		Type:    "MX",
+		Records: []string{"10 mx.example.com."},
+		TTL:     3600,
+	}
+
+	rrs, err := RRSetToRRs(rrSet)
+	require.NoError(t, err)
+
+	require.Len(t, rrs, 1)
+
+	mx, ok := rrs[0].(*dns.MX)
+	require.True(t, ok)
+
+	assert.Equal(t, "example.dedyn.io.", mx.Hdr.Name)
+	assert.Equal(t, uint16(10), mx.Preference)
+	assert.Equal(t, "mx.example.com.", mx.Mx)
+}
+
+func TestRRSetFromRRs(t *testing.T) {
+	rrs := []dns.RR{
+		mustNewRR(t, "www.example.dedyn.io. 60 IN A 10.10.10.10"),
+		mustNewRR(t, "www.example.dedyn.io. 60 IN A 10.10.10.11"),
+	}
+
+	rrSet, err := RRSetFromRRs("example.dedyn.io", rrs)
+	require.NoError(t, err)
+
+	expected := &RRSet{
+		Name:    "www.example.dedyn.io.",
+		Domain:  "example.dedyn.io",
+		SubName: "www",
+		Type:    "A",
+		Records: []string{"10.10.10.10", "10.10.10.11"},
+		TTL:     60,
+	}
+	assert.Equal(t, expected, rrSet)
+}
+
+func TestRRSetFromRRs_errors(t *testing.T) {
+	testCases := []struct {
+		desc string
+		rrs  []dns.RR
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc: "outside domain",
+			rrs:  []dns.RR{mustNewRR(t, "www.example.com. 60 IN A 10.10.10.10")},
+		},
+		{
+			desc: "type mismatch",
+			rrs: []dns.RR{
+				mustNewRR(t, "www.example.dedyn.io. 60 IN A 10.10.10.10"),
+				mustNewRR(t, "www.example.dedyn.io. 60 IN AAAA ::1"),
+			},
+		},
+		{
+			desc: "owner mismatch",
+			rrs: []dns.RR{
+				mustNewRR(t, "www.example.dedyn.io. 60 IN A 10.10.10.10"),
+				mustNewRR(t, "foo.example.dedyn.io. 60 IN A 10.10.10.11"),
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := RRSetFromRRs("example.dedyn.io", test.rrs)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestOwnerName(t *testing.T) {
+	assert.Equal(t, "example.dedyn.io.", OwnerName("example.dedyn.io", ""))
+	assert.Equal(t, "example.dedyn.io.", OwnerName("example.dedyn.io.", ApexZone))
+	assert.Equal(t, "a.b.example.dedyn.io.", OwnerName("example.dedyn.io", "a.b"))
+}
+
+func mustNewRR(t *testing.T, s string) dns.RR {
+	t.Helper()
+
+	rr, err := dns.NewRR(s)
+	require.NoError(t, err)
+
+	return rr
+}
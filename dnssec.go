@@ -0,0 +1,271 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// DNSSEC checks.
+const (
+	CheckDS     = "DS"
+	CheckDNSKEY = "DNSKEY"
+	CheckRRSIG  = "RRSIG"
+)
+
+// rrsigExpiryWarning the remaining validity of a RRSIG below which a warning is reported.
+const rrsigExpiryWarning = 72 * time.Hour
+
+// DNSSECFinding a problem found by VerifyDNSSEC.
+type DNSSECFinding struct {
+	Check    string
+	Severity string
+	Message  string
+}
+
+// DNSSECReport the result of VerifyDNSSEC.
+type DNSSECReport struct {
+	Domain   string
+	Findings []DNSSECFinding
+}
+
+// OK returns true if the report doesn't contain errors.
+func (r *DNSSECReport) OK() bool {
+	for _, finding := range r.Findings {
+		if finding.Severity == SeverityError {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *DNSSECReport) add(check, severity, format string, args ...any) {
+	r.Findings = append(r.Findings, DNSSECFinding{
+		Check:    check,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// VerifyDNSSEC checks the DNSSEC chain of a domain:
+// the DS records of the parent zone must match the keys of the domain,
+// the keys must be published, and the DNSKEY and SOA RRSIGs must be valid.
+// The DNS queries are sent to ClientOptions.Resolver.
+func (s *DomainsService) VerifyDNSSEC(ctx context.Context, domainName string) (*DNSSECReport, error) {
+	domain, err := s.Get(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	zone, err := toASCII(dns.Fqdn(domainName))
+	if err != nil {
+		return nil, err
+	}
+
+	expectedDS, expectedKeys, err := parseDomainKeys(zone, domain.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DNSSECReport{Domain: domainName}
+
+	parentDS, _, err := s.client.queryDNSSEC(ctx, zone, dns.TypeDS)
+	if err != nil {
+		return nil, err
+	}
+
+	checkDS(report, parentDS, expectedDS)
+
+	dnsKeys, dnsKeySigs, err := s.client.queryDNSSEC(ctx, zone, dns.TypeDNSKEY)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := checkDNSKEY(report, dnsKeys, expectedKeys)
+
+	checkRRSIG(report, "DNSKEY", dnsKeys, dnsKeySigs, keys)
+
+	soa, soaSigs, err := s.client.queryDNSSEC(ctx, zone, dns.TypeSOA)
+	if err != nil {
+		return nil, err
+	}
+
+	checkRRSIG(report, "SOA", soa, soaSigs, keys)
+
+	return report, nil
+}
+
+// queryDNSSEC returns the records of a type and the RRSIGs covering them.
+// The DNSSEC validation of the resolver is disabled, to be able to inspect a broken chain.
+func (c *Client) queryDNSSEC(ctx context.Context, name string, qType uint16) ([]dns.RR, []*dns.RRSIG, error) {
+	msg := new(dns.Msg)
+	msg.SetQuestion(name, qType)
+	msg.SetEdns0(4096, true)
+	msg.CheckingDisabled = true
+
+	resp, _, err := new(dns.Client).ExchangeContext(ctx, msg, c.resolver)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query %s %s: %w", name, dns.TypeToString[qType], err)
+	}
+
+	if resp.Rcode != dns.RcodeSuccess {
+		return nil, nil, fmt.Errorf("failed to query %s %s: %s", name, dns.TypeToString[qType], dns.RcodeToString[resp.Rcode])
+	}
+
+	var (
+		rrs  []dns.RR
+		sigs []*dns.RRSIG
+	)
+
+	for _, rr := range resp.Answer {
+		switch {
+		case rr.Header().Rrtype == qType:
+			rrs = append(rrs, rr)
+		case rr.Header().Rrtype == dns.TypeRRSIG && rr.(*dns.RRSIG).TypeCovered == qType:
+			sigs = append(sigs, rr.(*dns.RRSIG))
+		}
+	}
+
+	return rrs, sigs, nil
+}
+
+func parseDomainKeys(zone string, domainKeys []DomainKey) ([]*dns.DS, []*dns.DNSKEY, error) {
+	var (
+		expectedDS   []*dns.DS
+		expectedKeys []*dns.DNSKEY
+	)
+
+	for _, domainKey := range domainKeys {
+		rr, err := dns.NewRR(fmt.Sprintf("%s 3600 IN DNSKEY %s", zone, domainKey.DNSKey))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse DNSKEY %q: %w", domainKey.DNSKey, err)
+		}
+
+		if key, ok := rr.(*dns.DNSKEY); ok {
+			expectedKeys = append(expectedKeys, key)
+		}
+
+		for _, ds := range domainKey.DS {
+			rr, err := dns.NewRR(fmt.Sprintf("%s 3600 IN DS %s", zone, ds))
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to parse DS %q: %w", ds, err)
+			}
+
+			if ds, ok := rr.(*dns.DS); ok {
+				expectedDS = append(expectedDS, ds)
+			}
+		}
+	}
+
+	return expectedDS, expectedKeys, nil
+}
+
+func checkDS(report *DNSSECReport, parentDS []dns.RR, expected []*dns.DS) {
+	if len(parentDS) == 0 {
+		report.add(CheckDS, SeverityError, "no DS record in the parent zone (insecure delegation)")
+		return
+	}
+
+	for _, rr := range parentDS {
+		ds, ok := rr.(*dns.DS)
+		if !ok {
+			continue
+		}
+
+		if !matchDS(ds, expected) {
+			report.add(CheckDS, SeverityError, "DS %d %d %d in the parent zone doesn't match any key of the domain", ds.KeyTag, ds.Algorithm, ds.DigestType)
+		}
+	}
+}
+
+func matchDS(ds *dns.DS, expected []*dns.DS) bool {
+	for _, e := range expected {
+		if ds.KeyTag == e.KeyTag && ds.Algorithm == e.Algorithm && ds.DigestType == e.DigestType && strings.EqualFold(ds.Digest, e.Digest) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkDNSKEY checks that the keys of the domain are published, and returns the published keys.
+func checkDNSKEY(report *DNSSECReport, published []dns.RR, expected []*dns.DNSKEY) []*dns.DNSKEY {
+	var keys []*dns.DNSKEY
+
+	for _, rr := range published {
+		if key, ok := rr.(*dns.DNSKEY); ok {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, e := range expected {
+		if !containsKey(keys, e) {
+			report.add(CheckDNSKEY, SeverityError, "key %d is not published", e.KeyTag())
+		}
+	}
+
+	return keys
+}
+
+func containsKey(keys []*dns.DNSKEY, key *dns.DNSKEY) bool {
+	for _, k := range keys {
+		if k.Algorithm == key.Algorithm && k.Flags == key.Flags && k.PublicKey == key.PublicKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkRRSIG(report *DNSSECReport, rrType string, rrs []dns.RR, sigs []*dns.RRSIG, keys []*dns.DNSKEY) {
+	if len(rrs) == 0 {
+		report.add(CheckRRSIG, SeverityError, "no %s record", rrType)
+		return
+	}
+
+	if len(sigs) == 0 {
+		report.add(CheckRRSIG, SeverityError, "%s is not signed", rrType)
+		return
+	}
+
+	now := time.Now()
+
+	var valid bool
+
+	for _, sig := range sigs {
+		if !sig.ValidityPeriod(now) {
+			report.add(CheckRRSIG, SeverityError, "RRSIG %s (key %d) is outside its validity period", rrType, sig.KeyTag)
+			continue
+		}
+
+		if !verifyRRSIG(sig, rrs, keys) {
+			report.add(CheckRRSIG, SeverityError, "RRSIG %s (key %d) cannot be verified", rrType, sig.KeyTag)
+			continue
+		}
+
+		valid = true
+
+		expiration := time.Unix(int64(sig.Expiration), 0)
+		if expiration.Sub(now) < rrsigExpiryWarning {
+			report.add(CheckRRSIG, SeverityWarning, "RRSIG %s (key %d) expires at %s", rrType, sig.KeyTag, expiration.UTC().Format(time.RFC3339))
+		}
+	}
+
+	if !valid {
+		report.add(CheckRRSIG, SeverityError, "no valid RRSIG for %s", rrType)
+	}
+}
+
+func verifyRRSIG(sig *dns.RRSIG, rrs []dns.RR, keys []*dns.DNSKEY) bool {
+	for _, key := range keys {
+		if key.KeyTag() == sig.KeyTag && key.Algorithm == sig.Algorithm && sig.Verify(key, rrs) == nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,165 @@
+package desec
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testZoneKey struct {
+	key    *dns.DNSKEY
+	signer crypto.Signer
+}
+
+func newTestZoneKey(t *testing.T, zone string) testZoneKey {
+	t.Helper()
+
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: zone, Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 3600},
+		Flags:     257,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+	}
+
+	private, err := key.Generate(256)
+	require.NoError(t, err)
+
+	signer, ok := private.(crypto.Signer)
+	require.True(t, ok)
+
+	return testZoneKey{key: key, signer: signer}
+}
+
+func (k testZoneKey) sign(t *testing.T, rrs []dns.RR, inception, expiration time.Time) *dns.RRSIG {
+	t.Helper()
+
+	sig := &dns.RRSIG{
+		Hdr:        dns.RR_Header{Name: rrs[0].Header().Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: 3600},
+		Inception:  uint32(inception.Unix()),
+		Expiration: uint32(expiration.Unix()),
+		KeyTag:     k.key.KeyTag(),
+		SignerName: k.key.Hdr.Name,
+		Algorithm:  k.key.Algorithm,
+	}
+
+	err := sig.Sign(k.signer, rrs)
+	require.NoError(t, err)
+
+	return sig
+}
+
+func (k testZoneKey) domainKey() DomainKey {
+	ds := k.key.ToDS(dns.SHA256)
+
+	return DomainKey{
+		DNSKey:  strings.TrimPrefix(k.key.String(), k.key.Hdr.String()),
+		DS:      []string{strings.TrimPrefix(ds.String(), ds.Hdr.String())},
+		Flags:   int(k.key.Flags),
+		KeyType: "csk",
+	}
+}
+
+func setupDNSSECTest(t *testing.T, key testZoneKey, records ...string) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/domains/example.dedyn.io/", func(rw http.ResponseWriter, req *http.Request) {
+		domainKey := key.domainKey()
+		_, _ = fmt.Fprintf(rw, `{"name":"example.dedyn.io","keys":[{"dnskey":%q,"ds":[%q],"flags":257,"keytype":"csk"}]}`, domainKey.DNSKey, domainKey.DS[0])
+	})
+
+	opts := NewDefaultClientOptions()
+	opts.Resolver = startDNSServer(t, records...)
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	return client
+}
+
+func TestDomainsService_VerifyDNSSEC(t *testing.T) {
+	key := newTestZoneKey(t, "example.dedyn.io.")
+
+	soa := mustNewRR(t, "example.dedyn.io. 3600 IN SOA get.desec.io. get.desec.io. 1 86400 3600 2419200 3600")
+
+	now := time.Now()
+
+	client := setupDNSSECTest(t, key,
+		key.key.ToDS(dns.SHA256).String(),
+		key.key.String(),
+		key.sign(t, []dns.RR{key.key}, now.Add(-time.Hour), now.Add(7*24*time.Hour)).String(),
+		soa.String(),
+		key.sign(t, []dns.RR{soa}, now.Add(-time.Hour), now.Add(24*time.Hour)).String(),
+	)
+
+	report, err := client.Domains.VerifyDNSSEC(context.Background(), "example.dedyn.io")
+	require.NoError(t, err)
+
+	assert.True(t, report.OK())
+
+	// the SOA RRSIG expires in less than 72h.
+	require.Len(t, report.Findings, 1)
+	assert.Equal(t, CheckRRSIG, report.Findings[0].Check)
+	assert.Equal(t, SeverityWarning, report.Findings[0].Severity)
+}
+
+func TestDomainsService_VerifyDNSSEC_broken(t *testing.T) {
+	key := newTestZoneKey(t, "example.dedyn.io.")
+	oldKey := newTestZoneKey(t, "example.dedyn.io.")
+
+	soa := mustNewRR(t, "example.dedyn.io. 3600 IN SOA get.desec.io. get.desec.io. 1 86400 3600 2419200 3600")
+
+	now := time.Now()
+
+	// The parent zone still contains the DS of the old key, and the SOA RRSIG is expired.
+	client := setupDNSSECTest(t, key,
+		oldKey.key.ToDS(dns.SHA256).String(),
+		key.key.String(),
+		key.sign(t, []dns.RR{key.key}, now.Add(-time.Hour), now.Add(7*24*time.Hour)).String(),
+		soa.String(),
+		key.sign(t, []dns.RR{soa}, now.Add(-48*time.Hour), now.Add(-24*time.Hour)).String(),
+	)
+
+	report, err := client.Domains.VerifyDNSSEC(context.Background(), "example.dedyn.io")
+	require.NoError(t, err)
+
+	assert.False(t, report.OK())
+
+	var checks []string
+	for _, finding := range report.Findings {
+		assert.Equal(t, SeverityError, finding.Severity)
+		checks = append(checks, finding.Check)
+	}
+
+	assert.Equal(t, []string{CheckDS, CheckRRSIG, CheckRRSIG}, checks)
+}
+
+func TestDomainsService_VerifyDNSSEC_insecure(t *testing.T) {
+	key := newTestZoneKey(t, "example.dedyn.io.")
+
+	client := setupDNSSECTest(t, key)
+
+	report, err := client.Domains.VerifyDNSSEC(context.Background(), "example.dedyn.io")
+	require.NoError(t, err)
+
+	assert.False(t, report.OK())
+
+	var checks []string
+	for _, finding := range report.Findings {
+		checks = append(checks, finding.Check)
+	}
+
+	assert.Equal(t, []string{CheckDS, CheckDNSKEY, CheckRRSIG, CheckRRSIG}, checks)
+}
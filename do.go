@@ -0,0 +1,85 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Do sends an API request to an endpoint not yet wrapped by the library.
+// The path is relative to the base URL (ex: "auth/totp", "domains/example.com/rrsets?subname=www"),
+// the trailing slash is added automatically.
+// The body (if not nil) is encoded as JSON, and the response body is decoded into out (if not nil).
+// Any non-2xx response is returned as an error.
+func (c *Client) Do(ctx context.Context, method, path string, body, out any) error {
+	uri, err := url.Parse(path)
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+
+	var parts []string
+	if p := strings.Trim(uri.Path, "/"); p != "" {
+		parts = strings.Split(p, "/")
+	}
+
+	endpoint, err := c.createEndpoint(parts...)
+	if err != nil {
+		return fmt.Errorf("failed to create endpoint: %w", err)
+	}
+
+	endpoint.RawQuery = uri.RawQuery
+
+	_, err = c.send(ctx, method, endpoint, body, out, handleError)
+
+	return err
+}
+
+// do sends an API request, and decodes the response body into a T.
+// Any non-2xx response is returned as an error (handleError).
+// The body (if not nil) is encoded as JSON, an empty response body leaves the zero value (ex: 204 No Content).
+func do[T any](ctx context.Context, c *Client, method string, endpoint *url.URL, body any) (T, *Response, error) {
+	return doWithErrorHandler[T](ctx, c, method, endpoint, body, handleError)
+}
+
+// doWithErrorHandler is do, with a custom handling of the non-2xx responses.
+func doWithErrorHandler[T any](ctx context.Context, c *Client, method string, endpoint *url.URL, body any, onError func(resp *http.Response) error) (T, *Response, error) {
+	var out T
+
+	meta, err := c.send(ctx, method, endpoint, body, &out, onError)
+	if err != nil {
+		var zero T
+		return zero, meta, err
+	}
+
+	return out, meta, nil
+}
+
+// send is the core of the API calls: it sends the request through the middlewares (retries, hooks, audit, ...),
+// handles the errors, decodes the response body into out (if not nil), and returns the metadata of the response.
+func (c *Client) send(ctx context.Context, method string, endpoint *url.URL, body, out any, onError func(resp *http.Response) error) (*Response, error) {
+	req, err := c.newRequest(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call API: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	meta := newResponse(resp)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &meta, c.inspectToken(ctx, onError(resp))
+	}
+
+	if out == nil {
+		return &meta, nil
+	}
+
+	return &meta, c.handleResponse(resp, out)
+}
@@ -0,0 +1,122 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Do(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/auth/totp/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Token token" {
+			http.Error(rw, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		if req.URL.Query().Get("foo") != "bar" {
+			http.Error(rw, "invalid query", http.StatusBadRequest)
+			return
+		}
+
+		var body map[string]string
+		err := json.NewDecoder(req.Body).Decode(&body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(rw).Encode(map[string]string{"id": "1", "name": body["name"]})
+	})
+
+	var out map[string]string
+
+	err := client.Do(context.Background(), http.MethodPost, "/auth/totp?foo=bar", map[string]string{"name": "test"}, &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"id": "1", "name": "test"}, out)
+}
+
+func TestClient_Do_error(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/auth/totp/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"detail":"Not found."}`))
+	})
+
+	err := client.Do(context.Background(), http.MethodGet, "auth/totp", nil, nil)
+	require.Error(t, err)
+
+	var notFound *NotFoundError
+	require.ErrorAs(t, err, &notFound)
+}
+
+func Test_do(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("X-Request-Id", "abc")
+		_, _ = rw.Write([]byte(`{"name":"example.com"}`))
+	})
+
+	mux.HandleFunc("DELETE /domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("GET /domains/example.org/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"detail":"Not found."}`))
+	})
+
+	endpoint, err := client.createEndpoint("domains", "example.com")
+	require.NoError(t, err)
+
+	domain, resp, err := do[Domain](context.Background(), client, http.MethodGet, endpoint, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", domain.Name)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "abc", resp.RequestID)
+
+	deleted, resp, err := do[*Domain](context.Background(), client, http.MethodDelete, endpoint, nil)
+	require.NoError(t, err)
+
+	assert.Nil(t, deleted)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	endpoint, err = client.createEndpoint("domains", "example.org")
+	require.NoError(t, err)
+
+	_, resp, err = do[Domain](context.Background(), client, http.MethodGet, endpoint, nil)
+	require.ErrorAs(t, err, new(*NotFoundError))
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,123 @@
+package desec
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DomainCache keeps the domains of the account in memory.
+// The domains are refreshed when they are older than the TTL, manually (Refresh), or in the background (Run).
+type DomainCache struct {
+	service *DomainsService
+	ttl     time.Duration
+
+	mu      sync.RWMutex
+	domains []Domain
+	fetched time.Time
+}
+
+// NewDomainCache creates a new DomainCache.
+// A TTL of 0 disables the automatic refresh.
+func NewDomainCache(service *DomainsService, ttl time.Duration) *DomainCache {
+	return &DomainCache{service: service, ttl: ttl}
+}
+
+// Domains returns the cached domains, they are fetched if the cache is empty or expired.
+func (c *DomainCache) Domains(ctx context.Context) ([]Domain, error) {
+	c.mu.RLock()
+	domains, fetched := c.domains, c.fetched
+	c.mu.RUnlock()
+
+	if !fetched.IsZero() && (c.ttl <= 0 || time.Since(fetched) < c.ttl) {
+		return domains, nil
+	}
+
+	err := c.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.domains, nil
+}
+
+// Refresh fetches the domains.
+func (c *DomainCache) Refresh(ctx context.Context) error {
+	domains, err := c.service.getAllPages(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.domains = domains
+	c.fetched = time.Now()
+
+	return nil
+}
+
+// Match returns the domain owning a fully qualified domain name (longest suffix match) and the subname.
+// A NotFoundError is returned if no domain matches.
+func (c *DomainCache) Match(ctx context.Context, fqdn string) (*Domain, string, error) {
+	domains, err := c.Domains(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	domain, ok := longestSuffixMatch(domains, fqdn)
+	if !ok {
+		return nil, "", &NotFoundError{Detail: "no responsible domain found"}
+	}
+
+	subName, err := SubNameFromOwner(domain.Name, fqdn)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &domain, subName, nil
+}
+
+// Run refreshes the domains periodically until the context is canceled.
+// The refresh errors are ignored: the previous domains are kept.
+func (c *DomainCache) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = c.Refresh(ctx)
+		}
+	}
+}
+
+func longestSuffixMatch(domains []Domain, fqdn string) (Domain, bool) {
+	name := strings.ToLower(strings.TrimSuffix(fqdn, "."))
+
+	var (
+		match Domain
+		found bool
+	)
+
+	for _, domain := range domains {
+		domainName := strings.ToLower(strings.TrimSuffix(domain.Name, "."))
+
+		if name != domainName && !strings.HasSuffix(name, "."+domainName) {
+			continue
+		}
+
+		if !found || len(domainName) > len(strings.TrimSuffix(match.Name, ".")) {
+			match = domain
+			found = true
+		}
+	}
+
+	return match, found
+}
@@ -0,0 +1,68 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainCache_Match(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var calls int
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		if req.URL.Query().Get("cursor") == "" {
+			rw.Header().Set("Link", `<`+server.URL+`/domains/?cursor=>; rel="first", <`+server.URL+`/domains/?cursor=next>; rel="next"`)
+			_, _ = rw.Write([]byte(`[{"name":"example.com"}]`))
+
+			return
+		}
+
+		_, _ = rw.Write([]byte(`[{"name":"sub.example.com"},{"name":"example.org"}]`))
+	})
+
+	cache := NewDomainCache(client.Domains, time.Hour)
+
+	testCases := []struct {
+		fqdn    string
+		domain  string
+		subName string
+	}{
+		{fqdn: "www.example.com.", domain: "example.com", subName: "www"},
+		{fqdn: "example.com", domain: "example.com", subName: ""},
+		{fqdn: "_acme-challenge.www.sub.example.com", domain: "sub.example.com", subName: "_acme-challenge.www"},
+		{fqdn: "example.org.", domain: "example.org", subName: ""},
+	}
+
+	for _, test := range testCases {
+		domain, subName, err := cache.Match(context.Background(), test.fqdn)
+		require.NoError(t, err)
+
+		assert.Equal(t, test.domain, domain.Name)
+		assert.Equal(t, test.subName, subName)
+	}
+
+	_, _, err := cache.Match(context.Background(), "notexample.com")
+	require.ErrorAs(t, err, new(*NotFoundError))
+
+	// the pages are fetched only once.
+	assert.Equal(t, 2, calls)
+
+	err = cache.Refresh(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, calls)
+}
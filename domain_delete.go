@@ -0,0 +1,55 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DeleteGuard the conditions required by DeleteSafe, at least one must be set.
+type DeleteGuard struct {
+	// ConfirmName must match the name of the domain (case-insensitive).
+	ConfirmName string
+
+	// RRSetCount must match the current number of RRSets of the domain.
+	RRSetCount *int
+}
+
+// DeleteGuardError the deletion of a domain has been refused by a DeleteGuard.
+type DeleteGuardError struct {
+	Domain string
+	Reason string
+}
+
+func (e DeleteGuardError) Error() string {
+	return fmt.Sprintf("deletion of the domain %s refused: %s", e.Domain, e.Reason)
+}
+
+// DeleteSafe deletes a domain only if the conditions of the guard are met.
+// It prevents automation bugs from deleting the wrong zone.
+func (s *DomainsService) DeleteSafe(ctx context.Context, domainName string, guard DeleteGuard) error {
+	if guard.ConfirmName == "" && guard.RRSetCount == nil {
+		return errors.New("the guard has no condition")
+	}
+
+	if guard.ConfirmName != "" && !strings.EqualFold(guard.ConfirmName, domainName) {
+		return &DeleteGuardError{Domain: domainName, Reason: fmt.Sprintf("the confirmation %q doesn't match the domain name", guard.ConfirmName)}
+	}
+
+	if guard.RRSetCount != nil {
+		rrSets, err := s.client.Records.GetAll(ctx, domainName, nil)
+		if err != nil {
+			return fmt.Errorf("failed to count RRSets: %w", err)
+		}
+
+		if len(rrSets) != *guard.RRSetCount {
+			return &DeleteGuardError{
+				Domain: domainName,
+				Reason: fmt.Sprintf("the domain has %d RRSets, expected %d", len(rrSets), *guard.RRSetCount),
+			}
+		}
+	}
+
+	return s.Delete(ctx, domainName)
+}
@@ -0,0 +1,68 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainsService_DeleteSafe(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var deleted int
+
+	mux.HandleFunc("DELETE /domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		deleted++
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	mux.HandleFunc("GET /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"subname":"","type":"NS","records":["ns1.desec.io."]},{"subname":"www","type":"A","records":["10.0.0.1"]}]`))
+	})
+
+	one, two := 1, 2
+
+	testCases := []struct {
+		desc     string
+		guard    DeleteGuard
+		expected string
+	}{
+		{
+			desc:     "no condition",
+			expected: "the guard has no condition",
+		},
+		{
+			desc:     "name mismatch",
+			guard:    DeleteGuard{ConfirmName: "example.org"},
+			expected: `deletion of the domain example.com refused: the confirmation "example.org" doesn't match the domain name`,
+		},
+		{
+			desc:     "count mismatch",
+			guard:    DeleteGuard{ConfirmName: "example.com", RRSetCount: &one},
+			expected: "deletion of the domain example.com refused: the domain has 2 RRSets, expected 1",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			err := client.Domains.DeleteSafe(context.Background(), "example.com", test.guard)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+
+	assert.Zero(t, deleted)
+
+	err := client.Domains.DeleteSafe(context.Background(), "example.com", DeleteGuard{ConfirmName: "EXAMPLE.com", RRSetCount: &two})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, deleted)
+}
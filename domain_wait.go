@@ -0,0 +1,92 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// defaultPollInterval the default delay between two checks of WaitForPublication.
+const defaultPollInterval = 2 * time.Second
+
+// WaitOptions the options of WaitForPublication.
+type WaitOptions struct {
+	// PollInterval the delay between two checks (default: 2 seconds).
+	PollInterval time.Duration
+
+	// Nameservers the nameservers (host:port) that must answer for the zone, ex: DeSECNameservers.
+	// If empty, only the publication reported by the API is awaited.
+	Nameservers []string
+}
+
+// CreateAndWait creates a domain and waits until it is published (WaitForPublication).
+// A newly created zone is not immediately served by the nameservers.
+func (s *DomainsService) CreateAndWait(ctx context.Context, domainName string, timeout time.Duration) (*Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	domain, err := s.Create(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.WaitForPublication(ctx, domain.Name, nil)
+}
+
+// WaitForPublication polls a domain until it is published (Domain.Published),
+// and optionally until the nameservers answer for the zone.
+// The waiting is bounded by the context.
+func (s *DomainsService) WaitForPublication(ctx context.Context, domainName string, opts *WaitOptions) (*Domain, error) {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		domain, err := s.Get(ctx, domainName)
+		if err != nil {
+			return nil, err
+		}
+
+		if domain.Published != nil && s.client.nameserversAnswer(ctx, domainName, opts.Nameservers) {
+			return domain, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("domain %s not published: %w", domainName, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// nameserversAnswer returns true if all the nameservers answer authoritatively with the SOA of the zone.
+func (c *Client) nameserversAnswer(ctx context.Context, domainName string, nameservers []string) bool {
+	zone, err := toASCII(dns.Fqdn(domainName))
+	if err != nil {
+		return false
+	}
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(zone, dns.TypeSOA)
+
+	client := new(dns.Client)
+
+	for _, nameserver := range nameservers {
+		resp, _, err := client.ExchangeContext(ctx, msg, nameserver)
+		if err != nil || resp.Rcode != dns.RcodeSuccess || len(resp.Answer) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
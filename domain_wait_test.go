@@ -0,0 +1,77 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainsService_CreateAndWait(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("POST /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = rw.Write([]byte(`{"name":"example.com"}`))
+	})
+
+	var calls int
+
+	mux.HandleFunc("GET /domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		if calls < 2 {
+			_, _ = rw.Write([]byte(`{"name":"example.com"}`))
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"name":"example.com","published":"2020-05-06T12:13:06.138443Z"}`))
+	})
+
+	domain, err := client.Domains.CreateAndWait(context.Background(), "example.com", 10*time.Second)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", domain.Name)
+	assert.Equal(t, mustParseTime("2020-05-06T12:13:06.138443Z"), domain.Published)
+	assert.Equal(t, 2, calls)
+}
+
+func TestDomainsService_WaitForPublication_nameservers(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"name":"example.com","published":"2020-05-06T12:13:06.138443Z"}`))
+	})
+
+	serving := startDNSServer(t, "example.com. 300 IN SOA ns1.desec.io. get.desec.io. 1 86400 3600 2419200 3600")
+	notServing := startDNSServer(t)
+
+	opts := &WaitOptions{PollInterval: 10 * time.Millisecond, Nameservers: []string{serving}}
+
+	domain, err := client.Domains.WaitForPublication(context.Background(), "example.com", opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", domain.Name)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	opts.Nameservers = []string{serving, notServing}
+
+	_, err = client.Domains.WaitForPublication(ctx, "example.com", opts)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -2,9 +2,12 @@ package desec
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -16,14 +19,43 @@ type Domain struct {
 	Created    *time.Time  `json:"created,omitempty"`
 	Published  *time.Time  `json:"published,omitempty"`
 	Touched    *time.Time  `json:"touched,omitempty"`
+
+	// Zonefile the zone file imported on creation (CreateFromZonefile).
+	Zonefile string `json:"zonefile,omitempty"`
+
+	// Extra the fields returned by the API but not modeled by the library.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Domain) UnmarshalJSON(data []byte) error {
+	type alias Domain
+
+	var a alias
+
+	extra, err := unmarshalWithExtra(data, &a)
+	if err != nil {
+		return err
+	}
+
+	*d = Domain(a)
+	d.Extra = extra
+
+	return nil
+}
+
+func (d *Domain) extraFields() map[string]json.RawMessage {
+	return d.Extra
 }
 
 // DomainKey a domain key representation.
 type DomainKey struct {
-	DNSKey  string   `json:"dnskey,omitempty"`
-	DS      []string `json:"ds,omitempty"`
-	Flags   int      `json:"flags,omitempty"`
-	KeyType string   `json:"keytype,omitempty"`
+	DNSKey  string     `json:"dnskey,omitempty"`
+	DS      []string   `json:"ds,omitempty"`
+	Flags   int        `json:"flags,omitempty"`
+	KeyType string     `json:"keytype,omitempty"`
+	Managed bool       `json:"managed,omitempty"`
+	Created *time.Time `json:"created,omitempty"`
 }
 
 // DomainsService handles communication with the domain related methods of the deSEC API.
@@ -36,45 +68,110 @@ type DomainsService struct {
 // Create creating a domain.
 // https://desec.readthedocs.io/en/latest/dns/domains.html#creating-a-domain
 func (s *DomainsService) Create(ctx context.Context, domainName string) (*Domain, error) {
+	return s.create(ctx, Domain{Name: domainName})
+}
+
+// CreateFromZonefile creating a domain and importing the records of a zone file.
+// https://desec.readthedocs.io/en/latest/dns/domains.html#creating-a-domain
+func (s *DomainsService) CreateFromZonefile(ctx context.Context, domainName, zonefile string) (*Domain, error) {
+	return s.create(ctx, Domain{Name: domainName, Zonefile: zonefile})
+}
+
+func (s *DomainsService) create(ctx context.Context, domain Domain) (*Domain, error) {
+	var err error
+
+	domain.Name, err = toASCII(domain.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.client.checkDomainQuota {
+		err = s.client.preflightDomainQuota(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	endpoint, err := s.client.createEndpoint("domains")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, endpoint, Domain{Name: domainName})
+	newDomain, _, err := do[Domain](ctx, s.client, http.MethodPost, endpoint, domain)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
+	s.client.decodeDomain(&newDomain)
+
+	return &newDomain, nil
+}
+
+// DomainListOptions the filters of the domains listing.
+// https://desec.readthedocs.io/en/latest/dns/domains.html#listing-domains
+type DomainListOptions struct {
+	// OwnsQName only lists the domain responsible for a DNS query name.
+	// https://desec.readthedocs.io/en/latest/dns/domains.html#identifying-the-responsible-domain-for-a-dns-name
+	OwnsQName string
+
+	// PageSize the number of domains of a page, 0 uses the default (PageSize).
+	// When the domains are retrieved page by page, the page size is reduced if a page is too large (ClientOptions.MaxResponseSize).
+	PageSize int
+}
+
+func (o *DomainListOptions) query() (url.Values, error) {
+	queryValues := url.Values{}
+
+	if o == nil {
+		return queryValues, nil
 	}
 
-	defer func() { _ = resp.Body.Close() }()
+	if o.OwnsQName != "" {
+		qname, err := toASCII(o.OwnsQName)
+		if err != nil {
+			return nil, err
+		}
 
-	if resp.StatusCode != http.StatusCreated {
-		return nil, handleError(resp)
+		queryValues.Set("owns_qname", qname)
 	}
 
-	var domain Domain
-	err = handleResponse(resp, &domain)
-	if err != nil {
-		return nil, err
+	if o.PageSize < 0 {
+		return nil, fmt.Errorf("invalid page size: %d", o.PageSize)
 	}
 
-	return &domain, nil
+	if o.PageSize > 0 {
+		queryValues.Set(pageSizeParam, strconv.Itoa(o.PageSize))
+	}
+
+	return queryValues, nil
 }
 
 // GetAll listing domains.
+// If there are too many domains to be retrieved at once, all the pages are retrieved.
 // https://desec.readthedocs.io/en/latest/dns/domains.html#listing-domains
 func (s *DomainsService) GetAll(ctx context.Context) ([]Domain, error) {
-	domains, _, err := s.GetAllPaginated(ctx, "")
+	return s.List(ctx, nil)
+}
+
+// List listing domains matching the options.
+// If there are too many domains to be retrieved at once (or the response is too large), all the pages are retrieved.
+// https://desec.readthedocs.io/en/latest/dns/domains.html#listing-domains
+func (s *DomainsService) List(ctx context.Context, opts *DomainListOptions) ([]Domain, error) {
+	query, err := opts.query()
 	if err != nil {
 		return nil, err
 	}
 
-	return domains, nil
+	domains, _, err := s.getAll(ctx, query)
+	if err == nil {
+		return domains, nil
+	}
+
+	if !errors.As(err, new(*PaginationRequiredError)) && !errors.As(err, new(*ResponseTooLargeError)) {
+		return nil, err
+	}
+
+	return s.getAllPages(ctx, query)
 }
 
 // GetAllPaginated listing domains.
@@ -86,13 +183,21 @@ func (s *DomainsService) GetAllPaginated(ctx context.Context, cursor string) ([]
 	return s.getAll(ctx, queryValues)
 }
 
+// getAllPages lists the domains of all the pages.
+func (s *DomainsService) getAllPages(ctx context.Context, query url.Values) ([]Domain, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	return collectPages(query, "", func(query url.Values) ([]Domain, *Cursors, error) {
+		return s.getAll(ctx, query)
+	})
+}
+
 // GetResponsible returns the responsible domain for a given DNS query name.
 // https://desec.readthedocs.io/en/latest/dns/domains.html#identifying-the-responsible-domain-for-a-dns-name
 func (s *DomainsService) GetResponsible(ctx context.Context, domainName string) (*Domain, error) {
-	queryValues := url.Values{}
-	queryValues.Set("owns_qname", domainName)
-
-	domains, _, err := s.getAll(ctx, queryValues)
+	domains, err := s.List(ctx, &DomainListOptions{OwnsQName: domainName})
 	if err != nil {
 		return nil, err
 	}
@@ -112,38 +217,16 @@ func (s *DomainsService) getAll(ctx context.Context, query url.Values) ([]Domain
 		return nil, nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodGet, endpoint, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	if len(query) > 0 {
-		req.URL.RawQuery = query.Encode()
-	}
+	endpoint.RawQuery = query.Encode()
 
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, nil, handleError(resp)
-	}
-
-	cursors, err := parseCursor(resp.Header)
+	domains, resp, err := do[[]Domain](ctx, s.client, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var domains []Domain
-	err = handleResponse(resp, &domains)
-	if err != nil {
-		return nil, nil, err
-	}
+	s.client.decodeDomains(domains)
 
-	return domains, cursors, nil
+	return domains, resp.Cursors, nil
 }
 
 // Get retrieving a specific domain.
@@ -154,29 +237,14 @@ func (s *DomainsService) Get(ctx context.Context, domainName string) (*Domain, e
 		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodGet, endpoint, nil)
+	domain, _, err := do[Domain](ctx, s.client, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, handleError(resp)
-	}
-
-	var domains Domain
-	err = handleResponse(resp, &domains)
-	if err != nil {
-		return nil, err
-	}
+	s.client.decodeDomain(&domain)
 
-	return &domains, nil
+	return &domain, nil
 }
 
 // Delete deleting a domain.
@@ -187,21 +255,7 @@ func (s *DomainsService) Delete(ctx context.Context, domainName string) error {
 		return fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodDelete, endpoint, nil)
-	if err != nil {
-		return err
-	}
+	_, _, err = do[struct{}](ctx, s.client, http.MethodDelete, endpoint, nil)
 
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusNoContent {
-		return handleError(resp)
-	}
-
-	return nil
+	return err
 }
@@ -2,6 +2,7 @@ package desec
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -58,6 +59,8 @@ func TestDomainsService_Create(t *testing.T) {
 				},
 				Flags:   257,
 				KeyType: "csk",
+				Managed: true,
+				Created: mustParseTime("2018-09-18T16:36:16.510368Z"),
 			},
 		},
 		Created:   mustParseTime("2018-09-18T16:36:16.510368Z"),
@@ -133,6 +136,8 @@ func TestDomainsService_Get(t *testing.T) {
 				},
 				Flags:   257,
 				KeyType: "csk",
+				Managed: true,
+				Created: mustParseTime("2018-09-18T16:36:16.510368Z"),
 			},
 		},
 		Created:   mustParseTime("2018-09-18T16:36:16.510368Z"),
@@ -258,3 +263,93 @@ func TestDomainsService_GetAll(t *testing.T) {
 	}
 	assert.Equal(t, expected, domains)
 }
+
+func TestDomainsService_GetAll_pagination(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+
+		switch {
+		case !query.Has("cursor"):
+			rw.Header().Set("Link", `<`+server.URL+`/domains/?cursor=>; rel="first"`)
+			rw.WriteHeader(http.StatusBadRequest)
+			_, _ = rw.Write([]byte(`{"detail":"Pagination required. You can query up to 500 items at a time."}`))
+
+		case query.Get("cursor") == "":
+			rw.Header().Set("Link", `<`+server.URL+`/domains/?cursor=>; rel="first", <`+server.URL+`/domains/?cursor=next>; rel="next"`)
+			_, _ = rw.Write([]byte(`[{"name":"example.com"}]`))
+
+		default:
+			_, _ = rw.Write([]byte(`[{"name":"example.org"}]`))
+		}
+	})
+
+	domains, err := client.Domains.GetAll(context.Background())
+	require.NoError(t, err)
+
+	expected := []Domain{{Name: "example.com"}, {Name: "example.org"}}
+	assert.Equal(t, expected, domains)
+}
+
+func TestDomainsService_List(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("owns_qname") != "www.xn--bcher-kva.example" {
+			http.Error(rw, "invalid query", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`[{"name":"xn--bcher-kva.example"}]`))
+	})
+
+	domains, err := client.Domains.List(context.Background(), &DomainListOptions{OwnsQName: "www.bücher.example"})
+	require.NoError(t, err)
+
+	expected := []Domain{{Name: "xn--bcher-kva.example"}}
+	assert.Equal(t, expected, domains)
+}
+
+func TestDomainsService_CreateFromZonefile(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("POST /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		var domain Domain
+
+		err := json.NewDecoder(req.Body).Decode(&domain)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if domain.Zonefile != "www 3600 IN A 10.0.0.1" {
+			http.Error(rw, "invalid zonefile", http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = rw.Write([]byte(`{"name":"example.com","minimum_ttl":3600,"zonefile":"example.com. 3600 IN SOA ..."}`))
+	})
+
+	domain, err := client.Domains.CreateFromZonefile(context.Background(), "example.com", "www 3600 IN A 10.0.0.1")
+	require.NoError(t, err)
+
+	expected := &Domain{Name: "example.com", MinimumTTL: 3600, Zonefile: "example.com. 3600 IN SOA ..."}
+	assert.Equal(t, expected, domain)
+}
@@ -0,0 +1,313 @@
+package dyndns
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// Default echo services provided by deSEC.
+// https://desec.readthedocs.io/en/latest/dyndns/update-api.html#determine-your-public-ip-address
+const (
+	DefaultIPv4EchoURL = "https://checkipv4.dedyn.io/"
+	DefaultIPv6EchoURL = "https://checkipv6.dedyn.io/"
+)
+
+// IPDetector detects a public IP address.
+type IPDetector interface {
+	Detect(ctx context.Context) (netip.Addr, error)
+}
+
+// IPDetectorFunc an adapter to allow the use of ordinary functions as IPDetector.
+type IPDetectorFunc func(ctx context.Context) (netip.Addr, error)
+
+// Detect calls f(ctx).
+func (f IPDetectorFunc) Detect(ctx context.Context) (netip.Addr, error) {
+	return f(ctx)
+}
+
+// Family an IP address family.
+type Family int
+
+const (
+	// IPv4 IPv4 addresses.
+	IPv4 Family = 4
+	// IPv6 IPv6 addresses.
+	IPv6 Family = 6
+)
+
+func (f Family) match(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	switch f {
+	case IPv4:
+		return addr.Is4()
+	case IPv6:
+		return addr.Is6()
+	default:
+		return false
+	}
+}
+
+// InterfaceDetector detects the public IP address by inspecting a network interface.
+// The first global unicast address of the family is used.
+type InterfaceDetector struct {
+	// Name of the network interface (ex: eth0).
+	Name   string
+	Family Family
+}
+
+// Detect detects the public IP address.
+func (d InterfaceDetector) Detect(_ context.Context) (netip.Addr, error) {
+	iface, err := net.InterfaceByName(d.Name)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	for _, a := range addrs {
+		ipNet, ok := a.(*net.IPNet)
+		if !ok {
+			continue
+		}
+
+		addr, ok := netip.AddrFromSlice(ipNet.IP)
+		if !ok {
+			continue
+		}
+
+		addr = addr.Unmap()
+
+		if d.Family.match(addr) && addr.IsGlobalUnicast() && !addr.IsPrivate() {
+			return addr, nil
+		}
+	}
+
+	return netip.Addr{}, fmt.Errorf("no public IPv%d address on %s", d.Family, d.Name)
+}
+
+// HTTPDetector detects the public IP address with an HTTP echo service (the response body contains only the IP address).
+type HTTPDetector struct {
+	URL        string
+	Family     Family
+	HTTPClient *http.Client
+}
+
+// NewHTTPDetector creates a new HTTPDetector using the deSEC echo services.
+func NewHTTPDetector(family Family) HTTPDetector {
+	endpoint := DefaultIPv4EchoURL
+	if family == IPv6 {
+		endpoint = DefaultIPv6EchoURL
+	}
+
+	return HTTPDetector{
+		URL:        endpoint,
+		Family:     family,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Detect detects the public IP address.
+func (d HTTPDetector) Detect(ctx context.Context) (netip.Addr, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.URL, http.NoBody)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	client := d.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("failed to call echo service: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return netip.Addr{}, fmt.Errorf("echo service: %d: %s", resp.StatusCode, string(body))
+	}
+
+	addr, err := netip.ParseAddr(strings.TrimSpace(string(body)))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	if d.Family != 0 && !d.Family.match(addr) {
+		return netip.Addr{}, fmt.Errorf("unexpected address family: %s", addr)
+	}
+
+	return addr.Unmap(), nil
+}
+
+// STUN message constants.
+// https://www.rfc-editor.org/rfc/rfc5389.html
+const (
+	stunBindingRequest     = 0x0001
+	stunBindingSuccess     = 0x0101
+	stunMagicCookie        = 0x2112A442
+	stunHeaderSize         = 20
+	stunMappedAddress      = 0x0001
+	stunXORMappedAddress   = 0x0020
+	stunAddressFamilyIPv4  = 0x01
+	stunAddressFamilyIPv6  = 0x02
+	stunDefaultReadTimeout = 5 * time.Second
+)
+
+// STUNDetector detects the public IP address with a STUN server (RFC 5389 binding request over UDP).
+type STUNDetector struct {
+	// Server the address of the STUN server (ex: stun.l.google.com:19302).
+	Server string
+	Family Family
+}
+
+// Detect detects the public IP address.
+func (d STUNDetector) Detect(ctx context.Context) (netip.Addr, error) {
+	network := "udp4"
+	if d.Family == IPv6 {
+		network = "udp6"
+	}
+
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, network, d.Server)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(stunDefaultReadTimeout)
+	}
+
+	_ = conn.SetDeadline(deadline)
+
+	request := make([]byte, stunHeaderSize)
+	binary.BigEndian.PutUint16(request[0:2], stunBindingRequest)
+	binary.BigEndian.PutUint32(request[4:8], stunMagicCookie)
+
+	_, err = rand.Read(request[8:20])
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	_, err = conn.Write(request)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	response := make([]byte, 1500)
+
+	n, err := conn.Read(response)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	return parseSTUNResponse(response[:n], request[8:20])
+}
+
+func parseSTUNResponse(msg, transactionID []byte) (netip.Addr, error) {
+	if len(msg) < stunHeaderSize {
+		return netip.Addr{}, errors.New("STUN response too short")
+	}
+
+	if binary.BigEndian.Uint16(msg[0:2]) != stunBindingSuccess {
+		return netip.Addr{}, fmt.Errorf("unexpected STUN message type: %#04x", binary.BigEndian.Uint16(msg[0:2]))
+	}
+
+	if string(msg[8:20]) != string(transactionID) {
+		return netip.Addr{}, errors.New("STUN transaction ID mismatch")
+	}
+
+	length := int(binary.BigEndian.Uint16(msg[2:4]))
+	if len(msg) < stunHeaderSize+length {
+		return netip.Addr{}, errors.New("truncated STUN response")
+	}
+
+	var mapped netip.Addr
+
+	attrs := msg[stunHeaderSize : stunHeaderSize+length]
+
+	for len(attrs) >= 4 {
+		attrType := binary.BigEndian.Uint16(attrs[0:2])
+		attrLen := int(binary.BigEndian.Uint16(attrs[2:4]))
+
+		if len(attrs) < 4+attrLen {
+			return netip.Addr{}, errors.New("truncated STUN attribute")
+		}
+
+		value := attrs[4 : 4+attrLen]
+
+		switch attrType {
+		case stunXORMappedAddress:
+			return parseSTUNAddress(value, msg[4:20])
+		case stunMappedAddress:
+			mapped, _ = parseSTUNAddress(value, nil)
+		}
+
+		// attributes are padded to 4 bytes.
+		attrs = attrs[min(len(attrs), 4+(attrLen+3)&^3):]
+	}
+
+	if mapped.IsValid() {
+		return mapped, nil
+	}
+
+	return netip.Addr{}, errors.New("no mapped address in STUN response")
+}
+
+// parseSTUNAddress parses a (XOR-)MAPPED-ADDRESS attribute.
+// The key is the magic cookie followed by the transaction ID for XOR-MAPPED-ADDRESS, nil for MAPPED-ADDRESS.
+func parseSTUNAddress(value, key []byte) (netip.Addr, error) {
+	if len(value) < 4 {
+		return netip.Addr{}, errors.New("invalid STUN address attribute")
+	}
+
+	var ip []byte
+
+	switch value[1] {
+	case stunAddressFamilyIPv4:
+		ip = make([]byte, 4)
+	case stunAddressFamilyIPv6:
+		ip = make([]byte, 16)
+	default:
+		return netip.Addr{}, fmt.Errorf("unknown STUN address family: %d", value[1])
+	}
+
+	if len(value) < 4+len(ip) {
+		return netip.Addr{}, errors.New("invalid STUN address attribute")
+	}
+
+	copy(ip, value[4:])
+
+	for i := range ip {
+		if key != nil {
+			ip[i] ^= key[i]
+		}
+	}
+
+	addr, _ := netip.AddrFromSlice(ip)
+
+	return addr, nil
+}
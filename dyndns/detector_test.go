@@ -0,0 +1,61 @@
+package dyndns
+
+import (
+	"context"
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPDetector_Detect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte("192.0.2.1\n"))
+	}))
+	t.Cleanup(server.Close)
+
+	detector := HTTPDetector{URL: server.URL, Family: IPv4}
+
+	addr, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, netip.MustParseAddr("192.0.2.1"), addr)
+
+	detector.Family = IPv6
+
+	_, err = detector.Detect(context.Background())
+	require.Error(t, err)
+}
+
+func Test_parseSTUNResponse(t *testing.T) {
+	transactionID := []byte("0123456789ab")
+
+	msg := make([]byte, stunHeaderSize+12)
+	binary.BigEndian.PutUint16(msg[0:2], stunBindingSuccess)
+	binary.BigEndian.PutUint16(msg[2:4], 12)
+	binary.BigEndian.PutUint32(msg[4:8], stunMagicCookie)
+	copy(msg[8:20], transactionID)
+
+	// XOR-MAPPED-ADDRESS
+	binary.BigEndian.PutUint16(msg[20:22], stunXORMappedAddress)
+	binary.BigEndian.PutUint16(msg[22:24], 8)
+	msg[25] = stunAddressFamilyIPv4
+	binary.BigEndian.PutUint16(msg[26:28], 1234^(stunMagicCookie>>16))
+
+	ip := [4]byte{192, 0, 2, 1}
+	for i := range ip {
+		msg[28+i] = ip[i] ^ msg[4+i]
+	}
+
+	addr, err := parseSTUNResponse(msg, transactionID)
+	require.NoError(t, err)
+
+	assert.Equal(t, netip.MustParseAddr("192.0.2.1"), addr)
+
+	_, err = parseSTUNResponse(msg, []byte("ba9876543210"))
+	require.Error(t, err)
+}
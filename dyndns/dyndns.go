@@ -0,0 +1,223 @@
+// Package dyndns implements the deSEC dynDNS update API.
+//
+// https://desec.readthedocs.io/en/latest/dyndns/update-api.html
+package dyndns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nrdcg/desec"
+)
+
+const defaultBaseURL = "https://update.dedyn.io/"
+
+// preserve is the special value used to keep the current address.
+const preserve = "preserve"
+
+// Error an error returned by the update API.
+type Error struct {
+	StatusCode int
+	Message    string
+
+	// RetryAfter the delay requested by the server (Retry-After header), 0 if not provided.
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+}
+
+// Client the deSEC dynDNS update API client.
+type Client struct {
+	// Base URL for update requests.
+	BaseURL string
+
+	// HTTPClient HTTP client used to communicate with the update API.
+	HTTPClient *http.Client
+
+	token string
+
+	api *desec.Client
+
+	mu      sync.Mutex
+	domains map[string]string
+}
+
+// New creates a new Client.
+// The API client is used to retrieve the published addresses (UpdateIfChanged).
+func New(token string, api *desec.Client) *Client {
+	return &Client{
+		BaseURL:    defaultBaseURL,
+		HTTPClient: http.DefaultClient,
+		token:      token,
+		api:        api,
+		domains:    make(map[string]string),
+	}
+}
+
+// Update updates the addresses of a hostname.
+// An invalid address (zero value) preserves the current address.
+// https://desec.readthedocs.io/en/latest/dyndns/update-api.html
+func (c *Client) Update(ctx context.Context, hostname string, ipv4, ipv6 netip.Addr) error {
+	endpoint, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to create endpoint: %w", err)
+	}
+
+	query := endpoint.Query()
+	query.Set("hostname", hostname)
+	query.Set("myipv4", formatAddr(ipv4))
+	query.Set("myipv6", formatAddr(ipv6))
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Token "+c.token)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call API: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return &Error{
+			StatusCode: resp.StatusCode,
+			Message:    strings.TrimSpace(string(body)),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	return nil
+}
+
+// UpdateIfChanged detects the public addresses and calls the update API only if an address differs from the published RRSets.
+// A nil detector preserves the current address.
+func (c *Client) UpdateIfChanged(ctx context.Context, hostname string, ipv4, ipv6 IPDetector) (bool, error) {
+	if ipv4 == nil && ipv6 == nil {
+		return false, errors.New("no detector")
+	}
+
+	var changed bool
+
+	addrs := make(map[string]netip.Addr)
+
+	for recordType, detector := range map[string]IPDetector{"A": ipv4, "AAAA": ipv6} {
+		if detector == nil {
+			continue
+		}
+
+		addr, err := detector.Detect(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to detect %s address: %w", recordType, err)
+		}
+
+		published, err := c.published(ctx, hostname, recordType)
+		if err != nil {
+			return false, err
+		}
+
+		if len(published) != 1 || published[0] != addr {
+			changed = true
+		}
+
+		addrs[recordType] = addr
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	err := c.Update(ctx, hostname, addrs["A"], addrs["AAAA"])
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *Client) published(ctx context.Context, hostname, recordType string) ([]netip.Addr, error) {
+	domainName, err := c.domain(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	subName, err := desec.SubNameFromOwner(domainName, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	rrSet, err := c.api.Records.Get(ctx, domainName, subName, recordType)
+	if err != nil {
+		var notFound *desec.NotFoundError
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get published %s RRSet: %w", recordType, err)
+	}
+
+	return rrSet.Addrs()
+}
+
+// domain returns the responsible domain of a hostname.
+func (c *Client) domain(ctx context.Context, hostname string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if domainName, ok := c.domains[hostname]; ok {
+		return domainName, nil
+	}
+
+	domain, err := c.api.Domains.GetResponsible(ctx, hostname)
+	if err != nil {
+		return "", fmt.Errorf("failed to get the responsible domain of %s: %w", hostname, err)
+	}
+
+	c.domains[hostname] = domain.Name
+
+	return domain.Name, nil
+}
+
+func formatAddr(addr netip.Addr) string {
+	if !addr.IsValid() {
+		return preserve
+	}
+
+	return addr.Unmap().String()
+}
+
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return 0
+}
@@ -0,0 +1,157 @@
+package dyndns
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+
+	"github.com/nrdcg/desec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T) (*Client, *http.ServeMux, *http.ServeMux) {
+	t.Helper()
+
+	apiMux := http.NewServeMux()
+	apiServer := httptest.NewServer(apiMux)
+	t.Cleanup(apiServer.Close)
+
+	updateMux := http.NewServeMux()
+	updateServer := httptest.NewServer(updateMux)
+	t.Cleanup(updateServer.Close)
+
+	api := desec.New("token", desec.NewDefaultClientOptions())
+	api.BaseURL = apiServer.URL
+
+	client := New("token", api)
+	client.BaseURL = updateServer.URL
+
+	apiMux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("owns_qname") != "example.dedyn.io" {
+			http.Error(rw, "invalid query", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`[{"name":"example.dedyn.io"}]`))
+	})
+
+	return client, apiMux, updateMux
+}
+
+func TestClient_Update(t *testing.T) {
+	client, _, updateMux := setupTest(t)
+
+	var query url.Values
+
+	updateMux.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Token token" {
+			http.Error(rw, "badauth", http.StatusUnauthorized)
+			return
+		}
+
+		query = req.URL.Query()
+
+		_, _ = rw.Write([]byte("good"))
+	})
+
+	err := client.Update(context.Background(), "example.dedyn.io", netip.MustParseAddr("192.0.2.1"), netip.Addr{})
+	require.NoError(t, err)
+
+	expected := url.Values{
+		"hostname": {"example.dedyn.io"},
+		"myipv4":   {"192.0.2.1"},
+		"myipv6":   {"preserve"},
+	}
+	assert.Equal(t, expected, query)
+}
+
+func TestClient_Update_error(t *testing.T) {
+	client, _, updateMux := setupTest(t)
+
+	updateMux.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "badauth", http.StatusUnauthorized)
+	})
+
+	err := client.Update(context.Background(), "example.dedyn.io", netip.MustParseAddr("192.0.2.1"), netip.Addr{})
+	require.Error(t, err)
+
+	var updateErr *Error
+	require.ErrorAs(t, err, &updateErr)
+
+	assert.Equal(t, &Error{StatusCode: http.StatusUnauthorized, Message: "badauth"}, updateErr)
+}
+
+func TestClient_UpdateIfChanged(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		detected string
+		expected bool
+	}{
+		{
+			desc:     "unchanged",
+			detected: "192.0.2.1",
+			expected: false,
+		},
+		{
+			desc:     "changed",
+			detected: "192.0.2.2",
+			expected: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			client, apiMux, updateMux := setupTest(t)
+
+			apiMux.HandleFunc("/domains/example.dedyn.io/rrsets/@/A/", func(rw http.ResponseWriter, req *http.Request) {
+				_, _ = rw.Write([]byte(`{"subname":"","type":"A","records":["192.0.2.1"]}`))
+			})
+
+			var updated bool
+
+			updateMux.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+				updated = true
+
+				_, _ = rw.Write([]byte("good"))
+			})
+
+			detector := IPDetectorFunc(func(context.Context) (netip.Addr, error) {
+				return netip.MustParseAddr(test.detected), nil
+			})
+
+			changed, err := client.UpdateIfChanged(context.Background(), "example.dedyn.io", detector, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, changed)
+			assert.Equal(t, test.expected, updated)
+		})
+	}
+}
+
+func TestClient_UpdateIfChanged_notPublished(t *testing.T) {
+	client, apiMux, updateMux := setupTest(t)
+
+	apiMux.HandleFunc("/domains/example.dedyn.io/rrsets/@/AAAA/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"detail":"Not found."}`))
+	})
+
+	updateMux.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte("good"))
+	})
+
+	detector := IPDetectorFunc(func(context.Context) (netip.Addr, error) {
+		return netip.MustParseAddr("2001:db8::1"), nil
+	})
+
+	changed, err := client.UpdateIfChanged(context.Background(), "example.dedyn.io", nil, detector)
+	require.NoError(t, err)
+
+	assert.True(t, changed)
+}
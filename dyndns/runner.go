@@ -0,0 +1,135 @@
+package dyndns
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultInterval   = 5 * time.Minute
+	defaultMaxBackoff = time.Hour
+)
+
+// Status the status of a Runner.
+type Status struct {
+	// LastCheck the time of the last detection.
+	LastCheck time.Time
+	// LastUpdate the time of the last successful update.
+	LastUpdate time.Time
+	// LastError the error of the last iteration, nil if it succeeded.
+	LastError error
+	// Updates the number of successful updates.
+	Updates int
+	// Backoff the current backoff delay, 0 when not throttled.
+	Backoff time.Duration
+}
+
+// Runner periodically detects the public addresses and updates a dedyn hostname.
+type Runner struct {
+	Client   *Client
+	Hostname string
+
+	// IPv4 the IPv4 detector, nil to preserve the current address.
+	IPv4 IPDetector
+	// IPv6 the IPv6 detector, nil to preserve the current address.
+	IPv6 IPDetector
+
+	// Interval the delay between two checks (default: 5 minutes).
+	Interval time.Duration
+	// MaxBackoff the maximum delay between two checks when the server throttles the requests (default: 1 hour).
+	MaxBackoff time.Duration
+
+	mu     sync.Mutex
+	status Status
+}
+
+// NewRunner creates a new Runner using the deSEC echo services to detect the addresses.
+func NewRunner(client *Client, hostname string) *Runner {
+	return &Runner{
+		Client:     client,
+		Hostname:   hostname,
+		IPv4:       NewHTTPDetector(IPv4),
+		IPv6:       NewHTTPDetector(IPv6),
+		Interval:   defaultInterval,
+		MaxBackoff: defaultMaxBackoff,
+	}
+}
+
+// Status returns the current status.
+func (r *Runner) Status() Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.status
+}
+
+// Run runs the update loop until the context is canceled.
+func (r *Runner) Run(ctx context.Context) error {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	maxBackoff := r.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	for {
+		delay := r.runOnce(ctx, interval, maxBackoff)
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// runOnce checks and updates the addresses, and returns the delay before the next iteration.
+func (r *Runner) runOnce(ctx context.Context, interval, maxBackoff time.Duration) time.Duration {
+	changed, err := r.Client.UpdateIfChanged(ctx, r.Hostname, r.IPv4, r.IPv6)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	r.status.LastCheck = now
+	r.status.LastError = err
+
+	if err == nil {
+		r.status.Backoff = 0
+
+		if changed {
+			r.status.LastUpdate = now
+			r.status.Updates++
+		}
+
+		return interval
+	}
+
+	var updateErr *Error
+	if !errors.As(err, &updateErr) || !isThrottled(updateErr) {
+		return interval
+	}
+
+	backoff := max(r.status.Backoff*2, interval)
+
+	backoff = min(max(backoff, updateErr.RetryAfter), maxBackoff)
+
+	r.status.Backoff = backoff
+
+	return backoff
+}
+
+func isThrottled(err *Error) bool {
+	return err.StatusCode == http.StatusTooManyRequests || strings.Contains(err.Message, "abuse")
+}
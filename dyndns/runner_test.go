@@ -0,0 +1,79 @@
+package dyndns
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_runOnce(t *testing.T) {
+	client, apiMux, updateMux := setupTest(t)
+
+	apiMux.HandleFunc("/domains/example.dedyn.io/rrsets/@/A/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"subname":"","type":"A","records":["192.0.2.1"]}`))
+	})
+
+	var throttled bool
+
+	updateMux.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+		if throttled {
+			rw.Header().Set("Retry-After", "600")
+			http.Error(rw, "throttled", http.StatusTooManyRequests)
+
+			return
+		}
+
+		_, _ = rw.Write([]byte("good"))
+	})
+
+	runner := NewRunner(client, "example.dedyn.io")
+	runner.IPv4 = IPDetectorFunc(func(context.Context) (netip.Addr, error) {
+		return netip.MustParseAddr("192.0.2.2"), nil
+	})
+	runner.IPv6 = nil
+
+	delay := runner.runOnce(context.Background(), time.Minute, time.Hour)
+	assert.Equal(t, time.Minute, delay)
+
+	status := runner.Status()
+	require.NoError(t, status.LastError)
+	assert.Equal(t, 1, status.Updates)
+	assert.False(t, status.LastUpdate.IsZero())
+
+	throttled = true
+
+	delay = runner.runOnce(context.Background(), time.Minute, time.Hour)
+	assert.Equal(t, 10*time.Minute, delay)
+
+	delay = runner.runOnce(context.Background(), time.Minute, time.Hour)
+	assert.Equal(t, 20*time.Minute, delay)
+
+	delay = runner.runOnce(context.Background(), time.Minute, 30*time.Minute)
+	assert.Equal(t, 30*time.Minute, delay)
+
+	status = runner.Status()
+	require.Error(t, status.LastError)
+	assert.Equal(t, 1, status.Updates)
+	assert.Equal(t, 30*time.Minute, status.Backoff)
+}
+
+func TestRunner_Run(t *testing.T) {
+	client, _, _ := setupTest(t)
+
+	runner := NewRunner(client, "example.dedyn.io")
+	runner.IPv4 = nil
+	runner.IPv6 = nil
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	t.Cleanup(cancel)
+
+	err := runner.Run(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.False(t, runner.Status().LastCheck.IsZero())
+}
@@ -0,0 +1,320 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Email authentication checks.
+const (
+	CheckMX     = "MX"
+	CheckSPF    = "SPF"
+	CheckDKIM   = "DKIM"
+	CheckDMARC  = "DMARC"
+	CheckMTASTS = "MTA-STS"
+)
+
+// Finding severities.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// EmailAuthCheckOptions the options of CheckEmailAuth.
+type EmailAuthCheckOptions struct {
+	// SubName the mail subdomain (empty for the zone apex).
+	SubName string
+	// DKIMSelectors the DKIM selectors to check (selectors cannot be discovered).
+	DKIMSelectors []string
+	// RequireMTASTS reports a missing MTA-STS policy as an error instead of a warning.
+	RequireMTASTS bool
+}
+
+// EmailAuthFinding a problem found by CheckEmailAuth.
+type EmailAuthFinding struct {
+	Check    string
+	Severity string
+	SubName  string
+	Message  string
+}
+
+// EmailAuthReport the result of CheckEmailAuth.
+type EmailAuthReport struct {
+	Domain   string
+	SubName  string
+	Findings []EmailAuthFinding
+}
+
+// OK returns true if the report doesn't contain errors.
+func (r *EmailAuthReport) OK() bool {
+	for _, finding := range r.Findings {
+		if finding.Severity == SeverityError {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *EmailAuthReport) add(check, severity, subName, format string, args ...any) {
+	r.Findings = append(r.Findings, EmailAuthFinding{
+		Check:    check,
+		Severity: severity,
+		SubName:  subName,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// CheckEmailAuth fetches the RRSets of a domain and reports missing or malformed MX, SPF, DKIM, DMARC, and MTA-STS entries.
+func (s *RecordsService) CheckEmailAuth(ctx context.Context, domainName string, opts EmailAuthCheckOptions) (*EmailAuthReport, error) {
+	rrSets, err := s.GetAll(ctx, domainName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	index := NewZone(rrSets)
+
+	subName := normalizeSubName(opts.SubName)
+
+	report := &EmailAuthReport{Domain: domainName, SubName: subName}
+
+	checkMX(report, index, subName)
+	checkSPF(report, index, subName)
+	checkDMARC(report, index, prefixSubName("_dmarc", subName))
+
+	for _, selector := range opts.DKIMSelectors {
+		checkDKIM(report, index, prefixSubName(selector+"._domainkey", subName))
+	}
+
+	checkMTASTS(report, index, subName, opts.RequireMTASTS)
+
+	return report, nil
+}
+
+func checkMX(report *EmailAuthReport, index Zone, subName string) {
+	rrSet, ok := index.Get(subName, "MX")
+	if !ok || len(rrSet.Records) == 0 {
+		report.add(CheckMX, SeverityWarning, subName, "no MX RRSet")
+		return
+	}
+
+	if len(rrSet.Records) > 1 && slices.ContainsFunc(rrSet.Records, isNullMX) {
+		report.add(CheckMX, SeverityError, subName, "null MX must be the only MX record")
+	}
+}
+
+func isNullMX(record string) bool {
+	return strings.Join(strings.Fields(record), " ") == "0 ."
+}
+
+func checkSPF(report *EmailAuthReport, index Zone, subName string) {
+	policies := txtValues(index, subName, "v=spf1")
+
+	switch {
+	case len(policies) == 0:
+		report.add(CheckSPF, SeverityError, subName, "no SPF policy")
+		return
+	case len(policies) > 1:
+		report.add(CheckSPF, SeverityError, subName, "multiple SPF policies")
+		return
+	}
+
+	var lookups int
+
+	var terminated bool
+
+	for _, term := range strings.Fields(policies[0])[1:] {
+		name := strings.TrimLeft(term, SPFPass+SPFFail+SPFSoftFail+SPFNeutral)
+
+		if modifier, _, ok := strings.Cut(name, "="); ok {
+			switch modifier {
+			case "redirect":
+				lookups++
+				terminated = true
+			case "exp":
+			default:
+				report.add(CheckSPF, SeverityWarning, subName, "unknown modifier: %q", term)
+			}
+
+			continue
+		}
+
+		name, _, _ = strings.Cut(name, ":")
+		name, _, _ = strings.Cut(name, "/")
+
+		switch name {
+		case "include", "a", "mx", "ptr", "exists":
+			lookups++
+		case "ip4", "ip6":
+		case "all":
+			terminated = true
+		default:
+			report.add(CheckSPF, SeverityError, subName, "unknown mechanism: %q", term)
+		}
+	}
+
+	if lookups > spfMaxDNSLookups {
+		report.add(CheckSPF, SeverityError, subName, "too many DNS lookups: %d (maximum %d)", lookups, spfMaxDNSLookups)
+	}
+
+	if !terminated {
+		report.add(CheckSPF, SeverityWarning, subName, "no all mechanism nor redirect modifier")
+	}
+}
+
+func checkDMARC(report *EmailAuthReport, index Zone, subName string) {
+	policies := txtValues(index, subName, "v=DMARC1")
+
+	switch {
+	case len(policies) == 0:
+		report.add(CheckDMARC, SeverityError, subName, "no DMARC policy")
+		return
+	case len(policies) > 1:
+		report.add(CheckDMARC, SeverityError, subName, "multiple DMARC policies")
+		return
+	}
+
+	tags := parseTags(policies[0])
+
+	switch tags["p"] {
+	case DMARCQuarantine, DMARCReject:
+	case DMARCNone:
+		report.add(CheckDMARC, SeverityWarning, subName, "the policy is %q", DMARCNone)
+	case "":
+		report.add(CheckDMARC, SeverityError, subName, "missing policy (p)")
+	default:
+		report.add(CheckDMARC, SeverityError, subName, "invalid policy: %q", tags["p"])
+	}
+
+	if sp, ok := tags["sp"]; ok && !slices.Contains([]string{DMARCNone, DMARCQuarantine, DMARCReject}, sp) {
+		report.add(CheckDMARC, SeverityError, subName, "invalid subdomain policy: %q", sp)
+	}
+
+	if _, ok := tags["rua"]; !ok {
+		report.add(CheckDMARC, SeverityWarning, subName, "no aggregate report URI (rua)")
+	}
+}
+
+func checkDKIM(report *EmailAuthReport, index Zone, subName string) {
+	rrSet, ok := index.Get(subName, "TXT")
+	if !ok || len(rrSet.Records) == 0 {
+		report.add(CheckDKIM, SeverityError, subName, "no DKIM key")
+		return
+	}
+
+	for _, record := range rrSet.Records {
+		tags := parseTags(parseTXT(record))
+
+		if v, ok := tags["v"]; ok && v != "DKIM1" {
+			report.add(CheckDKIM, SeverityError, subName, "invalid version: %q", v)
+			continue
+		}
+
+		p, ok := tags["p"]
+
+		switch {
+		case !ok:
+			report.add(CheckDKIM, SeverityError, subName, "missing public key (p)")
+		case p == "":
+			report.add(CheckDKIM, SeverityWarning, subName, "the key is revoked (empty p)")
+		}
+	}
+}
+
+func checkMTASTS(report *EmailAuthReport, index Zone, subName string, required bool) {
+	policySubName := prefixSubName("_mta-sts", subName)
+
+	policies := txtValues(index, policySubName, "v=STSv1")
+
+	switch {
+	case len(policies) == 0:
+		severity := SeverityWarning
+		if required {
+			severity = SeverityError
+		}
+
+		report.add(CheckMTASTS, severity, policySubName, "no MTA-STS policy")
+
+		return
+
+	case len(policies) > 1:
+		report.add(CheckMTASTS, SeverityError, policySubName, "multiple MTA-STS policies")
+		return
+	}
+
+	if tags := parseTags(policies[0]); tags["id"] == "" {
+		report.add(CheckMTASTS, SeverityError, policySubName, "missing policy id")
+	}
+
+	hostSubName := prefixSubName("mta-sts", subName)
+
+	for _, recordType := range []string{"A", "AAAA", "CNAME"} {
+		if _, ok := index.Get(hostSubName, recordType); ok {
+			return
+		}
+	}
+
+	report.add(CheckMTASTS, SeverityError, hostSubName, "no A, AAAA or CNAME RRSet for the policy host")
+}
+
+// txtValues returns the values of the TXT records with the given prefix.
+func txtValues(index Zone, subName, prefix string) []string {
+	rrSet, ok := index.Get(subName, "TXT")
+	if !ok {
+		return nil
+	}
+
+	var values []string
+
+	for _, record := range rrSet.Records {
+		value := parseTXT(record)
+
+		if strings.EqualFold(value, prefix) || strings.HasPrefix(strings.ToLower(value), strings.ToLower(prefix)+" ") ||
+			strings.HasPrefix(strings.ToLower(value), strings.ToLower(prefix)+";") {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// parseTags parses `tag=value; tag=value` lists (DKIM, DMARC, MTA-STS).
+func parseTags(value string) map[string]string {
+	tags := make(map[string]string)
+
+	for _, part := range strings.Split(value, ";") {
+		tag, val, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+
+		tags[strings.TrimSpace(tag)] = strings.Join(strings.Fields(val), "")
+	}
+
+	return tags
+}
+
+// parseTXT returns the value of a TXT record: the character-strings are unquoted, unescaped, and concatenated.
+func parseTXT(record string) string {
+	var sb strings.Builder
+
+	var quoted, escaped bool
+
+	for _, r := range record {
+		switch {
+		case escaped:
+			sb.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+			sb.WriteRune(r)
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,91 @@
+package desec
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsService_CheckEmailAuth(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		file, err := os.Open("./fixtures/records_getall_email.json")
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		_, err = io.Copy(rw, file)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	report, err := client.Records.CheckEmailAuth(context.Background(), "example.com", EmailAuthCheckOptions{
+		DKIMSelectors: []string{"s1", "s2"},
+	})
+	require.NoError(t, err)
+
+	expected := []EmailAuthFinding{
+		{Check: CheckSPF, Severity: SeverityError, SubName: "", Message: `unknown mechanism: "foo:bar"`},
+		{Check: CheckSPF, Severity: SeverityWarning, SubName: "", Message: "no all mechanism nor redirect modifier"},
+		{Check: CheckDMARC, Severity: SeverityWarning, SubName: "_dmarc", Message: `the policy is "none"`},
+		{Check: CheckDMARC, Severity: SeverityWarning, SubName: "_dmarc", Message: "no aggregate report URI (rua)"},
+		{Check: CheckDKIM, Severity: SeverityError, SubName: "s2._domainkey", Message: "no DKIM key"},
+		{Check: CheckMTASTS, Severity: SeverityError, SubName: "mta-sts", Message: "no A, AAAA or CNAME RRSet for the policy host"},
+	}
+	assert.Equal(t, expected, report.Findings)
+
+	assert.False(t, report.OK())
+}
+
+func Test_parseTXT(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		record   string
+		expected string
+	}{
+		{
+			desc:     "simple",
+			record:   `"v=spf1 -all"`,
+			expected: "v=spf1 -all",
+		},
+		{
+			desc:     "multiple strings",
+			record:   `"v=DKIM1; " "p=abc"`,
+			expected: "v=DKIM1; p=abc",
+		},
+		{
+			desc:     "escaped",
+			record:   `"foo \"bar\" \\"`,
+			expected: `foo "bar" \`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, parseTXT(test.record))
+		})
+	}
+}
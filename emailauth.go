@@ -0,0 +1,305 @@
+package desec
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxTXTStringLength the maximum length of a character-string inside a TXT record.
+const maxTXTStringLength = 255
+
+// spfMaxDNSLookups the maximum number of DNS lookups allowed by an SPF policy.
+// https://www.rfc-editor.org/rfc/rfc7208.html#section-4.6.4
+const spfMaxDNSLookups = 10
+
+// SPF qualifiers.
+// https://www.rfc-editor.org/rfc/rfc7208.html#section-4.6.2
+const (
+	SPFPass     = "+"
+	SPFFail     = "-"
+	SPFSoftFail = "~"
+	SPFNeutral  = "?"
+)
+
+// SPFPolicy an SPF policy.
+// https://www.rfc-editor.org/rfc/rfc7208.html
+type SPFPolicy struct {
+	// Mechanisms the mechanisms, with an optional qualifier (ex: "mx", "ip4:192.0.2.0/24", "include:_spf.example.com", "-a").
+	Mechanisms []string
+	// All the qualifier of the "all" mechanism (ex: SPFFail), empty means no "all" mechanism.
+	All string
+	// Redirect the domain of the "redirect" modifier.
+	Redirect string
+}
+
+// Format returns the content of the SPF record (without quotes).
+func (p SPFPolicy) Format() (string, error) {
+	terms := []string{"v=spf1"}
+
+	var lookups int
+
+	for _, mechanism := range p.Mechanisms {
+		name := strings.TrimLeft(mechanism, SPFPass+SPFFail+SPFSoftFail+SPFNeutral)
+		name, _, _ = strings.Cut(name, ":")
+		name, _, _ = strings.Cut(name, "/")
+
+		switch name {
+		case "include", "a", "mx", "ptr", "exists":
+			lookups++
+		case "ip4", "ip6":
+		case "all":
+			return "", errors.New("the all mechanism must be defined with the All field")
+		default:
+			return "", fmt.Errorf("unknown SPF mechanism: %q", mechanism)
+		}
+
+		terms = append(terms, mechanism)
+	}
+
+	if p.Redirect != "" {
+		if p.All != "" {
+			return "", errors.New("the redirect modifier is ignored when the all mechanism is defined")
+		}
+
+		lookups++
+
+		terms = append(terms, "redirect="+p.Redirect)
+	}
+
+	if lookups > spfMaxDNSLookups {
+		return "", fmt.Errorf("too many DNS lookups: %d (maximum %d)", lookups, spfMaxDNSLookups)
+	}
+
+	if p.All != "" {
+		if !slices.Contains([]string{SPFPass, SPFFail, SPFSoftFail, SPFNeutral}, p.All) {
+			return "", fmt.Errorf("invalid qualifier: %q", p.All)
+		}
+
+		terms = append(terms, p.All+"all")
+	}
+
+	return strings.Join(terms, " "), nil
+}
+
+// NewSPFRRSet creates the TXT RRSet of an SPF policy.
+func NewSPFRRSet(domainName, subName string, ttl int, policy SPFPolicy) (RRSet, error) {
+	value, err := policy.Format()
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	return RRSet{
+		Domain:  domainName,
+		SubName: subName,
+		Type:    "TXT",
+		Records: []string{TXTRecord(value)},
+		TTL:     ttl,
+	}, nil
+}
+
+// DKIMRecord returns the content of the DKIM record (without quotes) of a public key.
+// Supported keys: *rsa.PublicKey, ed25519.PublicKey.
+// https://www.rfc-editor.org/rfc/rfc6376.html#section-3.6.1
+func DKIMRecord(key crypto.PublicKey) (string, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		der, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return "", err
+		}
+
+		return "v=DKIM1; k=rsa; p=" + base64.StdEncoding.EncodeToString(der), nil
+
+	case ed25519.PublicKey:
+		// https://www.rfc-editor.org/rfc/rfc8463.html#section-4
+		return "v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(k), nil
+
+	default:
+		return "", fmt.Errorf("unsupported key type: %T", key)
+	}
+}
+
+// NewDKIMRRSet creates the TXT RRSet of a DKIM public key.
+// The subname is the mail subdomain (empty for the zone apex), the selector subname is computed.
+func NewDKIMRRSet(domainName, subName, selector string, ttl int, key crypto.PublicKey) (RRSet, error) {
+	if selector == "" {
+		return RRSet{}, errors.New("missing selector")
+	}
+
+	value, err := DKIMRecord(key)
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	return RRSet{
+		Domain:  domainName,
+		SubName: prefixSubName(selector+"._domainkey", subName),
+		Type:    "TXT",
+		Records: []string{TXTRecord(value)},
+		TTL:     ttl,
+	}, nil
+}
+
+// DMARC policies.
+const (
+	DMARCNone       = "none"
+	DMARCQuarantine = "quarantine"
+	DMARCReject     = "reject"
+)
+
+// DMARC alignment modes.
+const (
+	DMARCRelaxed = "r"
+	DMARCStrict  = "s"
+)
+
+// DMARCPolicy a DMARC policy.
+// https://www.rfc-editor.org/rfc/rfc7489.html#section-6.3
+type DMARCPolicy struct {
+	// Policy the policy (p): DMARCNone, DMARCQuarantine, or DMARCReject.
+	Policy string
+	// SubdomainPolicy the policy for the subdomains (sp).
+	SubdomainPolicy string
+	// Percent the percentage of messages to which the policy applies (pct), 0 means not set.
+	Percent int
+	// AggregateReports the URIs of the aggregate reports (rua).
+	AggregateReports []string
+	// FailureReports the URIs of the failure reports (ruf).
+	FailureReports []string
+	// DKIMAlignment the DKIM alignment mode (adkim): DMARCRelaxed or DMARCStrict.
+	DKIMAlignment string
+	// SPFAlignment the SPF alignment mode (aspf): DMARCRelaxed or DMARCStrict.
+	SPFAlignment string
+	// FailureOptions the failure reporting options (fo) (ex: "1", "d:s").
+	FailureOptions string
+	// ReportInterval the interval between aggregate reports in seconds (ri), 0 means not set.
+	ReportInterval int
+}
+
+// Format returns the content of the DMARC record (without quotes).
+func (p DMARCPolicy) Format() (string, error) {
+	policies := []string{DMARCNone, DMARCQuarantine, DMARCReject}
+
+	if !slices.Contains(policies, p.Policy) {
+		return "", fmt.Errorf("invalid policy: %q", p.Policy)
+	}
+
+	tags := []string{"v=DMARC1", "p=" + p.Policy}
+
+	if p.SubdomainPolicy != "" {
+		if !slices.Contains(policies, p.SubdomainPolicy) {
+			return "", fmt.Errorf("invalid subdomain policy: %q", p.SubdomainPolicy)
+		}
+
+		tags = append(tags, "sp="+p.SubdomainPolicy)
+	}
+
+	if p.Percent != 0 {
+		if p.Percent < 0 || p.Percent > 100 {
+			return "", fmt.Errorf("invalid percent: %d", p.Percent)
+		}
+
+		tags = append(tags, "pct="+strconv.Itoa(p.Percent))
+	}
+
+	for tag, uris := range map[string][]string{"rua": p.AggregateReports, "ruf": p.FailureReports} {
+		for _, uri := range uris {
+			if !strings.Contains(uri, ":") || strings.ContainsAny(uri, ",; ") {
+				return "", fmt.Errorf("invalid %s URI: %q", tag, uri)
+			}
+		}
+	}
+
+	if len(p.AggregateReports) > 0 {
+		tags = append(tags, "rua="+strings.Join(p.AggregateReports, ","))
+	}
+
+	if len(p.FailureReports) > 0 {
+		tags = append(tags, "ruf="+strings.Join(p.FailureReports, ","))
+	}
+
+	for tag, mode := range map[string]string{"adkim": p.DKIMAlignment, "aspf": p.SPFAlignment} {
+		if mode != "" && mode != DMARCRelaxed && mode != DMARCStrict {
+			return "", fmt.Errorf("invalid %s alignment mode: %q", tag, mode)
+		}
+	}
+
+	if p.DKIMAlignment != "" {
+		tags = append(tags, "adkim="+p.DKIMAlignment)
+	}
+
+	if p.SPFAlignment != "" {
+		tags = append(tags, "aspf="+p.SPFAlignment)
+	}
+
+	if p.FailureOptions != "" {
+		for _, option := range strings.Split(p.FailureOptions, ":") {
+			if !slices.Contains([]string{"0", "1", "d", "s"}, option) {
+				return "", fmt.Errorf("invalid failure option: %q", option)
+			}
+		}
+
+		tags = append(tags, "fo="+p.FailureOptions)
+	}
+
+	if p.ReportInterval != 0 {
+		if p.ReportInterval < 0 {
+			return "", fmt.Errorf("invalid report interval: %d", p.ReportInterval)
+		}
+
+		tags = append(tags, "ri="+strconv.Itoa(p.ReportInterval))
+	}
+
+	return strings.Join(tags, "; "), nil
+}
+
+// NewDMARCRRSet creates the TXT RRSet of a DMARC policy.
+// The subname is the mail subdomain (empty for the zone apex), the `_dmarc` subname is computed.
+func NewDMARCRRSet(domainName, subName string, ttl int, policy DMARCPolicy) (RRSet, error) {
+	value, err := policy.Format()
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	return RRSet{
+		Domain:  domainName,
+		SubName: prefixSubName("_dmarc", subName),
+		Type:    "TXT",
+		Records: []string{TXTRecord(value)},
+		TTL:     ttl,
+	}, nil
+}
+
+// TXTRecord returns the content of a TXT record: the value is quoted, escaped,
+// and split into character-strings of 255 bytes maximum.
+func TXTRecord(value string) string {
+	var chunks []string
+
+	for len(value) > maxTXTStringLength {
+		chunks = append(chunks, quoteTXT(value[:maxTXTStringLength]))
+		value = value[maxTXTStringLength:]
+	}
+
+	return strings.Join(append(chunks, quoteTXT(value)), " ")
+}
+
+func quoteTXT(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+func prefixSubName(prefix, subName string) string {
+	subName = normalizeSubName(subName)
+	if subName == "" {
+		return prefix
+	}
+
+	return prefix + "." + subName
+}
@@ -0,0 +1,135 @@
+package desec
+
+import (
+	"crypto/ed25519"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSPFPolicy_Format(t *testing.T) {
+	policy := SPFPolicy{
+		Mechanisms: []string{"mx", "ip4:192.0.2.0/24", "include:_spf.example.com"},
+		All:        SPFFail,
+	}
+
+	value, err := policy.Format()
+	require.NoError(t, err)
+
+	assert.Equal(t, "v=spf1 mx ip4:192.0.2.0/24 include:_spf.example.com -all", value)
+}
+
+func TestSPFPolicy_Format_errors(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		policy SPFPolicy
+	}{
+		{
+			desc:   "unknown mechanism",
+			policy: SPFPolicy{Mechanisms: []string{"foo"}},
+		},
+		{
+			desc:   "all as mechanism",
+			policy: SPFPolicy{Mechanisms: []string{"-all"}},
+		},
+		{
+			desc:   "invalid qualifier",
+			policy: SPFPolicy{All: "!"},
+		},
+		{
+			desc:   "redirect with all",
+			policy: SPFPolicy{All: SPFFail, Redirect: "example.com"},
+		},
+		{
+			desc:   "too many lookups",
+			policy: SPFPolicy{Mechanisms: strings.Split("a mx a:a.example.com a:b.example.com a:c.example.com a:d.example.com a:e.example.com a:f.example.com a:g.example.com a:h.example.com a:i.example.com", " ")},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := test.policy.Format()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewDKIMRRSet(t *testing.T) {
+	key := ed25519.PublicKey(make([]byte, ed25519.PublicKeySize))
+
+	rrSet, err := NewDKIMRRSet("example.com", "mail", "s1", 3600, key)
+	require.NoError(t, err)
+
+	expected := RRSet{
+		Domain:  "example.com",
+		SubName: "s1._domainkey.mail",
+		Type:    "TXT",
+		Records: []string{`"v=DKIM1; k=ed25519; p=AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="`},
+		TTL:     3600,
+	}
+	assert.Equal(t, expected, rrSet)
+}
+
+func TestDMARCPolicy_Format(t *testing.T) {
+	policy := DMARCPolicy{
+		Policy:           DMARCReject,
+		SubdomainPolicy:  DMARCQuarantine,
+		Percent:          50,
+		AggregateReports: []string{"mailto:dmarc@example.com"},
+		DKIMAlignment:    DMARCStrict,
+		FailureOptions:   "d:s",
+	}
+
+	value, err := policy.Format()
+	require.NoError(t, err)
+
+	assert.Equal(t, "v=DMARC1; p=reject; sp=quarantine; pct=50; rua=mailto:dmarc@example.com; adkim=s; fo=d:s", value)
+}
+
+func TestDMARCPolicy_Format_errors(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		policy DMARCPolicy
+	}{
+		{desc: "missing policy", policy: DMARCPolicy{}},
+		{desc: "invalid subdomain policy", policy: DMARCPolicy{Policy: DMARCNone, SubdomainPolicy: "foo"}},
+		{desc: "invalid percent", policy: DMARCPolicy{Policy: DMARCNone, Percent: 101}},
+		{desc: "invalid URI", policy: DMARCPolicy{Policy: DMARCNone, AggregateReports: []string{"dmarc@example.com"}}},
+		{desc: "invalid alignment", policy: DMARCPolicy{Policy: DMARCNone, SPFAlignment: "x"}},
+		{desc: "invalid failure options", policy: DMARCPolicy{Policy: DMARCNone, FailureOptions: "2"}},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := test.policy.Format()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewDMARCRRSet(t *testing.T) {
+	rrSet, err := NewDMARCRRSet("example.com", "", 3600, DMARCPolicy{Policy: DMARCNone})
+	require.NoError(t, err)
+
+	expected := RRSet{
+		Domain:  "example.com",
+		SubName: "_dmarc",
+		Type:    "TXT",
+		Records: []string{`"v=DMARC1; p=none"`},
+		TTL:     3600,
+	}
+	assert.Equal(t, expected, rrSet)
+}
+
+func TestTXTRecord(t *testing.T) {
+	assert.Equal(t, `"foo \"bar\" \\"`, TXTRecord(`foo "bar" \`))
+
+	long := strings.Repeat("a", 300)
+	assert.Equal(t, `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("a", 45)+`"`, TXTRecord(long))
+}
@@ -0,0 +1,113 @@
+package desec
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Environment variables used by NewFromEnv.
+const (
+	EnvToken      = "DESEC_TOKEN"
+	EnvBaseURL    = "DESEC_BASE_URL"
+	EnvAPIVersion = "DESEC_API_VERSION"
+	EnvTimeout    = "DESEC_TIMEOUT"
+	EnvRetryMax   = "DESEC_RETRY_MAX"
+)
+
+// NewFromEnv creates a new Client configured with environment variables:
+//   - DESEC_TOKEN: the API token (required).
+//   - DESEC_BASE_URL: the base URL of the API (optional).
+//   - DESEC_API_VERSION: the version of the API (optional), DESEC_BASE_URL is then the root of the API.
+//   - DESEC_TIMEOUT: the HTTP client timeout, as a Go duration (ex: 30s) or a number of seconds (optional).
+//   - DESEC_RETRY_MAX: the maximum number of retries (optional).
+func NewFromEnv() (*Client, error) {
+	return NewFromEnvWithFallback(nil)
+}
+
+// NewFromEnvWithFallback creates a new Client configured with environment variables (NewFromEnv),
+// the fallback provides the token if DESEC_TOKEN is not set (ex: a token stored in the keyring of the OS).
+func NewFromEnvWithFallback(fallback TokenProvider) (*Client, error) {
+	token := os.Getenv(EnvToken)
+	if token == "" && fallback == nil {
+		return nil, fmt.Errorf("%s is required", EnvToken)
+	}
+
+	return newFromEnv(token, fallback)
+}
+
+// NewAnonymousFromEnv creates a new Client without token (ex: registration, login),
+// configured with the environment variables of NewFromEnv, DESEC_TOKEN excepted.
+func NewAnonymousFromEnv() (*Client, error) {
+	return newFromEnv("", nil)
+}
+
+func newFromEnv(token string, fallback TokenProvider) (*Client, error) {
+	opts := NewDefaultClientOptions()
+	opts.APIVersion = os.Getenv(EnvAPIVersion)
+
+	if token == "" && fallback != nil {
+		opts.TokenProvider = fallback
+	}
+
+	if value := os.Getenv(EnvTimeout); value != "" {
+		timeout, err := parseTimeout(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvTimeout, err)
+		}
+
+		opts.HTTPClient = &http.Client{Timeout: timeout}
+	}
+
+	if value := os.Getenv(EnvRetryMax); value != "" {
+		retryMax, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvRetryMax, err)
+		}
+
+		if retryMax < 0 {
+			return nil, fmt.Errorf("invalid %s: must be positive: %d", EnvRetryMax, retryMax)
+		}
+
+		opts.RetryMax = retryMax
+	}
+
+	client := New(token, opts)
+
+	if value := os.Getenv(EnvBaseURL); value != "" {
+		baseURL, err := url.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", EnvBaseURL, err)
+		}
+
+		if (baseURL.Scheme != "http" && baseURL.Scheme != "https") || baseURL.Host == "" {
+			return nil, fmt.Errorf("invalid %s: must be an absolute HTTP(S) URL: %s", EnvBaseURL, value)
+		}
+
+		client.BaseURL = value
+	}
+
+	return client, nil
+}
+
+func parseTimeout(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		seconds, errA := strconv.Atoi(value)
+		if errA != nil {
+			return 0, err
+		}
+
+		timeout = time.Duration(seconds) * time.Second
+	}
+
+	if timeout <= 0 {
+		return 0, errors.New("must be positive")
+	}
+
+	return timeout, nil
+}
@@ -0,0 +1,122 @@
+package desec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromEnv(t *testing.T) {
+	t.Setenv(EnvToken, "secret")
+	t.Setenv(EnvBaseURL, "https://desec.example.com/api/v1/")
+	t.Setenv(EnvTimeout, "30")
+	t.Setenv(EnvRetryMax, "2")
+
+	client, err := NewFromEnv()
+	require.NoError(t, err)
+
+	assert.Equal(t, StaticToken("secret"), client.tokenProvider)
+	assert.Equal(t, "https://desec.example.com/api/v1/", client.BaseURL)
+}
+
+func TestNewFromEnv_defaults(t *testing.T) {
+	t.Setenv(EnvToken, "secret")
+	t.Setenv(EnvBaseURL, "")
+	t.Setenv(EnvAPIVersion, "")
+	t.Setenv(EnvTimeout, "")
+	t.Setenv(EnvRetryMax, "")
+
+	client, err := NewFromEnv()
+	require.NoError(t, err)
+
+	assert.Equal(t, defaultBaseURL, client.BaseURL)
+}
+
+func TestNewFromEnv_errors(t *testing.T) {
+	testCases := []struct {
+		desc string
+		env  map[string]string
+	}{
+		{
+			desc: "missing token",
+			env:  map[string]string{EnvToken: ""},
+		},
+		{
+			desc: "invalid timeout",
+			env:  map[string]string{EnvToken: "secret", EnvTimeout: "foo"},
+		},
+		{
+			desc: "negative timeout",
+			env:  map[string]string{EnvToken: "secret", EnvTimeout: "-1s"},
+		},
+		{
+			desc: "invalid retry max",
+			env:  map[string]string{EnvToken: "secret", EnvRetryMax: "-1"},
+		},
+		{
+			desc: "relative base URL",
+			env:  map[string]string{EnvToken: "secret", EnvBaseURL: "/api/v1/"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			for _, key := range []string{EnvToken, EnvBaseURL, EnvTimeout, EnvRetryMax} {
+				t.Setenv(key, test.env[key])
+			}
+
+			_, err := NewFromEnv()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewFromEnv_apiVersion(t *testing.T) {
+	t.Setenv(EnvToken, "secret")
+	t.Setenv(EnvBaseURL, "https://desec.example.com/api/")
+	t.Setenv(EnvAPIVersion, "v2")
+	t.Setenv(EnvTimeout, "")
+	t.Setenv(EnvRetryMax, "")
+
+	client, err := NewFromEnv()
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://desec.example.com/api/", client.BaseURL)
+	assert.Equal(t, "v2", client.APIVersion)
+}
+
+func TestNewFromEnvWithFallback(t *testing.T) {
+	t.Setenv(EnvToken, "")
+	t.Setenv(EnvBaseURL, "")
+	t.Setenv(EnvAPIVersion, "")
+	t.Setenv(EnvTimeout, "")
+	t.Setenv(EnvRetryMax, "")
+
+	client, err := NewFromEnvWithFallback(StaticToken("stored"))
+	require.NoError(t, err)
+
+	assert.Equal(t, StaticToken("stored"), client.tokenProvider)
+
+	t.Setenv(EnvToken, "secret")
+
+	client, err = NewFromEnvWithFallback(StaticToken("stored"))
+	require.NoError(t, err)
+
+	assert.Equal(t, StaticToken("secret"), client.tokenProvider)
+}
+
+func TestNewAnonymousFromEnv(t *testing.T) {
+	t.Setenv(EnvToken, "secret")
+	t.Setenv(EnvBaseURL, "https://desec.example.com/api/")
+	t.Setenv(EnvAPIVersion, "v2")
+	t.Setenv(EnvTimeout, "")
+	t.Setenv(EnvRetryMax, "")
+
+	client, err := NewAnonymousFromEnv()
+	require.NoError(t, err)
+
+	assert.Equal(t, StaticToken(""), client.tokenProvider)
+	assert.Equal(t, "https://desec.example.com/api/", client.BaseURL)
+	assert.Equal(t, "v2", client.APIVersion)
+}
@@ -1,10 +1,15 @@
 package desec
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
 // NotFoundError Not found error.
@@ -16,10 +21,100 @@ func (n NotFoundError) Error() string {
 	return n.Detail
 }
 
+// ErrTokenInvalid the token is invalid (unknown, revoked, or expired).
+var ErrTokenInvalid = errors.New("invalid token")
+
+// TokenInvalidError the API rejected the token (401).
+// The API doesn't return the state of an invalid token (is_valid, last_used):
+// it is looked up with another token if ClientOptions.InspectInvalidToken is set.
+type TokenInvalidError struct {
+	Detail string `json:"detail"`
+
+	// IsValid the validity of the token, nil if unknown.
+	IsValid *bool `json:"-"`
+	// LastUsed the last use of the token, nil if unknown or never used.
+	LastUsed *time.Time `json:"-"`
+}
+
+func (e TokenInvalidError) Error() string {
+	msg := fmt.Sprintf("%v: %s", ErrTokenInvalid, e.Detail)
+
+	if e.IsValid != nil {
+		msg += fmt.Sprintf(" (is_valid: %t", *e.IsValid)
+
+		if e.LastUsed != nil {
+			msg += ", last used: " + e.LastUsed.Format(time.RFC3339)
+		}
+
+		msg += ")"
+	}
+
+	return msg
+}
+
+// Is allows errors.Is(err, ErrTokenInvalid).
+func (e TokenInvalidError) Is(target error) bool {
+	return target == ErrTokenInvalid
+}
+
+// QuotaExceededError the account reached its maximum number of domains (403).
+type QuotaExceededError struct {
+	Detail string `json:"detail"`
+}
+
+func (e QuotaExceededError) Error() string {
+	return e.Detail
+}
+
+// PendingConfirmationError the API accepted the request (202), but the operation is pending:
+// it must be confirmed (ex: with the link sent by email) to be applied.
+type PendingConfirmationError struct {
+	Detail string `json:"detail"`
+
+	// Location the value of the Location header, if any.
+	Location string `json:"-"`
+}
+
+func (e PendingConfirmationError) Error() string {
+	if e.Detail == "" {
+		return "pending confirmation"
+	}
+
+	return "pending confirmation: " + e.Detail
+}
+
+// PaginationRequiredError the list is too large to be retrieved at once (400), the pagination must be used.
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#pagination
+type PaginationRequiredError struct {
+	Detail string `json:"detail"`
+
+	// Cursors the cursor of the first page.
+	Cursors *Cursors `json:"-"`
+}
+
+func (e PaginationRequiredError) Error() string {
+	return e.Detail
+}
+
+// maxBodySnippet the maximum size of the response body kept by APIError.
+const maxBodySnippet = 512
+
 // APIError error from API.
 type APIError struct {
 	StatusCode int
-	err        error
+
+	// Method the HTTP method of the request.
+	Method string
+	// URL the URL of the request, without credentials.
+	URL string
+	// RequestID the value of the X-Request-Id header, if any.
+	RequestID string
+	// Date the value of the Date header, if any.
+	Date time.Time
+	// Body the beginning of the response body (512 bytes at most).
+	Body string
+
+	err error
 }
 
 func (e APIError) Error() string {
@@ -31,6 +126,58 @@ func (e APIError) Unwrap() error {
 	return e.err
 }
 
+// withRequestContext reads the body of an error response with the read function,
+// and adds the context of the request and the response to the returned APIError.
+func withRequestContext(resp *http.Response, read func(resp *http.Response) error) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		err = &APIError{
+			StatusCode: resp.StatusCode,
+			err:        fmt.Errorf("failed to read response body: %w", err),
+		}
+	} else {
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		err = read(resp)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.URL = sanitizeURL(resp.Request.URL)
+	}
+
+	apiErr.RequestID = resp.Header.Get("X-Request-Id")
+
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		apiErr.Date = date
+	}
+
+	if len(body) > maxBodySnippet {
+		body = body[:maxBodySnippet]
+	}
+
+	apiErr.Body = string(body)
+
+	return err
+}
+
+func sanitizeURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	clean := *u
+	clean.User = nil
+	clean.Fragment = ""
+
+	return clean.String()
+}
+
 func readError(resp *http.Response, er error) error {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -54,6 +201,36 @@ func readError(resp *http.Response, er error) error {
 	}
 }
 
+// readForbiddenError returns a QuotaExceededError if the body is about the domain limit, a raw error otherwise.
+func readForbiddenError(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &APIError{
+			StatusCode: resp.StatusCode,
+			err:        fmt.Errorf("failed to read response body: %w", err),
+		}
+	}
+
+	var quotaErr QuotaExceededError
+
+	err = json.Unmarshal(body, &quotaErr)
+	if err == nil && strings.Contains(quotaErr.Detail, "maximum number of domains") {
+		return &APIError{StatusCode: resp.StatusCode, err: &quotaErr}
+	}
+
+	return &APIError{StatusCode: resp.StatusCode, err: fmt.Errorf("body: %s", string(body))}
+}
+
+// readBadRequestError returns a PaginationRequiredError if the response contains pagination links, a raw error otherwise.
+func readBadRequestError(resp *http.Response) error {
+	cursors, err := parseCursor(resp.Header)
+	if err != nil || resp.Header.Get("Link") == "" {
+		return readRawError(resp)
+	}
+
+	return readError(resp, &PaginationRequiredError{Cursors: cursors})
+}
+
 func readRawError(resp *http.Response) error {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -65,3 +242,92 @@ func readRawError(resp *http.Response) error {
 
 	return &APIError{StatusCode: resp.StatusCode, err: fmt.Errorf("body: %s", string(body))}
 }
+
+// ResponseTooLargeError the response body is larger than the limit (ClientOptions.MaxResponseSize).
+type ResponseTooLargeError struct {
+	Limit int64
+}
+
+func (e ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body larger than %d bytes", e.Limit)
+}
+
+// InvalidSubNameError the syntax of a subname is invalid (ValidateSubName).
+type InvalidSubNameError struct {
+	SubName string
+	Reason  string
+}
+
+func (e InvalidSubNameError) Error() string {
+	return fmt.Sprintf("invalid subname %q: %s", e.SubName, e.Reason)
+}
+
+// UnsupportedTypeError the RRSet type is managed by deSEC and cannot be written (IsManagedType).
+type UnsupportedTypeError struct {
+	Type string
+}
+
+func (e UnsupportedTypeError) Error() string {
+	return fmt.Sprintf("the RRSet type %s is managed by deSEC and cannot be written", e.Type)
+}
+
+// TTLTooLowError the TTL of a RRSet is lower than the minimum TTL of the domain (MinimumTTLReject).
+type TTLTooLowError struct {
+	Domain     string
+	SubName    string
+	Type       string
+	TTL        int
+	MinimumTTL int
+}
+
+func (e TTLTooLowError) Error() string {
+	return fmt.Sprintf("the TTL %d of the RRSet %s/%s in %s is lower than the minimum TTL %d", e.TTL, e.SubName, e.Type, e.Domain, e.MinimumTTL)
+}
+
+// ConflictError a RRSet has been modified concurrently during a read-modify-write (ClientOptions.ConflictRetries).
+type ConflictError struct {
+	Domain  string
+	SubName string
+	Type    string
+}
+
+func (e ConflictError) Error() string {
+	return fmt.Sprintf("concurrent modification of the RRSet %s/%s in %s", e.SubName, e.Type, e.Domain)
+}
+
+// UnknownFieldError a response contains a field unknown to the library (ClientOptions.StrictDecoding).
+type UnknownFieldError struct {
+	Field string
+	err   error
+}
+
+func (e UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field %q in response body", e.Field)
+}
+
+// Unwrap unwraps error.
+func (e UnknownFieldError) Unwrap() error {
+	return e.err
+}
+
+func decodeStrict(body []byte, respData any) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(respData)
+	if err == nil {
+		// The types with a custom unmarshaler capture the unknown fields instead of rejecting them.
+		if field := firstExtraField(respData); field != "" {
+			return &UnknownFieldError{Field: field}
+		}
+
+		return nil
+	}
+
+	// The encoding/json package doesn't provide a typed error for unknown fields.
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return &UnknownFieldError{Field: strings.Trim(field, `"`), err: err}
+	}
+
+	return fmt.Errorf("failed to umarshal response body: %w", err)
+}
@@ -0,0 +1,153 @@
+package desec
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// external-dns TXT registry labels.
+// https://github.com/kubernetes-sigs/external-dns/blob/master/docs/registry/txt.md
+const (
+	ExternalDNSHeritage      = "external-dns"
+	ExternalDNSLabelOwner    = "owner"
+	ExternalDNSLabelResource = "resource"
+
+	externalDNSLabelPrefix     = "external-dns/"
+	externalDNSRecordTypeAffix = "%{record_type}"
+)
+
+// ExternalDNSRegistry builds and reads the ownership TXT records of the external-dns TXT registry.
+type ExternalDNSRegistry struct {
+	// OwnerID the owner ID of the external-dns instance (--txt-owner-id).
+	OwnerID string
+	// Prefix the prefix of the TXT records (--txt-prefix), can contain %{record_type}.
+	Prefix string
+	// Suffix the suffix of the TXT records (--txt-suffix), can contain %{record_type}.
+	Suffix string
+	// WildcardReplacement replaces a leading "*" in the TXT record names (--txt-wildcard-replacement).
+	WildcardReplacement string
+}
+
+// TXTSubName returns the subname of the ownership TXT record of a managed RRSet.
+// The name is built like external-dns does: the affixes and the record type are applied to the first label of the owner name.
+// An error is returned if the name is outside the domain (apex RRSets).
+func (r ExternalDNSRegistry) TXTSubName(domainName, subName, recordType string) (string, error) {
+	owner := strings.TrimSuffix(OwnerName(domainName, subName), ".")
+
+	labels := strings.SplitN(owner, ".", 2)
+	recordType = strings.ToLower(recordType)
+
+	prefix := strings.ReplaceAll(r.Prefix, externalDNSRecordTypeAffix, recordType)
+	suffix := strings.ReplaceAll(r.Suffix, externalDNSRecordTypeAffix, recordType)
+
+	if r.WildcardReplacement != "" && labels[0] == "*" {
+		labels[0] = r.WildcardReplacement
+	}
+
+	if !strings.Contains(r.Prefix+r.Suffix, externalDNSRecordTypeAffix) {
+		labels[0] = recordType + "-" + labels[0]
+	}
+
+	txtName := prefix + labels[0] + suffix
+	if len(labels) == 2 {
+		txtName += "." + labels[1]
+	}
+
+	return SubNameFromOwner(domainName, txtName)
+}
+
+// OwnershipRRSet creates the ownership TXT RRSet of a managed RRSet.
+// The labels are added to the heritage and owner labels (ex: resource).
+func (r ExternalDNSRegistry) OwnershipRRSet(rrSet RRSet, ttl int, labels map[string]string) (RRSet, error) {
+	if r.OwnerID == "" {
+		return RRSet{}, errors.New("missing owner ID")
+	}
+
+	subName, err := r.TXTSubName(rrSet.Domain, rrSet.SubName, rrSet.Type)
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	all := maps.Clone(labels)
+	if all == nil {
+		all = make(map[string]string)
+	}
+
+	all[ExternalDNSLabelOwner] = r.OwnerID
+
+	return RRSet{
+		Domain:  rrSet.Domain,
+		SubName: subName,
+		Type:    "TXT",
+		TTL:     ttl,
+		Records: []string{TXTRecord(FormatExternalDNSLabels(all))},
+	}, nil
+}
+
+// Owns reports whether an ownership TXT RRSet belongs to the owner ID of the registry.
+func (r ExternalDNSRegistry) Owns(txt RRSet) bool {
+	for _, record := range txt.Records {
+		labels, err := ParseExternalDNSLabels(parseTXT(record))
+		if err == nil && labels[ExternalDNSLabelOwner] == r.OwnerID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatExternalDNSLabels formats the labels of an ownership TXT record (without quotes).
+func FormatExternalDNSLabels(labels map[string]string) string {
+	parts := []string{"heritage=" + ExternalDNSHeritage}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s%s=%s", externalDNSLabelPrefix, key, labels[key]))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// ParseExternalDNSLabels parses the labels of an ownership TXT record (without quotes).
+// The "external-dns/" prefix is removed from the keys.
+func ParseExternalDNSLabels(value string) (map[string]string, error) {
+	labels := make(map[string]string)
+
+	var heritage bool
+
+	for _, part := range strings.Split(value, ",") {
+		key, val, found := strings.Cut(part, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid label: %q", part)
+		}
+
+		if key == "heritage" {
+			if val != ExternalDNSHeritage {
+				return nil, fmt.Errorf("invalid heritage: %q", val)
+			}
+
+			heritage = true
+
+			continue
+		}
+
+		if name, ok := strings.CutPrefix(key, externalDNSLabelPrefix); ok {
+			labels[name] = val
+		}
+	}
+
+	if !heritage {
+		return nil, errors.New("missing heritage")
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,101 @@
+package desec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalDNSRegistry_TXTSubName(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		registry ExternalDNSRegistry
+		subName  string
+		expected string
+	}{
+		{
+			desc:     "no affix",
+			subName:  "www",
+			expected: "a-www",
+		},
+		{
+			desc:     "prefix",
+			registry: ExternalDNSRegistry{Prefix: "txt."},
+			subName:  "www",
+			expected: "txt.a-www",
+		},
+		{
+			desc:     "prefix with record type",
+			registry: ExternalDNSRegistry{Prefix: "%{record_type}-txt."},
+			subName:  "www.foo",
+			expected: "a-txt.www.foo",
+		},
+		{
+			desc:     "suffix",
+			registry: ExternalDNSRegistry{Suffix: "-txt"},
+			subName:  "www.foo",
+			expected: "a-www-txt.foo",
+		},
+		{
+			desc:     "wildcard",
+			registry: ExternalDNSRegistry{WildcardReplacement: "any"},
+			subName:  "*.foo",
+			expected: "a-any.foo",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			subName, err := test.registry.TXTSubName("example.dedyn.io", test.subName, "A")
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, subName)
+		})
+	}
+}
+
+func TestExternalDNSRegistry_TXTSubName_apex(t *testing.T) {
+	_, err := ExternalDNSRegistry{}.TXTSubName("example.dedyn.io", "", "A")
+	require.Error(t, err)
+}
+
+func TestExternalDNSRegistry_OwnershipRRSet(t *testing.T) {
+	registry := ExternalDNSRegistry{OwnerID: "cluster-1"}
+
+	rrSet := RRSet{Domain: "example.dedyn.io", SubName: "www", Type: "CNAME"}
+
+	txt, err := registry.OwnershipRRSet(rrSet, 3600, map[string]string{ExternalDNSLabelResource: "ingress/default/web"})
+	require.NoError(t, err)
+
+	expected := RRSet{
+		Domain:  "example.dedyn.io",
+		SubName: "cname-www",
+		Type:    "TXT",
+		TTL:     3600,
+		Records: []string{`"heritage=external-dns,external-dns/owner=cluster-1,external-dns/resource=ingress/default/web"`},
+	}
+	assert.Equal(t, expected, txt)
+
+	assert.True(t, registry.Owns(txt))
+	assert.False(t, ExternalDNSRegistry{OwnerID: "cluster-2"}.Owns(txt))
+}
+
+func TestParseExternalDNSLabels(t *testing.T) {
+	labels, err := ParseExternalDNSLabels("heritage=external-dns,external-dns/owner=default,external-dns/resource=service/ns/svc")
+	require.NoError(t, err)
+
+	expected := map[string]string{
+		ExternalDNSLabelOwner:    "default",
+		ExternalDNSLabelResource: "service/ns/svc",
+	}
+	assert.Equal(t, expected, labels)
+
+	_, err = ParseExternalDNSLabels("heritage=other,external-dns/owner=default")
+	require.Error(t, err)
+
+	_, err = ParseExternalDNSLabels("v=spf1 -all")
+	require.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package desec
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// unmarshalWithExtra decodes data into v (a pointer to a struct without UnmarshalJSON method)
+// and returns the fields that are not modeled by the struct.
+func unmarshalWithExtra(data []byte, v any) (map[string]json.RawMessage, error) {
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]json.RawMessage
+
+	err = json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range jsonFieldNames(reflect.TypeOf(v).Elem()) {
+		delete(raw, name)
+	}
+
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	return raw, nil
+}
+
+func jsonFieldNames(t reflect.Type) []string {
+	var names []string
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// withExtra is implemented by the types capturing unknown fields.
+type withExtra interface {
+	extraFields() map[string]json.RawMessage
+}
+
+// firstExtraField returns the name of an unknown field captured inside v (a pointer to a value or to a slice), if any.
+// The pointers are dereferenced at any depth (ex: the **RRSet decoded by do[*RRSet]).
+func firstExtraField(v any) string {
+	value := indirect(reflect.ValueOf(v))
+
+	values := []reflect.Value{value}
+
+	if value.Kind() == reflect.Slice {
+		values = values[:0]
+
+		for i := range value.Len() {
+			values = append(values, indirect(value.Index(i)))
+		}
+	}
+
+	for _, val := range values {
+		if !val.IsValid() || !val.CanAddr() {
+			continue
+		}
+
+		e, ok := val.Addr().Interface().(withExtra)
+		if !ok {
+			continue
+		}
+
+		for name := range e.extraFields() {
+			return name
+		}
+	}
+
+	return ""
+}
+
+// indirect dereferences the pointers until a non-pointer value.
+// Returns the zero Value for a nil pointer.
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+
+		value = value.Elem()
+	}
+
+	return value
+}
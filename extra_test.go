@@ -0,0 +1,62 @@
+package desec
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRRSet_UnmarshalJSON(t *testing.T) {
+	var rrSet RRSet
+
+	err := json.Unmarshal([]byte(`{"subname":"www","type":"A","records":["10.10.10.10"],"ttl":60,"new_field":{"foo":"bar"}}`), &rrSet)
+	require.NoError(t, err)
+
+	expected := RRSet{
+		SubName: "www",
+		Type:    "A",
+		Records: []string{"10.10.10.10"},
+		TTL:     60,
+		Extra:   map[string]json.RawMessage{"new_field": json.RawMessage(`{"foo":"bar"}`)},
+	}
+	assert.Equal(t, expected, rrSet)
+
+	// Extra is not marshaled.
+	data, err := json.Marshal(rrSet)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"subname":"www","type":"A","records":["10.10.10.10"],"ttl":60}`, string(data))
+}
+
+func TestDomain_UnmarshalJSON(t *testing.T) {
+	var domains []Domain
+
+	err := json.Unmarshal([]byte(`[{"name":"example.com"},{"name":"example.org","new_field":"foo"}]`), &domains)
+	require.NoError(t, err)
+
+	expected := []Domain{
+		{Name: "example.com"},
+		{Name: "example.org", Extra: map[string]json.RawMessage{"new_field": json.RawMessage(`"foo"`)}},
+	}
+	assert.Equal(t, expected, domains)
+
+	assert.Equal(t, "new_field", firstExtraField(&domains))
+}
+
+func TestToken_UnmarshalJSON(t *testing.T) {
+	var token Token
+
+	err := json.Unmarshal([]byte(`{"id":"1","name":"test","max_age":"365 00:00:00"}`), &token)
+	require.NoError(t, err)
+
+	expected := Token{
+		ID:    "1",
+		Name:  "test",
+		Extra: map[string]json.RawMessage{"max_age": json.RawMessage(`"365 00:00:00"`)},
+	}
+	assert.Equal(t, expected, token)
+
+	assert.Equal(t, "max_age", firstExtraField(&token))
+}
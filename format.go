@@ -0,0 +1,114 @@
+package desec
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// String returns a one-line representation of the RRSet: "www.example.com A 3600 [192.0.2.1 192.0.2.2]".
+func (r RRSet) String() string {
+	return fmt.Sprintf("%s %s %d [%s]", r.displayName(), r.Type, r.TTL, strings.Join(r.Records, " "))
+}
+
+func (r RRSet) displayName() string {
+	switch {
+	case r.Domain == "" && r.SubName == "":
+		return ApexZone
+	case r.Domain == "":
+		return r.SubName
+	default:
+		return strings.TrimSuffix(OwnerName(r.Domain, r.SubName), ".")
+	}
+}
+
+func (r RRSet) tableHeader() []string {
+	return []string{"NAME", "TYPE", "TTL", "RECORDS"}
+}
+
+func (r RRSet) tableRow() []string {
+	return []string{r.displayName(), r.Type, strconv.Itoa(r.TTL), strings.Join(r.Records, " ")}
+}
+
+// String returns a one-line representation of the domain: "example.com minimum_ttl=3600 keys=1 published=2024-01-01T00:00:00Z".
+func (d Domain) String() string {
+	s := fmt.Sprintf("%s minimum_ttl=%d keys=%d", d.Name, d.MinimumTTL, len(d.Keys))
+
+	if d.Published != nil {
+		s += " published=" + d.Published.Format(time.RFC3339)
+	}
+
+	return s
+}
+
+func (d Domain) tableHeader() []string {
+	return []string{"NAME", "MINIMUM TTL", "KEYS", "PUBLISHED"}
+}
+
+func (d Domain) tableRow() []string {
+	published := "-"
+	if d.Published != nil {
+		published = d.Published.Format(time.RFC3339)
+	}
+
+	return []string{d.Name, strconv.Itoa(d.MinimumTTL), strconv.Itoa(len(d.Keys)), published}
+}
+
+// String returns a one-line representation of the policy: "domain=example.com subname=www type=* write=true".
+// The fields matching everything (nil) are represented by "*", the apex by "@".
+func (p TokenPolicy) String() string {
+	return fmt.Sprintf("domain=%s subname=%s type=%s write=%t", policyField(p.Domain), policySubName(p.SubName), policyField(p.Type), p.WritePermission)
+}
+
+func (p TokenPolicy) tableHeader() []string {
+	return []string{"ID", "DOMAIN", "SUBNAME", "TYPE", "WRITE"}
+}
+
+func (p TokenPolicy) tableRow() []string {
+	return []string{p.ID, policyField(p.Domain), policySubName(p.SubName), policyField(p.Type), strconv.FormatBool(p.WritePermission)}
+}
+
+func policyField(value *string) string {
+	if value == nil {
+		return "*"
+	}
+
+	return *value
+}
+
+func policySubName(value *string) string {
+	if value != nil && *value == "" {
+		return ApexZone
+	}
+
+	return policyField(value)
+}
+
+type tableRower interface {
+	tableHeader() []string
+	tableRow() []string
+}
+
+// WriteTable writes the items (RRSet, Domain, TokenPolicy) as a table aligned with spaces.
+func WriteTable[T tableRower](w io.Writer, items []T) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	var zero T
+
+	_, err := fmt.Fprintln(tw, strings.Join(zero.tableHeader(), "\t"))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		_, err = fmt.Fprintln(tw, strings.Join(item.tableRow(), "\t"))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
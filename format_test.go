@@ -0,0 +1,79 @@
+package desec
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRRSet_String(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rrSet    RRSet
+		expected string
+	}{
+		{
+			desc:     "subname",
+			rrSet:    RRSet{SubName: "www", Type: "A", TTL: 3600, Records: []string{"192.0.2.1", "192.0.2.2"}},
+			expected: "www A 3600 [192.0.2.1 192.0.2.2]",
+		},
+		{
+			desc:     "apex",
+			rrSet:    RRSet{Type: "MX", TTL: 3600, Records: []string{"10 mx.example.com."}},
+			expected: "@ MX 3600 [10 mx.example.com.]",
+		},
+		{
+			desc:     "with domain",
+			rrSet:    RRSet{Domain: "example.com", SubName: "www", Type: "A", TTL: 3600, Records: []string{"192.0.2.1"}},
+			expected: "www.example.com A 3600 [192.0.2.1]",
+		},
+		{
+			desc:     "apex with domain",
+			rrSet:    RRSet{Domain: "example.com", Type: "A", TTL: 3600, Records: []string{"192.0.2.1"}},
+			expected: "example.com A 3600 [192.0.2.1]",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.rrSet.String())
+		})
+	}
+}
+
+func TestDomain_String(t *testing.T) {
+	published := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	domain := Domain{Name: "example.com", MinimumTTL: 3600, Keys: []DomainKey{{}}, Published: &published}
+
+	assert.Equal(t, "example.com minimum_ttl=3600 keys=1 published=2024-01-02T03:04:05Z", domain.String())
+}
+
+func TestTokenPolicy_String(t *testing.T) {
+	policy := TokenPolicy{Domain: Pointer("example.com"), SubName: Pointer(""), WritePermission: true}
+
+	assert.Equal(t, "domain=example.com subname=@ type=* write=true", policy.String())
+}
+
+func TestWriteTable(t *testing.T) {
+	rrSets := []RRSet{
+		{SubName: "www", Type: "A", TTL: 3600, Records: []string{"192.0.2.1", "192.0.2.2"}},
+		{Type: "MX", TTL: 300, Records: []string{"10 mx.example.com."}},
+	}
+
+	buf := &bytes.Buffer{}
+
+	err := WriteTable(buf, rrSets)
+	require.NoError(t, err)
+
+	expected := `NAME  TYPE  TTL   RECORDS
+www   A     3600  192.0.2.1 192.0.2.2
+@     MX    300   10 mx.example.com.
+`
+	assert.Equal(t, expected, buf.String())
+}
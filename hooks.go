@@ -0,0 +1,70 @@
+package desec
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// Hooks callbacks called during the lifecycle of the requests.
+// Useful to emit metrics or audit entries without wrapping the transport.
+// The callbacks must be safe for concurrent use.
+type Hooks struct {
+	// OnRequest is called before sending a request (once per API call, retries excluded).
+	OnRequest func(req *http.Request)
+	// OnResponse is called when an API call ends (after the retries).
+	// The response is nil if err is not nil, the body must not be read.
+	OnResponse func(req *http.Request, resp *http.Response, err error, duration time.Duration)
+	// OnRetry is called before each retry, attempt starts at 1.
+	OnRetry func(req *http.Request, attempt int)
+	// OnRateLimited is called each time the API answers with the status 429 (Too Many Requests).
+	OnRateLimited func(resp *http.Response)
+}
+
+// hooksDoer calls the OnRequest and OnResponse hooks.
+type hooksDoer struct {
+	next  httpDoer
+	hooks Hooks
+}
+
+func (d hooksDoer) Do(req *http.Request) (*http.Response, error) {
+	if d.hooks.OnRequest != nil {
+		d.hooks.OnRequest(req)
+	}
+
+	start := time.Now()
+
+	resp, err := d.next.Do(req)
+
+	if d.hooks.OnResponse != nil {
+		d.hooks.OnResponse(req, resp, err, time.Since(start))
+	}
+
+	return resp, err
+}
+
+// setupRetryHooks plugs the OnRetry and OnRateLimited hooks, the retry observers (Progress), and the attempts tracking (CanceledError), into the retry client.
+func setupRetryHooks(client *retryablehttp.Client, hooks Hooks) {
+	client.RequestLogHook = func(_ retryablehttp.Logger, req *http.Request, attempt int) {
+		recordAttempt(req.Context(), attempt)
+
+		if attempt == 0 {
+			return
+		}
+
+		observeRetry(req.Context())
+
+		if hooks.OnRetry != nil {
+			hooks.OnRetry(req, attempt)
+		}
+	}
+
+	if hooks.OnRateLimited != nil {
+		client.ResponseLogHook = func(_ retryablehttp.Logger, resp *http.Response) {
+			if resp.StatusCode == http.StatusTooManyRequests {
+				hooks.OnRateLimited(resp)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_hooks(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var (
+		mu          sync.Mutex
+		events      []string
+		rateLimited int
+	)
+
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		events = append(events, event)
+	}
+
+	opts := NewDefaultClientOptions()
+	opts.Hooks = Hooks{
+		OnRequest: func(req *http.Request) {
+			record("request " + req.Method)
+		},
+		OnResponse: func(_ *http.Request, resp *http.Response, err error, _ time.Duration) {
+			if err != nil {
+				record("error")
+				return
+			}
+
+			record("response " + resp.Status)
+		},
+		OnRetry: func(_ *http.Request, attempt int) {
+			record("retry")
+		},
+		OnRateLimited: func(resp *http.Response) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			rateLimited++
+		},
+	}
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	var calls int
+
+	mux.HandleFunc("/domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		if calls == 1 {
+			rw.Header().Set("Retry-After", "0")
+			http.Error(rw, `{"detail":"Request was throttled."}`, http.StatusTooManyRequests)
+
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"name":"example.com"}`))
+	})
+
+	_, err := client.Domains.Get(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"request GET", "retry", "response 200 OK"}, events)
+	assert.Equal(t, 1, rateLimited)
+}
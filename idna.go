@@ -0,0 +1,113 @@
+package desec
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// idnaProfile is the IDNA profile used to convert names to punycode.
+// The underscore must be allowed because it's used by some subnames (ex: `_acme-challenge`).
+var idnaProfile = idna.New(idna.MapForLookup(), idna.StrictDomainName(false), idna.Transitional(false))
+
+// toASCII converts a domain name or a subname containing Unicode characters to punycode.
+// ASCII names are returned unchanged.
+func toASCII(name string) (string, error) {
+	if isASCII(name) {
+		return name, nil
+	}
+
+	ascii, err := idnaProfile.ToASCII(name)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert %q to punycode: %w", name, err)
+	}
+
+	return ascii, nil
+}
+
+// toUnicode converts a punycode domain name or subname to Unicode if the IDN decoding is enabled.
+func (c *Client) toUnicode(name string) string {
+	if !c.decodeIDN || !strings.Contains(name, "xn--") {
+		return name
+	}
+
+	unicode, err := idna.Punycode.ToUnicode(name)
+	if err != nil {
+		return name
+	}
+
+	return unicode
+}
+
+func encodeRRSet(rrSet RRSet) (RRSet, error) {
+	var err error
+
+	rrSet.Domain, err = toASCII(rrSet.Domain)
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	// The subname of the apex is empty in the request bodies.
+	rrSet.SubName = normalizeSubName(rrSet.SubName)
+
+	err = ValidateSubName(rrSet.SubName)
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	rrSet.SubName, err = toASCII(rrSet.SubName)
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	return rrSet, nil
+}
+
+func encodeRRSets(rrSets []RRSet) ([]RRSet, error) {
+	encoded := make([]RRSet, len(rrSets))
+
+	for i, rrSet := range rrSets {
+		var err error
+
+		encoded[i], err = encodeRRSet(rrSet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return encoded, nil
+}
+
+func (c *Client) decodeRRSets(rrSets []RRSet) {
+	for i := range rrSets {
+		c.decodeRRSet(&rrSets[i])
+	}
+}
+
+func (c *Client) decodeRRSet(rrSet *RRSet) {
+	rrSet.Name = c.toUnicode(rrSet.Name)
+	rrSet.Domain = c.toUnicode(rrSet.Domain)
+	rrSet.SubName = c.toUnicode(rrSet.SubName)
+}
+
+func (c *Client) decodeDomains(domains []Domain) {
+	for i := range domains {
+		c.decodeDomain(&domains[i])
+	}
+}
+
+func (c *Client) decodeDomain(domain *Domain) {
+	domain.Name = c.toUnicode(domain.Name)
+}
+
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
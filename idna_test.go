@@ -0,0 +1,109 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_toASCII(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		name     string
+		expected string
+	}{
+		{
+			desc:     "ASCII",
+			name:     "_acme-challenge",
+			expected: "_acme-challenge",
+		},
+		{
+			desc:     "apex",
+			name:     ApexZone,
+			expected: ApexZone,
+		},
+		{
+			desc:     "Unicode",
+			name:     "bücher.example",
+			expected: "xn--bcher-kva.example",
+		},
+		{
+			desc:     "Unicode uppercase",
+			name:     "Bücher.example",
+			expected: "xn--bcher-kva.example",
+		},
+		{
+			desc:     "Unicode with underscore",
+			name:     "_acme-challenge.bücher",
+			expected: "_acme-challenge.xn--bcher-kva",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			name, err := toASCII(test.name)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, name)
+		})
+	}
+}
+
+func TestRecordsService_Create_idn(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	opts := NewDefaultClientOptions()
+	opts.DecodeIDN = true
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/xn--bcher-kva.example/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var rrSet RRSet
+		err := json.NewDecoder(req.Body).Decode(&rrSet)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rrSet.Name = rrSet.SubName + "." + rrSet.Domain + "."
+
+		rw.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(rw).Encode(rrSet)
+	})
+
+	rrSet := RRSet{
+		Domain:  "bücher.example",
+		SubName: "café",
+		Type:    "A",
+		Records: []string{"10.10.10.10"},
+		TTL:     3600,
+	}
+
+	newRRSet, err := client.Records.Create(context.Background(), rrSet)
+	require.NoError(t, err)
+
+	expected := &RRSet{
+		Name:    "café.bücher.example.",
+		Domain:  "bücher.example",
+		SubName: "café",
+		Type:    "A",
+		Records: []string{"10.10.10.10"},
+		TTL:     3600,
+	}
+	assert.Equal(t, expected, newRRSet)
+}
@@ -0,0 +1,178 @@
+package desec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// cloudflareAutoTTL the TTL used by Cloudflare for "automatic".
+const cloudflareAutoTTL = 1
+
+// ParseZonefile parses a BIND zone file.
+// The records managed by deSEC (SOA, apex NS, DNSSEC records) are skipped.
+// The records without TTL get a TTL of 3600.
+func ParseZonefile(domainName string, r io.Reader) ([]RRSet, error) {
+	return parseZonefile(domainName, r, func(uint32) uint32 { return 0 })
+}
+
+// ParseCloudflareZonefile parses a zone file exported by Cloudflare.
+// In addition to ParseZonefile, the "automatic" TTLs are replaced by a TTL of 3600.
+// The Cloudflare specific comments (ex: cf_tags) are ignored.
+func ParseCloudflareZonefile(domainName string, r io.Reader) ([]RRSet, error) {
+	return parseZonefile(domainName, r, func(ttl uint32) uint32 {
+		if ttl == cloudflareAutoTTL {
+			return fallbackTTL
+		}
+
+		return ttl
+	})
+}
+
+func parseZonefile(domainName string, r io.Reader, mapTTL func(uint32) uint32) ([]RRSet, error) {
+	zp := dns.NewZoneParser(r, dns.Fqdn(domainName), "")
+
+	var rrs []dns.RR
+
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		if ttl := mapTTL(rr.Header().Ttl); ttl != 0 {
+			rr.Header().Ttl = ttl
+		}
+
+		rrs = append(rrs, rr)
+	}
+
+	err := zp.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse zone file: %w", err)
+	}
+
+	return groupRRs(domainName, rrs)
+}
+
+type route53Export struct {
+	ResourceRecordSets []struct {
+		Name            string `json:"Name"`
+		Type            string `json:"Type"`
+		TTL             int    `json:"TTL"`
+		ResourceRecords []struct {
+			Value string `json:"Value"`
+		} `json:"ResourceRecords"`
+		AliasTarget json.RawMessage `json:"AliasTarget"`
+	} `json:"ResourceRecordSets"`
+}
+
+// ParseRoute53 parses the JSON output of `aws route53 list-resource-record-sets`.
+// The records managed by deSEC (SOA, apex NS, DNSSEC records) are skipped.
+// The alias records are skipped: they have no equivalent.
+// The record sets with the same name and type (routing policies) are merged.
+func ParseRoute53(domainName string, r io.Reader) ([]RRSet, error) {
+	var export route53Export
+
+	err := json.NewDecoder(r).Decode(&export)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Route 53 export: %w", err)
+	}
+
+	var rrs []dns.RR
+
+	for _, set := range export.ResourceRecordSets {
+		if len(set.AliasTarget) > 0 {
+			continue
+		}
+
+		ttl := set.TTL
+		if ttl == 0 {
+			ttl = fallbackTTL
+		}
+
+		owner := unescapeRoute53Name(set.Name)
+
+		for _, record := range set.ResourceRecords {
+			rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", owner, ttl, set.Type, record.Value))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse record %q: %w", record.Value, err)
+			}
+
+			if rr != nil {
+				rrs = append(rrs, rr)
+			}
+		}
+	}
+
+	return groupRRs(domainName, rrs)
+}
+
+// unescapeRoute53Name decodes the octal escapes (ex: \052 for *) used by Route 53.
+func unescapeRoute53Name(name string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\\' && i+3 < len(name) {
+			if c, err := strconv.ParseUint(name[i+1:i+4], 8, 8); err == nil {
+				sb.WriteByte(byte(c))
+				i += 3
+
+				continue
+			}
+		}
+
+		sb.WriteByte(name[i])
+	}
+
+	return sb.String()
+}
+
+// groupRRs groups the resource records by owner name and type, and skips the records managed by deSEC.
+func groupRRs(domainName string, rrs []dns.RR) ([]RRSet, error) {
+	var keys []string
+
+	groups := make(map[string][]dns.RR)
+
+	for _, rr := range rrs {
+		hdr := rr.Header()
+
+		if hdr.Ttl == 0 {
+			hdr.Ttl = fallbackTTL
+		}
+
+		if isManagedRR(domainName, hdr) {
+			continue
+		}
+
+		key := strings.ToLower(hdr.Name) + "/" + dns.TypeToString[hdr.Rrtype]
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		groups[key] = append(groups[key], rr)
+	}
+
+	rrSets := make([]RRSet, 0, len(keys))
+
+	for _, key := range keys {
+		rrSet, err := RRSetFromRRs(domainName, dns.Dedup(groups[key], nil))
+		if err != nil {
+			return nil, err
+		}
+
+		rrSets = append(rrSets, *rrSet)
+	}
+
+	return rrSets, nil
+}
+
+func isManagedRR(domainName string, hdr *dns.RR_Header) bool {
+	switch hdr.Rrtype {
+	case dns.TypeSOA, dns.TypeRRSIG, dns.TypeNSEC, dns.TypeNSEC3, dns.TypeNSEC3PARAM, dns.TypeDNSKEY:
+		return true
+	case dns.TypeNS:
+		return strings.EqualFold(dns.Fqdn(hdr.Name), dns.Fqdn(domainName))
+	default:
+		return false
+	}
+}
@@ -0,0 +1,70 @@
+package desec
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseZonefile(t *testing.T) {
+	zone := `$ORIGIN example.com.
+$TTL 300
+@	IN	SOA	ns1.example.com. hostmaster.example.com. 1 7200 900 1209600 86400
+@	IN	NS	ns1.example.com.
+www	IN	A	10.10.10.10
+sub	IN	NS	ns1.other.com.
+`
+
+	rrSets, err := ParseZonefile("example.com", strings.NewReader(zone))
+	require.NoError(t, err)
+
+	expected := []RRSet{
+		{Name: "www.example.com.", Domain: "example.com", SubName: "www", Type: "A", TTL: 300, Records: []string{"10.10.10.10"}},
+		{Name: "sub.example.com.", Domain: "example.com", SubName: "sub", Type: "NS", TTL: 300, Records: []string{"ns1.other.com."}},
+	}
+	assert.Equal(t, expected, rrSets)
+}
+
+func TestParseCloudflareZonefile(t *testing.T) {
+	file, err := os.Open("./fixtures/import_cloudflare.txt")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = file.Close() })
+
+	rrSets, err := ParseCloudflareZonefile("example.com", file)
+	require.NoError(t, err)
+
+	expected := []RRSet{
+		{Name: "example.com.", Domain: "example.com", SubName: "", Type: "A", TTL: fallbackTTL, Records: []string{"10.10.10.10"}},
+		{Name: "www.example.com.", Domain: "example.com", SubName: "www", Type: "A", TTL: 300, Records: []string{"10.10.10.11", "10.10.10.12"}},
+		{Name: "example.com.", Domain: "example.com", SubName: "", Type: "MX", TTL: fallbackTTL, Records: []string{"10 mx.example.com."}},
+		{Name: "example.com.", Domain: "example.com", SubName: "", Type: "TXT", TTL: fallbackTTL, Records: []string{`"v=spf1 mx -all"`}},
+	}
+	assert.Equal(t, expected, rrSets)
+}
+
+func TestParseRoute53(t *testing.T) {
+	file, err := os.Open("./fixtures/import_route53.json")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = file.Close() })
+
+	rrSets, err := ParseRoute53("example.com", file)
+	require.NoError(t, err)
+
+	expected := []RRSet{
+		{Name: "*.example.com.", Domain: "example.com", SubName: "*", Type: "CNAME", TTL: 300, Records: []string{"example.com."}},
+		{Name: "_acme-challenge.example.com.", Domain: "example.com", SubName: "_acme-challenge", Type: "TXT", TTL: 60, Records: []string{`"foo"`, `"bar"`}},
+		{Name: "api.example.com.", Domain: "example.com", SubName: "api", Type: "A", TTL: 3600, Records: []string{"10.10.10.10", "10.10.10.11"}},
+	}
+	assert.Equal(t, expected, rrSets)
+}
+
+func TestUnescapeRoute53Name(t *testing.T) {
+	assert.Equal(t, "*.example.com.", unescapeRoute53Name(`\052.example.com.`))
+	assert.Equal(t, "example.com.", unescapeRoute53Name("example.com."))
+	assert.Equal(t, `a\0.example.com.`, unescapeRoute53Name(`a\0.example.com.`))
+}
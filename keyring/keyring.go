@@ -0,0 +1,83 @@
+// Package keyring stores deSEC tokens in the keyring of the OS
+// (macOS Keychain, Windows Credential Manager, Secret Service on Linux).
+//
+//	store := keyring.New(keyring.DefaultUser)
+//
+//	opts := desec.NewDefaultClientOptions()
+//	opts.TokenProvider = store
+//
+//	client := desec.New("", opts)
+package keyring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gokeyring "github.com/zalando/go-keyring"
+)
+
+// DefaultService the service name of the tokens in the keyring.
+const DefaultService = "desec"
+
+// DefaultUser the user name of the token when there is a single account.
+const DefaultUser = "default"
+
+// ErrNotFound no token is stored in the keyring.
+var ErrNotFound = errors.New("no token stored in the keyring")
+
+// Store a token stored in the keyring of the OS, it implements desec.TokenProvider.
+// The token is read from the keyring for each request: a token replaced in the keyring is used without recreating the client.
+type Store struct {
+	// Service the service name of the token (default: DefaultService).
+	Service string
+	// User the user name of the token, ex: DefaultUser, an email address, or a profile name.
+	User string
+}
+
+// New creates a new Store for the token of a user, with DefaultService.
+func New(user string) *Store {
+	return &Store{Service: DefaultService, User: user}
+}
+
+// Token implements desec.TokenProvider.
+func (s *Store) Token(_ context.Context) (string, error) {
+	token, err := gokeyring.Get(s.service(), s.User)
+	if errors.Is(err, gokeyring.ErrNotFound) {
+		return "", fmt.Errorf("%w (%s/%s)", ErrNotFound, s.service(), s.User)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read the token from the keyring: %w", err)
+	}
+
+	return token, nil
+}
+
+// Set stores the token in the keyring, replacing the previous one.
+func (s *Store) Set(token string) error {
+	err := gokeyring.Set(s.service(), s.User, token)
+	if err != nil {
+		return fmt.Errorf("failed to store the token in the keyring: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the token from the keyring, a missing token is not an error.
+func (s *Store) Delete() error {
+	err := gokeyring.Delete(s.service(), s.User)
+	if err != nil && !errors.Is(err, gokeyring.ErrNotFound) {
+		return fmt.Errorf("failed to remove the token from the keyring: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) service() string {
+	if s.Service == "" {
+		return DefaultService
+	}
+
+	return s.Service
+}
@@ -0,0 +1,43 @@
+package keyring
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nrdcg/desec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gokeyring "github.com/zalando/go-keyring"
+)
+
+var _ desec.TokenProvider = (*Store)(nil)
+
+func TestStore(t *testing.T) {
+	gokeyring.MockInit()
+
+	store := New(DefaultUser)
+
+	_, err := store.Token(context.Background())
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = store.Set("secret")
+	require.NoError(t, err)
+
+	token, err := store.Token(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "secret", token)
+
+	other, err := (&Store{User: "other"}).Token(context.Background())
+	require.ErrorIs(t, err, ErrNotFound)
+	assert.Empty(t, other)
+
+	err = store.Delete()
+	require.NoError(t, err)
+
+	err = store.Delete()
+	require.NoError(t, err)
+
+	_, err = store.Token(context.Background())
+	require.ErrorIs(t, err, ErrNotFound)
+}
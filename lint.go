@@ -0,0 +1,183 @@
+package desec
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Lint checks.
+const (
+	LintDuplicate       = "duplicate"
+	LintCNAMECoexist    = "cname-coexistence"
+	LintApexCNAME       = "apex-cname"
+	LintWildcard        = "wildcard"
+	LintLimits          = "limits"
+	LintUnsupportedType = "unsupported-type"
+)
+
+// The limits enforced by deSEC.
+const (
+	maxTTL         = 604800
+	maxLabelLength = 63
+	maxNameLength  = 253
+	maxRRSetLength = 64000
+)
+
+// LintFinding a problem found by Lint.
+type LintFinding struct {
+	Check    string
+	Severity string
+	SubName  string
+	Type     string
+	Message  string
+}
+
+// LintReport the result of Lint.
+type LintReport struct {
+	Findings []LintFinding
+}
+
+// OK returns true if the report doesn't contain errors.
+func (r *LintReport) OK() bool {
+	for _, finding := range r.Findings {
+		if finding.Severity == SeverityError {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *LintReport) add(check, severity string, rrSet RRSet, format string, args ...any) {
+	r.Findings = append(r.Findings, LintFinding{
+		Check:    check,
+		Severity: severity,
+		SubName:  normalizeSubName(rrSet.SubName),
+		Type:     rrSet.Type,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Lint checks RRSets before writing them, to fail fast instead of relying on the errors of the API:
+// duplicated subname/type pairs, CNAME coexisting with other types, CNAME at the zone apex,
+// illegal wildcards, managed types, and values above the deSEC limits (TTL, name lengths, RRSet size).
+// The RRSets without records (deletions) are ignored.
+func Lint(rrSets []RRSet) *LintReport {
+	report := &LintReport{}
+
+	seen := map[RRSetKey]struct{}{}
+	typesBySubName := map[string][]string{}
+
+	for _, rrSet := range rrSets {
+		if len(rrSet.Records) == 0 {
+			continue
+		}
+
+		subName := strings.ToLower(normalizeSubName(rrSet.SubName))
+		recordType := strings.ToUpper(rrSet.Type)
+
+		key := NewRRSetKey(subName, recordType)
+		if _, ok := seen[key]; ok {
+			report.add(LintDuplicate, SeverityError, rrSet, "duplicated RRSet %s", key)
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		typesBySubName[subName] = append(typesBySubName[subName], recordType)
+
+		lintRRSet(report, rrSet, subName, recordType)
+	}
+
+	subNames := make([]string, 0, len(typesBySubName))
+	for subName := range typesBySubName {
+		subNames = append(subNames, subName)
+	}
+
+	slices.Sort(subNames)
+
+	for _, subName := range subNames {
+		types := typesBySubName[subName]
+		if len(types) < 2 || !slices.Contains(types, RRTypeCNAME) {
+			continue
+		}
+
+		others := slices.DeleteFunc(slices.Clone(types), func(t string) bool { return t == RRTypeCNAME })
+		slices.Sort(others)
+
+		report.add(LintCNAMECoexist, SeverityError, RRSet{SubName: subName, Type: RRTypeCNAME},
+			"CNAME cannot coexist with other types: %s", strings.Join(others, ", "))
+	}
+
+	return report
+}
+
+func lintRRSet(report *LintReport, rrSet RRSet, subName, recordType string) {
+	if IsManagedType(recordType) {
+		report.add(LintUnsupportedType, SeverityError, rrSet, "the type %s is managed by deSEC", recordType)
+	}
+
+	if recordType == RRTypeCNAME {
+		if subName == "" {
+			report.add(LintApexCNAME, SeverityError, rrSet, "CNAME is not allowed at the zone apex")
+		}
+
+		if len(rrSet.Records) > 1 {
+			report.add(LintCNAMECoexist, SeverityError, rrSet, "CNAME must have a single record, got %d", len(rrSet.Records))
+		}
+	}
+
+	lintWildcard(report, rrSet, subName, recordType)
+	lintLimits(report, rrSet, subName)
+}
+
+func lintWildcard(report *LintReport, rrSet RRSet, subName, recordType string) {
+	if !strings.Contains(subName, "*") {
+		return
+	}
+
+	for i, label := range strings.Split(subName, ".") {
+		if !strings.Contains(label, "*") {
+			continue
+		}
+
+		if label != "*" {
+			report.add(LintWildcard, SeverityError, rrSet, "the wildcard must be a whole label: %q", label)
+		} else if i > 0 {
+			report.add(LintWildcard, SeverityError, rrSet, "the wildcard must be the leftmost label: %q", subName)
+		}
+	}
+
+	if recordType == RRTypeNS {
+		report.add(LintWildcard, SeverityWarning, rrSet, "wildcard NS RRSets are not supported by resolvers (RFC 4592)")
+	}
+}
+
+func lintLimits(report *LintReport, rrSet RRSet, subName string) {
+	if rrSet.TTL > maxTTL {
+		report.add(LintLimits, SeverityError, rrSet, "the TTL %d is above the maximum %d", rrSet.TTL, maxTTL)
+	}
+
+	for _, label := range strings.Split(subName, ".") {
+		if len(label) > maxLabelLength {
+			report.add(LintLimits, SeverityError, rrSet, "the label %q is longer than %d characters", label, maxLabelLength)
+		}
+	}
+
+	if rrSet.Domain != "" {
+		name := strings.TrimSuffix(OwnerName(rrSet.Domain, subName), ".")
+		if len(name) > maxNameLength {
+			report.add(LintLimits, SeverityError, rrSet, "the name %q is longer than %d characters", name, maxNameLength)
+		}
+	}
+
+	var size int
+	for _, record := range rrSet.Records {
+		size += len(record)
+	}
+
+	if size > maxRRSetLength {
+		report.add(LintLimits, SeverityError, rrSet, "the records are longer than %d characters", maxRRSetLength)
+	}
+}
@@ -0,0 +1,102 @@
+package desec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rrSets   []RRSet
+		expected []LintFinding
+	}{
+		{
+			desc: "valid",
+			rrSets: []RRSet{
+				{SubName: "", Type: "A", TTL: 3600, Records: []string{"10.0.0.1"}},
+				{SubName: "www", Type: "CNAME", TTL: 3600, Records: []string{"example.com."}},
+				{SubName: "*", Type: "A", TTL: 3600, Records: []string{"10.0.0.1"}},
+				{SubName: "*.dev", Type: "TXT", TTL: 3600, Records: []string{`"dev"`}},
+				{SubName: "www", Type: "A", TTL: 3600, Records: []string{}},
+			},
+		},
+		{
+			desc: "duplicate",
+			rrSets: []RRSet{
+				{SubName: "www", Type: "A", Records: []string{"10.0.0.1"}},
+				{SubName: "WWW", Type: "a", Records: []string{"10.0.0.2"}},
+				{SubName: "@", Type: "A", Records: []string{"10.0.0.1"}},
+				{SubName: "", Type: "A", Records: []string{"10.0.0.2"}},
+			},
+			expected: []LintFinding{
+				{Check: LintDuplicate, Severity: SeverityError, SubName: "WWW", Type: "a", Message: "duplicated RRSet www/A"},
+				{Check: LintDuplicate, Severity: SeverityError, SubName: "", Type: "A", Message: "duplicated RRSet /A"},
+			},
+		},
+		{
+			desc: "CNAME",
+			rrSets: []RRSet{
+				{SubName: "", Type: "CNAME", Records: []string{"example.org."}},
+				{SubName: "www", Type: "CNAME", Records: []string{"a.example.org.", "b.example.org."}},
+				{SubName: "www", Type: "TXT", Records: []string{`"foo"`}},
+				{SubName: "www", Type: "A", Records: []string{"10.0.0.1"}},
+			},
+			expected: []LintFinding{
+				{Check: LintApexCNAME, Severity: SeverityError, SubName: "", Type: "CNAME", Message: "CNAME is not allowed at the zone apex"},
+				{Check: LintCNAMECoexist, Severity: SeverityError, SubName: "www", Type: "CNAME", Message: "CNAME must have a single record, got 2"},
+				{Check: LintCNAMECoexist, Severity: SeverityError, SubName: "www", Type: "CNAME", Message: "CNAME cannot coexist with other types: A, TXT"},
+			},
+		},
+		{
+			desc: "wildcard",
+			rrSets: []RRSet{
+				{SubName: "foo*", Type: "A", Records: []string{"10.0.0.1"}},
+				{SubName: "a.*", Type: "A", Records: []string{"10.0.0.1"}},
+				{SubName: "*", Type: "NS", Records: []string{"ns1.example.org."}},
+			},
+			expected: []LintFinding{
+				{Check: LintWildcard, Severity: SeverityError, SubName: "foo*", Type: "A", Message: `the wildcard must be a whole label: "foo*"`},
+				{Check: LintWildcard, Severity: SeverityError, SubName: "a.*", Type: "A", Message: `the wildcard must be the leftmost label: "a.*"`},
+				{Check: LintWildcard, Severity: SeverityWarning, SubName: "*", Type: "NS", Message: "wildcard NS RRSets are not supported by resolvers (RFC 4592)"},
+			},
+		},
+		{
+			desc: "limits",
+			rrSets: []RRSet{
+				{SubName: "a", Type: "A", TTL: 604801, Records: []string{"10.0.0.1"}},
+				{SubName: strings.Repeat("b", 64), Type: "A", Records: []string{"10.0.0.1"}},
+				{Domain: "example.com", SubName: strings.Repeat(strings.Repeat("c", 60)+".", 4) + "c", Type: "A", Records: []string{"10.0.0.1"}},
+				{SubName: "d", Type: "TXT", Records: []string{strings.Repeat("d", 64001)}},
+			},
+			expected: []LintFinding{
+				{Check: LintLimits, Severity: SeverityError, SubName: "a", Type: "A", Message: "the TTL 604801 is above the maximum 604800"},
+				{Check: LintLimits, Severity: SeverityError, SubName: strings.Repeat("b", 64), Type: "A", Message: `the label "` + strings.Repeat("b", 64) + `" is longer than 63 characters`},
+				{Check: LintLimits, Severity: SeverityError, SubName: strings.Repeat(strings.Repeat("c", 60)+".", 4) + "c", Type: "A", Message: `the name "` + strings.Repeat(strings.Repeat("c", 60)+".", 4) + `c.example.com" is longer than 253 characters`},
+				{Check: LintLimits, Severity: SeverityError, SubName: "d", Type: "TXT", Message: "the records are longer than 64000 characters"},
+			},
+		},
+		{
+			desc: "managed type",
+			rrSets: []RRSet{
+				{SubName: "", Type: "SOA", Records: []string{"ns1.desec.io. get.desec.io. 1 86400 3600 2419200 3600"}},
+			},
+			expected: []LintFinding{
+				{Check: LintUnsupportedType, Severity: SeverityError, SubName: "", Type: "SOA", Message: "the type SOA is managed by deSEC"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			report := Lint(test.rrSets)
+
+			assert.Equal(t, test.expected, report.Findings)
+			assert.Equal(t, len(test.expected) == 0, report.OK())
+		})
+	}
+}
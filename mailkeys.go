@@ -0,0 +1,158 @@
+package desec
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DANE certificate usages.
+// https://www.rfc-editor.org/rfc/rfc6698.html#section-2.1.1
+const (
+	DANEUsagePKIXTA = 0
+	DANEUsagePKIXEE = 1
+	DANEUsageDANETA = 2
+	DANEUsageDANEEE = 3
+)
+
+// DANE selectors.
+// https://www.rfc-editor.org/rfc/rfc6698.html#section-2.1.2
+const (
+	DANESelectorCert = 0
+	DANESelectorSPKI = 1
+)
+
+// DANE matching types.
+// https://www.rfc-editor.org/rfc/rfc6698.html#section-2.1.3
+const (
+	DANEMatchingFull   = 0
+	DANEMatchingSHA256 = 1
+	DANEMatchingSHA512 = 2
+)
+
+// OpenPGPKeyOwner returns the owner name of the OPENPGPKEY record of an email address.
+// https://www.rfc-editor.org/rfc/rfc7929.html#section-3
+func OpenPGPKeyOwner(email string) (string, error) {
+	return hashedEmailOwner(email, "_openpgpkey")
+}
+
+// SMIMEAOwner returns the owner name of the SMIMEA record of an email address.
+// https://www.rfc-editor.org/rfc/rfc8162.html#section-3
+func SMIMEAOwner(email string) (string, error) {
+	return hashedEmailOwner(email, "_smimecert")
+}
+
+// NewOpenPGPKeyRRSet creates the OPENPGPKEY RRSet of an email address.
+// The key is the binary (non-armored) OpenPGP transferable public key.
+func NewOpenPGPKeyRRSet(domainName, email string, ttl int, key []byte) (RRSet, error) {
+	if len(key) == 0 {
+		return RRSet{}, errors.New("missing key")
+	}
+
+	owner, err := OpenPGPKeyOwner(email)
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	subName, err := SubNameFromOwner(domainName, owner)
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	return RRSet{
+		Domain:  domainName,
+		SubName: subName,
+		Type:    "OPENPGPKEY",
+		Records: []string{base64.StdEncoding.EncodeToString(key)},
+		TTL:     ttl,
+	}, nil
+}
+
+// SMIMEARecord returns the content of a SMIMEA (or TLSA) record for a certificate.
+func SMIMEARecord(usage, selector, matchingType int, cert *x509.Certificate) (string, error) {
+	if cert == nil {
+		return "", errors.New("missing certificate")
+	}
+
+	if usage < DANEUsagePKIXTA || usage > DANEUsageDANEEE {
+		return "", fmt.Errorf("unsupported certificate usage: %d", usage)
+	}
+
+	var data []byte
+
+	switch selector {
+	case DANESelectorCert:
+		data = cert.Raw
+	case DANESelectorSPKI:
+		data = cert.RawSubjectPublicKeyInfo
+	default:
+		return "", fmt.Errorf("unsupported selector: %d", selector)
+	}
+
+	switch matchingType {
+	case DANEMatchingFull:
+	case DANEMatchingSHA256:
+		sum := sha256.Sum256(data)
+		data = sum[:]
+	case DANEMatchingSHA512:
+		sum := sha512.Sum512(data)
+		data = sum[:]
+	default:
+		return "", fmt.Errorf("unsupported matching type: %d", matchingType)
+	}
+
+	return fmt.Sprintf("%d %d %d %s", usage, selector, matchingType, hex.EncodeToString(data)), nil
+}
+
+// NewSMIMEARRSet creates the SMIMEA RRSet of an email address.
+func NewSMIMEARRSet(domainName, email string, ttl, usage, selector, matchingType int, certs ...*x509.Certificate) (RRSet, error) {
+	if len(certs) == 0 {
+		return RRSet{}, errors.New("no certificates")
+	}
+
+	owner, err := SMIMEAOwner(email)
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	subName, err := SubNameFromOwner(domainName, owner)
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	records := make([]string, 0, len(certs))
+
+	for _, cert := range certs {
+		record, err := SMIMEARecord(usage, selector, matchingType, cert)
+		if err != nil {
+			return RRSet{}, err
+		}
+
+		records = append(records, record)
+	}
+
+	return RRSet{
+		Domain:  domainName,
+		SubName: subName,
+		Type:    "SMIMEA",
+		Records: records,
+		TTL:     ttl,
+	}, nil
+}
+
+// hashedEmailOwner returns `<sha256(local-part) truncated to 28 octets>.<label>.<domain>.`.
+func hashedEmailOwner(email, label string) (string, error) {
+	i := strings.LastIndex(email, "@")
+	if i <= 0 || i == len(email)-1 {
+		return "", fmt.Errorf("invalid email address: %q", email)
+	}
+
+	sum := sha256.Sum256([]byte(email[:i]))
+
+	return OwnerName(email[i+1:], hex.EncodeToString(sum[:28])+"."+label), nil
+}
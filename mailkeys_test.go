@@ -0,0 +1,103 @@
+package desec
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenPGPKeyOwner(t *testing.T) {
+	// https://www.rfc-editor.org/rfc/rfc7929.html#section-3
+	owner, err := OpenPGPKeyOwner("hugh@example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, "c93f1e400f26708f98cb19d936620da35eec8f72e57f9eec01c1afd6._openpgpkey.example.com.", owner)
+}
+
+func TestOpenPGPKeyOwner_invalid(t *testing.T) {
+	for _, email := range []string{"", "hugh", "@example.com", "hugh@"} {
+		_, err := OpenPGPKeyOwner(email)
+		require.Error(t, err, email)
+	}
+}
+
+func TestNewOpenPGPKeyRRSet(t *testing.T) {
+	rrSet, err := NewOpenPGPKeyRRSet("example.com", "hugh@example.com", 3600, []byte("key"))
+	require.NoError(t, err)
+
+	expected := RRSet{
+		Domain:  "example.com",
+		SubName: "c93f1e400f26708f98cb19d936620da35eec8f72e57f9eec01c1afd6._openpgpkey",
+		Type:    "OPENPGPKEY",
+		Records: []string{"a2V5"},
+		TTL:     3600,
+	}
+	assert.Equal(t, expected, rrSet)
+}
+
+func TestNewSMIMEARRSet(t *testing.T) {
+	cert := generateCertificate(t)
+
+	rrSet, err := NewSMIMEARRSet("example.com", "hugh@mail.example.com", 3600, DANEUsageDANEEE, DANESelectorSPKI, DANEMatchingSHA256, cert)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+
+	expected := RRSet{
+		Domain:  "example.com",
+		SubName: "c93f1e400f26708f98cb19d936620da35eec8f72e57f9eec01c1afd6._smimecert.mail",
+		Type:    "SMIMEA",
+		Records: []string{"3 1 1 " + hex.EncodeToString(sum[:])},
+		TTL:     3600,
+	}
+	assert.Equal(t, expected, rrSet)
+}
+
+func TestSMIMEARecord_errors(t *testing.T) {
+	cert := generateCertificate(t)
+
+	_, err := SMIMEARecord(4, DANESelectorCert, DANEMatchingFull, cert)
+	require.Error(t, err)
+
+	_, err = SMIMEARecord(DANEUsageDANEEE, 2, DANEMatchingFull, cert)
+	require.Error(t, err)
+
+	_, err = SMIMEARecord(DANEUsageDANEEE, DANESelectorCert, 3, cert)
+	require.Error(t, err)
+
+	_, err = SMIMEARecord(DANEUsageDANEEE, DANESelectorCert, DANEMatchingFull, nil)
+	require.Error(t, err)
+}
+
+func generateCertificate(t *testing.T) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		Subject:        pkix.Name{CommonName: "hugh"},
+		EmailAddresses: []string{"hugh@example.com"},
+		NotBefore:      time.Now(),
+		NotAfter:       time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
@@ -0,0 +1,148 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Manager holds the Clients of several deSEC accounts, keyed by label.
+// The Clients share the same HTTP transport and the same rate limiter.
+type Manager struct {
+	opts ClientOptions
+
+	mu      sync.RWMutex
+	clients map[string]*Client
+}
+
+// NewManager creates a new Manager.
+// The Clients are created with opts, the limiter (optional) is shared by all the API calls of all the accounts.
+func NewManager(opts ClientOptions, limiter RateLimiter) *Manager {
+	if limiter != nil {
+		opts.RateLimiter = limiter
+	}
+
+	return &Manager{
+		opts:    opts,
+		clients: map[string]*Client{},
+	}
+}
+
+// Add creates the Client of an account, it replaces the existing Client with the same label.
+func (m *Manager) Add(label, token string) *Client {
+	client := New(token, m.opts)
+
+	m.mu.Lock()
+	m.clients[label] = client
+	m.mu.Unlock()
+
+	return client
+}
+
+// Remove removes the Client of an account.
+func (m *Manager) Remove(label string) {
+	m.mu.Lock()
+	delete(m.clients, label)
+	m.mu.Unlock()
+}
+
+// Client returns the Client of an account.
+func (m *Manager) Client(label string) (*Client, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	client, ok := m.clients[label]
+
+	return client, ok
+}
+
+// Labels returns the sorted labels of the accounts.
+func (m *Manager) Labels() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	labels := make([]string, 0, len(m.clients))
+	for label := range m.clients {
+		labels = append(labels, label)
+	}
+
+	slices.Sort(labels)
+
+	return labels
+}
+
+// FindOwner returns the label of the account owning the domain responsible for a DNS name, and this domain.
+// Returns a NotFoundError if no account owns a responsible domain.
+// If several accounts own a responsible domain, the most specific domain wins, then the first label.
+func (m *Manager) FindOwner(ctx context.Context, name string) (string, *Domain, error) {
+	labels := m.Labels()
+
+	domains := make([]*Domain, len(labels))
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(defaultConcurrency)
+
+	for i, label := range labels {
+		client, ok := m.Client(label)
+		if !ok {
+			continue
+		}
+
+		group.Go(func() error {
+			domain, err := client.Domains.GetResponsible(ctx, name)
+			if err != nil {
+				if errors.As(err, new(*NotFoundError)) {
+					return nil
+				}
+
+				return fmt.Errorf("%s: %w", label, err)
+			}
+
+			domains[i] = domain
+
+			return nil
+		})
+	}
+
+	err := group.Wait()
+	if err != nil {
+		return "", nil, err
+	}
+
+	owner := -1
+
+	for i, domain := range domains {
+		if domain == nil {
+			continue
+		}
+
+		if owner < 0 || len(domain.Name) > len(domains[owner].Name) {
+			owner = i
+		}
+	}
+
+	if owner < 0 {
+		return "", nil, &NotFoundError{Detail: fmt.Sprintf("no account owns a domain responsible for %s", name)}
+	}
+
+	return labels[owner], domains[owner], nil
+}
+
+// ClientFor returns the Client of the account owning the domain responsible for a DNS name (FindOwner).
+func (m *Manager) ClientFor(ctx context.Context, name string) (*Client, error) {
+	label, _, err := m.FindOwner(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	client, ok := m.Client(label)
+	if !ok {
+		return nil, &NotFoundError{Detail: fmt.Sprintf("unknown account %s", label)}
+	}
+
+	return client, nil
+}
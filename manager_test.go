@@ -0,0 +1,77 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_FindOwner(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("GET /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "www.sub.example.com", req.URL.Query().Get("owns_qname"))
+
+		switch req.Header.Get("Authorization") {
+		case "Token tokenA":
+			_, _ = rw.Write([]byte(`[{"name":"example.com"}]`))
+		case "Token tokenB":
+			_, _ = rw.Write([]byte(`[{"name":"sub.example.com"}]`))
+		default:
+			_, _ = rw.Write([]byte(`[]`))
+		}
+	})
+
+	limiter := &countingLimiter{}
+
+	manager := NewManager(NewDefaultClientOptions(), limiter)
+
+	for label, token := range map[string]string{"a": "tokenA", "b": "tokenB", "c": "tokenC"} {
+		client := manager.Add(label, token)
+		client.BaseURL = server.URL
+	}
+
+	assert.Equal(t, []string{"a", "b", "c"}, manager.Labels())
+
+	label, domain, err := manager.FindOwner(context.Background(), "www.sub.example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, "b", label)
+	assert.Equal(t, "sub.example.com", domain.Name)
+	assert.EqualValues(t, 3, limiter.calls.Load())
+
+	client, err := manager.ClientFor(context.Background(), "www.sub.example.com")
+	require.NoError(t, err)
+
+	expected, ok := manager.Client("b")
+	require.True(t, ok)
+
+	assert.Same(t, expected, client)
+}
+
+func TestManager_FindOwner_notFound(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("GET /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	manager := NewManager(NewDefaultClientOptions(), nil)
+
+	client := manager.Add("a", "tokenA")
+	client.BaseURL = server.URL
+
+	manager.Add("b", "tokenB")
+	manager.Remove("b")
+
+	_, _, err := manager.FindOwner(context.Background(), "www.example.com")
+	require.ErrorAs(t, err, new(*NotFoundError))
+}
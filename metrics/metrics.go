@@ -0,0 +1,119 @@
+// Package metrics exports the activity of a deSEC client as Prometheus metrics.
+//
+//	collector := metrics.New("")
+//	prometheus.MustRegister(collector)
+//
+//	opts := desec.NewDefaultClientOptions()
+//	opts.Hooks = collector.Hooks()
+//
+//	client := desec.New("token", opts)
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nrdcg/desec"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defaultNamespace = "desec"
+	subsystem        = "client"
+)
+
+// Collector collects the metrics of a deSEC client through its lifecycle hooks.
+type Collector struct {
+	requests    *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+	retries     *prometheus.CounterVec
+	rateLimited prometheus.Counter
+	inFlight    prometheus.Gauge
+}
+
+// New creates a new Collector.
+// The default namespace is "desec".
+func New(namespace string) *Collector {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "requests_total",
+			Help:      "Total number of API calls, by method and status code (\"error\" when no response).",
+		}, []string{"method", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the API calls (retries included), by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "retries_total",
+			Help:      "Total number of retries, by method.",
+		}, []string{"method"}),
+		rateLimited: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "rate_limited_total",
+			Help:      "Total number of responses with the status 429 (Too Many Requests).",
+		}),
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "in_flight_requests",
+			Help:      "Number of API calls in progress.",
+		}),
+	}
+}
+
+// Hooks returns the client hooks feeding the collector.
+func (c *Collector) Hooks() desec.Hooks {
+	return desec.Hooks{
+		OnRequest: func(_ *http.Request) {
+			c.inFlight.Inc()
+		},
+		OnResponse: func(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+			c.inFlight.Dec()
+
+			code := "error"
+			if err == nil && resp != nil {
+				code = strconv.Itoa(resp.StatusCode)
+			}
+
+			c.requests.WithLabelValues(req.Method, code).Inc()
+			c.duration.WithLabelValues(req.Method).Observe(duration.Seconds())
+		},
+		OnRetry: func(req *http.Request, _ int) {
+			c.retries.WithLabelValues(req.Method).Inc()
+		},
+		OnRateLimited: func(_ *http.Response) {
+			c.rateLimited.Inc()
+		},
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.duration.Describe(ch)
+	c.retries.Describe(ch)
+	c.rateLimited.Describe(ch)
+	c.inFlight.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.duration.Collect(ch)
+	c.retries.Collect(ch)
+	c.rateLimited.Collect(ch)
+	c.inFlight.Collect(ch)
+}
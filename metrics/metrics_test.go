@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nrdcg/desec"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	collector := New("")
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	opts := desec.NewDefaultClientOptions()
+	opts.Hooks = collector.Hooks()
+
+	client := desec.New("token", opts)
+	client.BaseURL = server.URL
+
+	var calls int
+
+	mux.HandleFunc("/domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		if calls == 1 {
+			rw.Header().Set("Retry-After", "0")
+			http.Error(rw, `{"detail":"Request was throttled."}`, http.StatusTooManyRequests)
+
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{"name":"example.com"}`))
+	})
+
+	_, err := client.Domains.Get(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	expected := `
+# HELP desec_client_in_flight_requests Number of API calls in progress.
+# TYPE desec_client_in_flight_requests gauge
+desec_client_in_flight_requests 0
+# HELP desec_client_rate_limited_total Total number of responses with the status 429 (Too Many Requests).
+# TYPE desec_client_rate_limited_total counter
+desec_client_rate_limited_total 1
+# HELP desec_client_requests_total Total number of API calls, by method and status code ("error" when no response).
+# TYPE desec_client_requests_total counter
+desec_client_requests_total{code="200",method="GET"} 1
+# HELP desec_client_retries_total Total number of retries, by method.
+# TYPE desec_client_retries_total counter
+desec_client_retries_total{method="GET"} 1
+`
+
+	err = testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"desec_client_in_flight_requests", "desec_client_rate_limited_total", "desec_client_requests_total", "desec_client_retries_total")
+	require.NoError(t, err)
+
+	require.Equal(t, 1, testutil.CollectAndCount(collector, "desec_client_request_duration_seconds"))
+}
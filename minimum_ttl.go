@@ -0,0 +1,114 @@
+package desec
+
+import (
+	"context"
+	"time"
+)
+
+// minimumTTLCacheTTL the duration of the cache of the minimum TTLs of the domains.
+const minimumTTLCacheTTL = 5 * time.Minute
+
+// MinimumTTLMode defines how the TTLs lower than the minimum TTL of the domain (Domain.MinimumTTL) are handled.
+type MinimumTTLMode int
+
+const (
+	// MinimumTTLDefault the TTLs are sent as is: the API rejects the TTLs lower than the minimum (400).
+	MinimumTTLDefault MinimumTTLMode = iota
+	// MinimumTTLClamp the TTLs lower than the minimum are raised to the minimum.
+	MinimumTTLClamp
+	// MinimumTTLReject the TTLs lower than the minimum are rejected (TTLTooLowError) before calling the API.
+	MinimumTTLReject
+)
+
+type minimumTTLModeKey struct{}
+
+// WithMinimumTTLMode returns a context that overrides the MinimumTTLMode of the client (ClientOptions.MinimumTTL).
+//
+//	rrSet, err := client.Records.Create(desec.WithMinimumTTLMode(ctx, desec.MinimumTTLClamp), rrSet)
+func WithMinimumTTLMode(ctx context.Context, mode MinimumTTLMode) context.Context {
+	return context.WithValue(ctx, minimumTTLModeKey{}, mode)
+}
+
+// applyMinimumTTL clamps or rejects the TTL of the RRSet, according to the MinimumTTLMode.
+// The minimum TTLs of the domains are cached in memory.
+func (c *Client) applyMinimumTTL(ctx context.Context, domainName string, rrSet *RRSet) error {
+	mode := c.minimumTTLMode
+	if m, ok := ctx.Value(minimumTTLModeKey{}).(MinimumTTLMode); ok {
+		mode = m
+	}
+
+	// Without records, the RRSet is deleted and the TTL is not relevant.
+	if mode == MinimumTTLDefault || rrSet.TTL == 0 || len(rrSet.Records) == 0 {
+		return nil
+	}
+
+	minimumTTL, err := c.domainMinimumTTL(ctx, domainName)
+	if err != nil {
+		return err
+	}
+
+	if rrSet.TTL >= minimumTTL {
+		return nil
+	}
+
+	if mode == MinimumTTLReject {
+		return &TTLTooLowError{
+			Domain:     domainName,
+			SubName:    rrSet.SubName,
+			Type:       rrSet.Type,
+			TTL:        rrSet.TTL,
+			MinimumTTL: minimumTTL,
+		}
+	}
+
+	rrSet.TTL = minimumTTL
+
+	return nil
+}
+
+// domainMinimumTTL returns the minimum TTL of the domain, cached in memory per token.
+func (c *Client) domainMinimumTTL(ctx context.Context, domainName string) (int, error) {
+	scope, err := c.tokenScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	key := scope + "/" + domainName
+
+	if minimumTTL, ok := c.minimumTTLs.get(key); ok {
+		return minimumTTL, nil
+	}
+
+	domain, err := c.Domains.Get(ctx, domainName)
+	if err != nil {
+		return 0, err
+	}
+
+	c.minimumTTLs.set(key, domain.MinimumTTL)
+
+	return domain.MinimumTTL, nil
+}
+
+// applyTTL prepares the TTL of a RRSet before it is written, streamed or not:
+// the default TTL (if withDefault) then the minimum TTL.
+func (c *Client) applyTTL(ctx context.Context, domainName string, withDefault bool, rrSet *RRSet) error {
+	if withDefault {
+		err := c.applyDefaultTTL(ctx, domainName, rrSet)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.applyMinimumTTL(ctx, domainName, rrSet)
+}
+
+func (c *Client) applyTTLs(ctx context.Context, domainName string, withDefault bool, rrSets []RRSet) error {
+	for i := range rrSets {
+		err := c.applyTTL(ctx, domainName, withDefault, &rrSets[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
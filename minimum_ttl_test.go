@@ -0,0 +1,134 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMinimumTTL(t *testing.T, mode MinimumTTLMode) (*Client, *[]RRSet, *int) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	opts := NewDefaultClientOptions()
+	opts.MinimumTTL = mode
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	var domainCalls int
+
+	mux.HandleFunc("/domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		domainCalls++
+
+		_, _ = rw.Write([]byte(`{"name":"example.com","minimum_ttl":3600}`))
+	})
+
+	var received []RRSet
+
+	mux.HandleFunc("/domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(&received)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if req.Method == http.MethodPost {
+			rw.WriteHeader(http.StatusCreated)
+		}
+
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	return client, &received, &domainCalls
+}
+
+func TestClient_minimumTTL_clamp(t *testing.T) {
+	client, received, domainCalls := setupMinimumTTL(t, MinimumTTLClamp)
+
+	_, err := client.Records.BulkCreate(context.Background(), "example.com", []RRSet{
+		{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}, TTL: 60},
+		{SubName: "b", Type: "A", Records: []string{"10.0.0.2"}, TTL: 7200},
+		{SubName: "c", Type: "A", Records: []string{"10.0.0.3"}},
+	})
+	require.NoError(t, err)
+
+	expected := []RRSet{
+		{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}, TTL: 3600},
+		{SubName: "b", Type: "A", Records: []string{"10.0.0.2"}, TTL: 7200},
+		{SubName: "c", Type: "A", Records: []string{"10.0.0.3"}},
+	}
+	assert.Equal(t, expected, *received)
+
+	_, err = client.Records.BulkCreate(context.Background(), "example.com", []RRSet{
+		{SubName: "d", Type: "A", Records: []string{"10.0.0.4"}, TTL: 60},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, *domainCalls)
+}
+
+func TestClient_minimumTTL_reject(t *testing.T) {
+	client, received, _ := setupMinimumTTL(t, MinimumTTLReject)
+
+	_, err := client.Records.BulkCreate(context.Background(), "example.com", []RRSet{
+		{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}, TTL: 60},
+	})
+
+	var ttlErr *TTLTooLowError
+	require.ErrorAs(t, err, &ttlErr)
+
+	expected := &TTLTooLowError{Domain: "example.com", SubName: "a", Type: "A", TTL: 60, MinimumTTL: 3600}
+	assert.Equal(t, expected, ttlErr)
+
+	assert.Empty(t, *received)
+}
+
+func TestClient_minimumTTL_context(t *testing.T) {
+	client, received, domainCalls := setupMinimumTTL(t, MinimumTTLReject)
+
+	ctx := WithMinimumTTLMode(context.Background(), MinimumTTLDefault)
+
+	_, err := client.Records.BulkCreate(ctx, "example.com", []RRSet{
+		{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}, TTL: 60},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 60, (*received)[0].TTL)
+	assert.Zero(t, *domainCalls)
+}
+
+func TestClient_minimumTTL_stream(t *testing.T) {
+	client, received, _ := setupMinimumTTL(t, MinimumTTLClamp)
+
+	r := strings.NewReader(`[{"subname":"a","type":"A","ttl":60,"records":["10.0.0.1"]},{"subname":"b","type":"A","ttl":7200,"records":["10.0.0.2"]}]`)
+
+	_, err := client.Records.BulkCreateFromReader(context.Background(), "example.com", r)
+	require.NoError(t, err)
+
+	expected := []RRSet{
+		{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}, TTL: 3600},
+		{SubName: "b", Type: "A", Records: []string{"10.0.0.2"}, TTL: 7200},
+	}
+	assert.Equal(t, expected, *received)
+
+	client.minimumTTLMode = MinimumTTLReject
+
+	seq := func(yield func(RRSet) bool) {
+		yield(RRSet{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}, TTL: 60})
+	}
+
+	_, err = client.Records.BulkUpdateStream(context.Background(), OnlyFields, "example.com", seq)
+
+	var ttlErr *TTLTooLowError
+	require.ErrorAs(t, err, &ttlErr)
+}
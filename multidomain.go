@@ -0,0 +1,155 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultConcurrency the default number of domains processed concurrently by ApplyAcrossDomains.
+const defaultConcurrency = 4
+
+// RateLimiter limits the rate of the API calls.
+// It is implemented by golang.org/x/time/rate.Limiter.
+type RateLimiter interface {
+	// Wait blocks until an API call is allowed, or the context is done.
+	Wait(ctx context.Context) error
+}
+
+// ApplyAcrossDomainsOptions the options of ApplyAcrossDomains.
+type ApplyAcrossDomainsOptions struct {
+	// Concurrency the maximum number of domains processed concurrently (default: 4).
+	Concurrency int
+
+	// RateLimiter limits the rate of the API calls shared by all the domains (optional).
+	RateLimiter RateLimiter
+}
+
+// DomainApplyResult the result of the changes applied to a domain by ApplyAcrossDomains.
+type DomainApplyResult struct {
+	Domain  string
+	Changes []RRSetChange
+	Err     error
+}
+
+// ApplyAcrossDomainsReport the aggregated results of ApplyAcrossDomains, sorted by domain.
+type ApplyAcrossDomainsReport struct {
+	Results []DomainApplyResult
+}
+
+// OK returns true if the changes have been applied to all the domains.
+func (r *ApplyAcrossDomainsReport) OK() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the results of the domains where the changes have not been applied.
+func (r *ApplyAcrossDomainsReport) Failed() []DomainApplyResult {
+	var failed []DomainApplyResult
+
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+
+	return failed
+}
+
+// ApplyAcrossDomains applies changes to several domains, ex: to retarget an IP address on a fleet of domains.
+// The changes of a domain are applied with a single bulk request, the deSEC API applies them atomically.
+// The domains are processed concurrently, an error on a domain doesn't stop the processing of the others.
+func (s *RecordsService) ApplyAcrossDomains(ctx context.Context, changesByDomain map[string][]RRSetChange, opts *ApplyAcrossDomainsOptions) *ApplyAcrossDomainsReport {
+	if opts == nil {
+		opts = &ApplyAcrossDomainsOptions{}
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	domainNames := make([]string, 0, len(changesByDomain))
+	for domainName := range changesByDomain {
+		domainNames = append(domainNames, domainName)
+	}
+
+	slices.Sort(domainNames)
+
+	report := &ApplyAcrossDomainsReport{Results: make([]DomainApplyResult, len(domainNames))}
+
+	group := new(errgroup.Group)
+	group.SetLimit(concurrency)
+
+	for i, domainName := range domainNames {
+		result := &report.Results[i]
+		result.Domain = domainName
+		result.Changes = changesByDomain[domainName]
+
+		group.Go(func() error {
+			result.Err = s.applyDomainChanges(ctx, domainName, result.Changes, opts.RateLimiter)
+			return nil
+		})
+	}
+
+	_ = group.Wait()
+
+	return report
+}
+
+func (s *RecordsService) applyDomainChanges(ctx context.Context, domainName string, changes []RRSetChange, limiter RateLimiter) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if limiter != nil {
+		err := limiter.Wait(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := s.BulkUpdate(ctx, FullResource, domainName, changesToRRSets(changes))
+
+	return err
+}
+
+// GetAllDomains retrieves the RRSets of several domains concurrently (default concurrency: 4).
+// The large zones are retrieved with the pagination.
+// The first error cancels the retrieval of the other domains.
+func (s *RecordsService) GetAllDomains(ctx context.Context, domainNames []string, concurrency int) (map[string][]RRSet, error) {
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(concurrency)
+
+	var mu sync.Mutex
+
+	rrSetsByDomain := make(map[string][]RRSet, len(domainNames))
+
+	for _, domainName := range domainNames {
+		group.Go(func() error {
+			rrSets, err := s.GetAll(ctx, domainName, nil)
+			if err != nil {
+				return fmt.Errorf("%s: %w", domainName, err)
+			}
+
+			mu.Lock()
+			rrSetsByDomain[domainName] = rrSets
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	err := group.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	return rrSetsByDomain, nil
+}
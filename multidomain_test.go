@@ -0,0 +1,158 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingLimiter struct {
+	calls atomic.Int32
+}
+
+func (l *countingLimiter) Wait(_ context.Context) error {
+	l.calls.Add(1)
+	return nil
+}
+
+func TestRecordsService_ApplyAcrossDomains(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var mu sync.Mutex
+
+	received := map[string][]RRSet{}
+
+	mux.HandleFunc("/domains/{domain}/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		domainName := req.PathValue("domain")
+
+		if domainName == "example.org" {
+			rw.WriteHeader(http.StatusBadRequest)
+			_, _ = rw.Write([]byte(`[{"records":["invalid"]}]`))
+
+			return
+		}
+
+		var rrSets []RRSet
+
+		err := json.NewDecoder(req.Body).Decode(&rrSets)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		received[domainName] = rrSets
+		mu.Unlock()
+
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	changesByDomain := map[string][]RRSetChange{
+		"example.net": {
+			{Action: ChangeUpdate, RRSet: RRSet{SubName: "www", Type: "A", TTL: 3600, Records: []string{"10.0.0.2"}}},
+		},
+		"example.com": {
+			{Action: ChangeUpdate, RRSet: RRSet{SubName: "", Type: "A", TTL: 3600, Records: []string{"10.0.0.2"}}},
+			{Action: ChangeDelete, RRSet: RRSet{SubName: "old", Type: "A", TTL: 3600, Records: []string{"10.0.0.1"}}},
+		},
+		"example.org": {
+			{Action: ChangeUpdate, RRSet: RRSet{SubName: "", Type: "A", TTL: 3600, Records: []string{"10.0.0.2"}}},
+		},
+		"example.io": nil,
+	}
+
+	limiter := &countingLimiter{}
+
+	report := client.Records.ApplyAcrossDomains(context.Background(), changesByDomain, &ApplyAcrossDomainsOptions{
+		Concurrency: 2,
+		RateLimiter: limiter,
+	})
+
+	require.Len(t, report.Results, 4)
+
+	var domainNames []string
+	for _, result := range report.Results {
+		domainNames = append(domainNames, result.Domain)
+	}
+
+	assert.Equal(t, []string{"example.com", "example.io", "example.net", "example.org"}, domainNames)
+
+	assert.False(t, report.OK())
+
+	failed := report.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "example.org", failed[0].Domain)
+	require.Error(t, failed[0].Err)
+
+	assert.EqualValues(t, 3, limiter.calls.Load())
+
+	expected := map[string][]RRSet{
+		"example.com": {
+			{Type: "A", TTL: 3600, Records: []string{"10.0.0.2"}},
+			{SubName: "old", Type: "A", TTL: 3600, Records: []string{}},
+		},
+		"example.net": {
+			{SubName: "www", Type: "A", TTL: 3600, Records: []string{"10.0.0.2"}},
+		},
+	}
+	assert.Equal(t, expected, received)
+}
+
+func TestRecordsService_GetAllDomains(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/{domain}/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		domainName := req.PathValue("domain")
+
+		switch domainName {
+		case "missing.com":
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"detail":"Not found."}`))
+
+		case "large.com":
+			if !req.URL.Query().Has("cursor") {
+				rw.Header().Set("Link", `<`+server.URL+`/domains/large.com/rrsets/?cursor=>; rel="first"`)
+				rw.WriteHeader(http.StatusBadRequest)
+				_, _ = rw.Write([]byte(`{"detail":"Pagination required."}`))
+
+				return
+			}
+
+			_, _ = rw.Write([]byte(`[{"domain":"large.com","subname":"a","type":"A","records":["10.0.0.1"]}]`))
+
+		default:
+			_, _ = rw.Write([]byte(`[{"domain":"` + domainName + `","subname":"","type":"A","records":["10.0.0.1"]}]`))
+		}
+	})
+
+	rrSets, err := client.Records.GetAllDomains(context.Background(), []string{"example.com", "example.org", "large.com"}, 2)
+	require.NoError(t, err)
+
+	expected := map[string][]RRSet{
+		"example.com": {{Domain: "example.com", Type: "A", Records: []string{"10.0.0.1"}}},
+		"example.org": {{Domain: "example.org", Type: "A", Records: []string{"10.0.0.1"}}},
+		"large.com":   {{Domain: "large.com", SubName: "a", Type: "A", Records: []string{"10.0.0.1"}}},
+	}
+	assert.Equal(t, expected, rrSets)
+
+	_, err = client.Records.GetAllDomains(context.Background(), []string{"example.com", "missing.com"}, 0)
+	require.ErrorAs(t, err, new(*NotFoundError))
+	require.ErrorContains(t, err, "missing.com: 404: Not found.")
+}
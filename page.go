@@ -0,0 +1,97 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Page a page of a list endpoint.
+// The next page is retrieved with Cursors.Next, it is empty on the last page.
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#pagination
+type Page[T any] struct {
+	Items   []T
+	Cursors Cursors
+}
+
+// HasNext returns true if there is a next page.
+func (p *Page[T]) HasNext() bool {
+	return p.Cursors.Next != ""
+}
+
+func newPage[T any](items []T, cursors *Cursors) *Page[T] {
+	page := &Page[T]{Items: items}
+
+	if cursors != nil {
+		page.Cursors = *cursors
+	}
+
+	return page
+}
+
+// ListPage retrieves a page of the RRSets of a zone.
+// The empty cursor is the first page.
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#retrieving-all-rrsets-in-a-zone
+func (s *RecordsService) ListPage(ctx context.Context, domainName string, filter RRSetSelector, cursor string) (*Page[RRSet], error) {
+	rrSets, cursors, err := s.GetAllPaginated(ctx, domainName, filter, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(rrSets, cursors), nil
+}
+
+// ListPage retrieves a page of the domains matching the options.
+// The empty cursor is the first page.
+// https://desec.readthedocs.io/en/latest/dns/domains.html#listing-domains
+func (s *DomainsService) ListPage(ctx context.Context, opts *DomainListOptions, cursor string) (*Page[Domain], error) {
+	query, err := opts.query()
+	if err != nil {
+		return nil, err
+	}
+
+	query.Set("cursor", cursor)
+
+	domains, cursors, err := s.getAll(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(domains, cursors), nil
+}
+
+// ListPage retrieves a page of the tokens.
+// The empty cursor is the first page.
+// https://desec.readthedocs.io/en/latest/auth/tokens.html#retrieving-all-current-tokens
+func (s *TokensService) ListPage(ctx context.Context, cursor string) (*Page[Token], error) {
+	endpoint, err := s.client.createEndpoint("auth", "tokens")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+	}
+
+	return getPage[Token](ctx, s.client, endpoint, cursor)
+}
+
+// ListPage retrieves a page of the RRSet policies of a token.
+// The empty cursor is the first page.
+// https://desec.readthedocs.io/en/latest/auth/tokens.html#token-policy-management
+func (s *TokenPoliciesService) ListPage(ctx context.Context, tokenID, cursor string) (*Page[TokenPolicy], error) {
+	endpoint, err := s.client.createEndpoint("auth", "tokens", tokenID, "policies", "rrsets")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+	}
+
+	return getPage[TokenPolicy](ctx, s.client, endpoint, cursor)
+}
+
+func getPage[T any](ctx context.Context, client *Client, endpoint *url.URL, cursor string) (*Page[T], error) {
+	endpoint.RawQuery = url.Values{"cursor": []string{cursor}}.Encode()
+
+	items, resp, err := do[[]T](ctx, client, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(items, resp.Cursors), nil
+}
@@ -0,0 +1,90 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsService_ListPage(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Query().Get("cursor") {
+		case "":
+			rw.Header().Set("Link", `<`+server.URL+`/domains/example.com/rrsets/?cursor=>; rel="first", <`+server.URL+`/domains/example.com/rrsets/?cursor=next>; rel="next"`)
+			_, _ = rw.Write([]byte(`[{"subname":"a","type":"A","records":["10.0.0.1"]}]`))
+		case "next":
+			rw.Header().Set("Link", `<`+server.URL+`/domains/example.com/rrsets/?cursor=>; rel="first", <`+server.URL+`/domains/example.com/rrsets/?cursor=>; rel="prev"`)
+			_, _ = rw.Write([]byte(`[{"subname":"b","type":"A","records":["10.0.0.2"]}]`))
+		}
+	})
+
+	page, err := client.Records.ListPage(context.Background(), "example.com", nil, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []RRSet{{SubName: "a", Type: "A", Records: []string{"10.0.0.1"}}}, page.Items)
+	assert.True(t, page.HasNext())
+
+	page, err = client.Records.ListPage(context.Background(), "example.com", nil, page.Cursors.Next)
+	require.NoError(t, err)
+
+	assert.Equal(t, []RRSet{{SubName: "b", Type: "A", Records: []string{"10.0.0.2"}}}, page.Items)
+	assert.False(t, page.HasNext())
+}
+
+func TestDomainsService_ListPage(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "www.example.com", req.URL.Query().Get("owns_qname"))
+
+		_, _ = rw.Write([]byte(`[{"name":"example.com"}]`))
+	})
+
+	page, err := client.Domains.ListPage(context.Background(), &DomainListOptions{OwnsQName: "www.example.com"}, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, &Page[Domain]{Items: []Domain{{Name: "example.com"}}}, page)
+}
+
+func TestTokensService_ListPage(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /auth/tokens/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"id":"1","name":"ci"}]`))
+	})
+
+	mux.HandleFunc("GET /auth/tokens/1/policies/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"id":"p1","domain":null,"subname":null,"type":null}]`))
+	})
+
+	tokens, err := client.Tokens.ListPage(context.Background(), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, &Page[Token]{Items: []Token{{ID: "1", Name: "ci"}}}, tokens)
+
+	policies, err := client.TokenPolicies.ListPage(context.Background(), "1", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, &Page[TokenPolicy]{Items: []TokenPolicy{{ID: "p1"}}}, policies)
+}
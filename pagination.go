@@ -1,12 +1,23 @@
 package desec
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/peterhellberg/link"
 )
 
+// PageSize the default number of items of a page.
+// A smaller page size can be requested (RRSetQuery.PageSize, DomainListOptions.PageSize),
+// the pages are delimited by the cursors returned by the API in any case.
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#pagination
+const PageSize = 500
+
+// pageSizeParam the query parameter of the page size.
+const pageSizeParam = "page_size"
+
 // Cursors allows to retrieve the next (or previous) page.
 // https://desec.readthedocs.io/en/latest/dns/rrsets.html#pagination
 type Cursors struct {
@@ -15,6 +26,55 @@ type Cursors struct {
 	Next  string
 }
 
+// collectPages retrieves the items of all the pages, starting at the cursor.
+// When a page is larger than the maximum response size (ResponseTooLargeError),
+// the page size is halved and the page is retrieved again.
+func collectPages[T any](query url.Values, cursor string, get func(query url.Values) ([]T, *Cursors, error)) ([]T, error) {
+	var items []T
+
+	for {
+		query.Set("cursor", cursor)
+
+		page, cursors, err := get(query)
+		if err != nil {
+			if shrinkPageSize(query, err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		items = append(items, page...)
+
+		if cursors == nil || cursors.Next == "" {
+			return items, nil
+		}
+
+		cursor = cursors.Next
+	}
+}
+
+// shrinkPageSize halves the page size of the query after a response too large.
+// Returns false for the other errors, or if the page size can't be reduced.
+func shrinkPageSize(query url.Values, err error) bool {
+	if !errors.As(err, new(*ResponseTooLargeError)) {
+		return false
+	}
+
+	size := PageSize
+	if value, errA := strconv.Atoi(query.Get(pageSizeParam)); errA == nil && value > 0 {
+		size = value
+	}
+
+	if size <= 1 {
+		return false
+	}
+
+	query.Set(pageSizeParam, strconv.Itoa(size/2))
+
+	return true
+}
+
 func parseCursor(h http.Header) (*Cursors, error) {
 	links := link.ParseHeader(h)
 
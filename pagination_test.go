@@ -1,9 +1,16 @@
 package desec
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -49,3 +56,69 @@ func Test_parseCursor(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordsService_GetAll_shrinkPageSize(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	opts := NewDefaultClientOptions()
+	opts.MaxResponseSize = 1024
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	var all []RRSet
+	for i := range 40 {
+		all = append(all, RRSet{SubName: fmt.Sprintf("s%02d", i), Type: "A", TTL: 3600, Records: []string{"10.0.0.1"}})
+	}
+
+	var pageSizes []string
+
+	mux.HandleFunc("GET /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+
+		// Without cursor, the whole zone is returned: the response is too large.
+		if !query.Has("cursor") {
+			_ = json.NewEncoder(rw).Encode(all)
+			return
+		}
+
+		pageSizes = append(pageSizes, query.Get("page_size"))
+
+		size := PageSize
+		if value := query.Get("page_size"); value != "" {
+			size, _ = strconv.Atoi(value)
+		}
+
+		offset, _ := strconv.Atoi(query.Get("cursor"))
+		end := min(offset+size, len(all))
+
+		if end < len(all) {
+			rw.Header().Set("Link", fmt.Sprintf(`<%s/domains/example.com/rrsets/?cursor=%d>; rel="next"`, server.URL, end))
+		}
+
+		_ = json.NewEncoder(rw).Encode(all[offset:end])
+	})
+
+	rrSets, err := client.Records.GetAll(context.Background(), "example.com", NewRRSetQuery().PageSize(100))
+	require.NoError(t, err)
+
+	assert.Equal(t, all, rrSets)
+
+	// The pages of 100, 50, and 25 RRSets are too large, the pages of 12 RRSets fit.
+	assert.Equal(t, []string{"100", "50", "25", "12", "12", "12", "12"}, pageSizes)
+}
+
+func TestDomainListOptions_pageSize(t *testing.T) {
+	query, err := (&DomainListOptions{PageSize: 50}).query()
+	require.NoError(t, err)
+
+	assert.Equal(t, url.Values{"page_size": {"50"}}, query)
+
+	_, err = (&DomainListOptions{PageSize: -1}).query()
+	require.EqualError(t, err, "invalid page size: -1")
+
+	_, err = NewRRSetQuery().PageSize(0).Values()
+	require.EqualError(t, err, "invalid RRSet query: invalid page size: 0")
+}
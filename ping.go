@@ -0,0 +1,69 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// PingResult the result of Ping.
+type PingResult struct {
+	// Reachable the API answered.
+	Reachable bool
+	// TokenValid the token has been accepted (authenticated).
+	TokenValid bool
+	// Forbidden the token is valid, but its permissions don't allow the call (403).
+	Forbidden bool
+	// StatusCode the HTTP status code of the response.
+	StatusCode int
+	// Latency the duration of the call (including the retries).
+	Latency time.Duration
+}
+
+// Ping performs a cheap authenticated call to check the reachability of the API and the validity of the token.
+// An error is returned only if the API is unreachable or answers with an unexpected status.
+func (c *Client) Ping(ctx context.Context) (*PingResult, error) {
+	endpoint, err := c.createEndpoint("auth", "account")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return &PingResult{Latency: time.Since(start)}, fmt.Errorf("failed to call API: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	result := &PingResult{
+		Reachable:  true,
+		StatusCode: resp.StatusCode,
+		Latency:    time.Since(start),
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		result.TokenValid = true
+		return result, nil
+
+	case http.StatusForbidden:
+		result.TokenValid = true
+		result.Forbidden = true
+
+		return result, nil
+
+	case http.StatusUnauthorized:
+		return result, nil
+
+	default:
+		return result, handleError(resp)
+	}
+}
@@ -0,0 +1,96 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Ping(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		token      string
+		tokenValid bool
+		forbidden  bool
+		statusCode int
+	}{
+		{
+			desc:       "valid token",
+			token:      "token",
+			tokenValid: true,
+			statusCode: http.StatusOK,
+		},
+		{
+			desc:       "invalid token",
+			token:      "invalid",
+			tokenValid: false,
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			desc:       "insufficient permissions",
+			token:      "restricted",
+			tokenValid: true,
+			forbidden:  true,
+			statusCode: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			t.Cleanup(server.Close)
+
+			client := New(test.token, NewDefaultClientOptions())
+			client.BaseURL = server.URL
+
+			mux.HandleFunc("/auth/account/", func(rw http.ResponseWriter, req *http.Request) {
+				if req.Method != http.MethodGet {
+					http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+					return
+				}
+
+				if req.Header.Get("Authorization") == "Token restricted" {
+					http.Error(rw, `{"detail": "You do not have permission to perform this action."}`, http.StatusForbidden)
+					return
+				}
+
+				if req.Header.Get("Authorization") != "Token token" {
+					http.Error(rw, `{"detail": "Invalid token."}`, http.StatusUnauthorized)
+					return
+				}
+
+				_, _ = rw.Write([]byte(`{"email":"youremailaddress@example.com"}`))
+			})
+
+			result, err := client.Ping(context.Background())
+			require.NoError(t, err)
+
+			assert.True(t, result.Reachable)
+			assert.Equal(t, test.tokenValid, result.TokenValid)
+			assert.Equal(t, test.forbidden, result.Forbidden)
+			assert.Equal(t, test.statusCode, result.StatusCode)
+			assert.Positive(t, result.Latency)
+		})
+	}
+}
+
+func TestClient_Ping_unreachable(t *testing.T) {
+	opts := NewDefaultClientOptions()
+	opts.RetryMax = 0
+
+	client := New("token", opts)
+	client.BaseURL = "http://127.0.0.1:1"
+
+	result, err := client.Ping(context.Background())
+	require.Error(t, err)
+
+	assert.False(t, result.Reachable)
+	assert.False(t, result.TokenValid)
+}
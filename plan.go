@@ -0,0 +1,242 @@
+package desec
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// ANSI colors of Plan.Render.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+// PlanEntry a change of a Plan.
+type PlanEntry struct {
+	// Before the live RRSet, nil for a creation.
+	Before *RRSet `json:"before,omitempty"`
+	// After the desired RRSet, nil for a deletion.
+	After *RRSet `json:"after,omitempty"`
+}
+
+// Plan the changes to apply to the RRSets of a domain to reach a desired state (RecordsService.Plan),
+// sorted by subname and type.
+// A plan can be serialized (JSON) to be reviewed, and applied later (RecordsService.ApplyPlan).
+type Plan struct {
+	Domain  string      `json:"domain"`
+	Creates []PlanEntry `json:"creates"`
+	Updates []PlanEntry `json:"updates"`
+	Deletes []PlanEntry `json:"deletes"`
+}
+
+// NewPlan computes the plan to reach the desired RRSets from the current RRSets of a domain (DiffZone).
+func NewPlan(domainName string, current, desired []RRSet) *Plan {
+	currentIndex := NewZone(current)
+
+	plan := &Plan{Domain: domainName, Creates: []PlanEntry{}, Updates: []PlanEntry{}, Deletes: []PlanEntry{}}
+
+	for _, change := range DiffZone(domainName, current, desired) {
+		after := change.RRSet
+
+		var before *RRSet
+		if have, ok := currentIndex.Get(after.SubName, after.Type); ok {
+			have.Domain = domainName
+			have.SubName = normalizeSubName(have.SubName)
+			before = &have
+		}
+
+		switch change.Action {
+		case ChangeCreate:
+			plan.Creates = append(plan.Creates, PlanEntry{After: &after})
+		case ChangeUpdate:
+			plan.Updates = append(plan.Updates, PlanEntry{Before: before, After: &after})
+		case ChangeDelete:
+			plan.Deletes = append(plan.Deletes, PlanEntry{Before: before})
+		}
+	}
+
+	return plan
+}
+
+// IsEmpty returns true if the plan has no change.
+func (p *Plan) IsEmpty() bool {
+	return len(p.Creates) == 0 && len(p.Updates) == 0 && len(p.Deletes) == 0
+}
+
+// Validate checks the consistency of a plan (ex: decoded from JSON):
+// a creation needs the after state, an update both states of the same RRSet, and a deletion the before state.
+func (p *Plan) Validate() error {
+	if p.Domain == "" {
+		return errors.New("invalid plan: missing domain")
+	}
+
+	check := func(kind string, entries []PlanEntry, before, after bool) error {
+		for i, entry := range entries {
+			switch {
+			case before && entry.Before == nil:
+				return fmt.Errorf("invalid plan: %s %d: missing before state", kind, i)
+			case after && entry.After == nil:
+				return fmt.Errorf("invalid plan: %s %d: missing after state", kind, i)
+			case before && after && entry.Before.Key() != entry.After.Key():
+				return fmt.Errorf("invalid plan: %s %d: the states are for different RRSets (%s, %s)", kind, i, entry.Before.Key(), entry.After.Key())
+			}
+		}
+
+		return nil
+	}
+
+	return errors.Join(
+		check("create", p.Creates, false, true),
+		check("update", p.Updates, true, true),
+		check("delete", p.Deletes, true, false),
+	)
+}
+
+// Changes returns the changes of the plan, sorted by subname and type.
+// The malformed entries are skipped (Validate).
+func (p *Plan) Changes() []RRSetChange {
+	changes := make([]RRSetChange, 0, len(p.Creates)+len(p.Updates)+len(p.Deletes))
+
+	for _, entry := range p.Creates {
+		if entry.After != nil {
+			changes = append(changes, RRSetChange{Action: ChangeCreate, RRSet: *entry.After})
+		}
+	}
+
+	for _, entry := range p.Updates {
+		if entry.Before != nil && entry.After != nil {
+			changes = append(changes, RRSetChange{Action: ChangeUpdate, RRSet: *entry.After})
+		}
+	}
+
+	for _, entry := range p.Deletes {
+		if entry.Before != nil {
+			changes = append(changes, RRSetChange{Action: ChangeDelete, RRSet: *entry.Before})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b RRSetChange) int {
+		return cmp.Or(
+			cmp.Compare(a.RRSet.SubName, b.RRSet.SubName),
+			cmp.Compare(a.RRSet.Type, b.RRSet.Type),
+		)
+	})
+
+	return changes
+}
+
+// Render writes a human-readable representation of the plan, with ANSI colors if color is true.
+// One line per change ("+" create, "~" update with the before and after states, "-" delete),
+// followed by a summary ("Plan: 1 to create, 1 to update, 1 to delete.").
+// A malformed plan is rejected (Validate).
+func (p *Plan) Render(w io.Writer, color bool) error {
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	paint := func(c, s string) string {
+		if !color {
+			return s
+		}
+
+		return c + s + ansiReset
+	}
+
+	var sb strings.Builder
+
+	for _, change := range p.Changes() {
+		switch change.Action {
+		case ChangeCreate:
+			sb.WriteString(paint(ansiGreen, "+ "+change.RRSet.String()))
+		case ChangeUpdate:
+			sb.WriteString(paint(ansiYellow, "~ "+p.renderUpdate(change.RRSet)))
+		case ChangeDelete:
+			sb.WriteString(paint(ansiRed, "- "+change.RRSet.String()))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	if p.IsEmpty() {
+		sb.WriteString("No changes.\n")
+	} else {
+		_, _ = fmt.Fprintf(&sb, "\nPlan: %d to create, %d to update, %d to delete.\n", len(p.Creates), len(p.Updates), len(p.Deletes))
+	}
+
+	_, err = io.WriteString(w, sb.String())
+
+	return err
+}
+
+func (p *Plan) renderUpdate(after RRSet) string {
+	var before *RRSet
+
+	for _, entry := range p.Updates {
+		if entry.After != nil && entry.After.Key() == after.Key() {
+			before = entry.Before
+			break
+		}
+	}
+
+	if before == nil {
+		return after.String()
+	}
+
+	return fmt.Sprintf("%s -> %d [%s]", before.String(), after.TTL, strings.Join(after.Records, " "))
+}
+
+// Plan computes the plan to reconcile the RRSets of a domain with the desired RRSets, without applying it.
+// The KeepUnmanaged option is applied, the other options are ignored.
+func (s *RecordsService) Plan(ctx context.Context, domainName string, desired []RRSet, opts *SyncOptions) (*Plan, error) {
+	current, err := s.GetAll(ctx, domainName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current state: %w", err)
+	}
+
+	plan := NewPlan(domainName, current, desired)
+
+	if opts != nil && opts.KeepUnmanaged {
+		plan.Deletes = []PlanEntry{}
+	}
+
+	return plan, nil
+}
+
+// ApplyPlan applies a plan with a single bulk request, the deSEC API applies it atomically.
+// The plan is applied as is: the changes made since its computation are overwritten.
+// A malformed plan is rejected (Validate).
+func (s *RecordsService) ApplyPlan(ctx context.Context, plan *Plan, opts *SyncOptions) error {
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+
+	err := plan.Validate()
+	if err != nil {
+		return err
+	}
+
+	changes := plan.Changes()
+
+	if opts.DryRun || len(changes) == 0 {
+		return nil
+	}
+
+	tracker := newProgressTracker(opts.Progress, len(changes), 1)
+
+	_, err = s.BulkUpdate(tracker.start(ctx), FullResource, plan.Domain, changesToRRSets(changes))
+	if err != nil {
+		return err
+	}
+
+	tracker.done(len(changes))
+
+	return nil
+}
@@ -0,0 +1,265 @@
+package desec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPlan() *Plan {
+	current := []RRSet{
+		{SubName: "", Type: "NS", TTL: 3600, Records: []string{"ns1.desec.io.", "ns2.desec.org."}},
+		{SubName: "old", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}},
+		{SubName: "ttl", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}},
+	}
+
+	desired := []RRSet{
+		{SubName: "ttl", Type: "A", TTL: 60, Records: []string{"10.10.10.10"}},
+		{SubName: "new", Type: "TXT", TTL: 3600, Records: []string{`"foo"`}},
+	}
+
+	return NewPlan("example.dedyn.io", current, desired)
+}
+
+func TestNewPlan(t *testing.T) {
+	plan := testPlan()
+
+	expected := &Plan{
+		Domain: "example.dedyn.io",
+		Creates: []PlanEntry{
+			{After: &RRSet{Domain: "example.dedyn.io", SubName: "new", Type: "TXT", TTL: 3600, Records: []string{`"foo"`}}},
+		},
+		Updates: []PlanEntry{
+			{
+				Before: &RRSet{Domain: "example.dedyn.io", SubName: "ttl", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}},
+				After:  &RRSet{Domain: "example.dedyn.io", SubName: "ttl", Type: "A", TTL: 60, Records: []string{"10.10.10.10"}},
+			},
+		},
+		Deletes: []PlanEntry{
+			{Before: &RRSet{Domain: "example.dedyn.io", SubName: "old", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}}},
+		},
+	}
+	assert.Equal(t, expected, plan)
+
+	assert.False(t, plan.IsEmpty())
+	assert.True(t, NewPlan("example.dedyn.io", nil, nil).IsEmpty())
+}
+
+func TestPlan_Changes(t *testing.T) {
+	changes := testPlan().Changes()
+
+	expected := []RRSetChange{
+		{Action: ChangeCreate, RRSet: RRSet{Domain: "example.dedyn.io", SubName: "new", Type: "TXT", TTL: 3600, Records: []string{`"foo"`}}},
+		{Action: ChangeDelete, RRSet: RRSet{Domain: "example.dedyn.io", SubName: "old", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}}},
+		{Action: ChangeUpdate, RRSet: RRSet{Domain: "example.dedyn.io", SubName: "ttl", Type: "A", TTL: 60, Records: []string{"10.10.10.10"}}},
+	}
+	assert.Equal(t, expected, changes)
+}
+
+func TestPlan_JSON(t *testing.T) {
+	plan := testPlan()
+
+	data, err := json.Marshal(plan)
+	require.NoError(t, err)
+
+	var decoded Plan
+
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, plan, &decoded)
+}
+
+func TestPlan_Render(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		plan     *Plan
+		color    bool
+		expected string
+	}{
+		{
+			desc: "plain",
+			plan: testPlan(),
+			expected: `+ new.example.dedyn.io TXT 3600 ["foo"]
+- old.example.dedyn.io A 3600 [10.10.10.10]
+~ ttl.example.dedyn.io A 3600 [10.10.10.10] -> 60 [10.10.10.10]
+
+Plan: 1 to create, 1 to update, 1 to delete.
+`,
+		},
+		{
+			desc:  "color",
+			plan:  testPlan(),
+			color: true,
+			expected: "\x1b[32m+ new.example.dedyn.io TXT 3600 [\"foo\"]\x1b[0m\n" +
+				"\x1b[31m- old.example.dedyn.io A 3600 [10.10.10.10]\x1b[0m\n" +
+				"\x1b[33m~ ttl.example.dedyn.io A 3600 [10.10.10.10] -> 60 [10.10.10.10]\x1b[0m\n" +
+				"\nPlan: 1 to create, 1 to update, 1 to delete.\n",
+		},
+		{
+			desc:     "empty",
+			plan:     NewPlan("example.dedyn.io", nil, nil),
+			expected: "No changes.\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+
+			err := test.plan.Render(buf, test.color)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+}
+
+func TestRecordsService_PlanApplyPlan(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var received []RRSet
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			_, _ = rw.Write([]byte(`[{"subname":"old","type":"A","ttl":3600,"records":["10.10.10.10"]}]`))
+
+		case http.MethodPut:
+			err := json.NewDecoder(req.Body).Decode(&received)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			_, _ = rw.Write([]byte(`[]`))
+
+		default:
+			http.Error(rw, "unexpected method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	desired := []RRSet{{SubName: "www", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}}}
+
+	plan, err := client.Records.Plan(context.Background(), "example.dedyn.io", desired, nil)
+	require.NoError(t, err)
+
+	assert.Len(t, plan.Creates, 1)
+	assert.Empty(t, plan.Updates)
+	assert.Len(t, plan.Deletes, 1)
+
+	err = client.Records.ApplyPlan(context.Background(), plan, nil)
+	require.NoError(t, err)
+
+	expected := []RRSet{
+		{SubName: "old", Type: "A", TTL: 3600, Records: []string{}},
+		{SubName: "www", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}},
+	}
+	assert.Equal(t, expected, received)
+}
+
+func TestPlan_Validate(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		data     string
+		expected string
+	}{
+		{
+			desc:     "missing domain",
+			data:     `{"creates":[]}`,
+			expected: "invalid plan: missing domain",
+		},
+		{
+			desc:     "create without after",
+			data:     `{"domain":"example.dedyn.io","creates":[{}]}`,
+			expected: "invalid plan: create 0: missing after state",
+		},
+		{
+			desc:     "update without after",
+			data:     `{"domain":"example.dedyn.io","updates":[{"before":{"subname":"www","type":"A","records":["10.10.10.10"],"ttl":3600}}]}`,
+			expected: "invalid plan: update 0: missing after state",
+		},
+		{
+			desc:     "update of different RRSets",
+			data:     `{"domain":"example.dedyn.io","updates":[{"before":{"subname":"www","type":"A","records":["10.10.10.10"],"ttl":3600},"after":{"subname":"api","type":"A","records":["10.10.10.10"],"ttl":60}}]}`,
+			expected: "invalid plan: update 0: the states are for different RRSets (www/A, api/A)",
+		},
+		{
+			desc:     "delete without before",
+			data:     `{"domain":"example.dedyn.io","deletes":[{"after":{"subname":"www","type":"A","records":["10.10.10.10"],"ttl":3600}}]}`,
+			expected: "invalid plan: delete 0: missing before state",
+		},
+	}
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = "http://desec.invalid/"
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			var plan Plan
+
+			err := json.Unmarshal([]byte(test.data), &plan)
+			require.NoError(t, err)
+
+			require.EqualError(t, plan.Validate(), test.expected)
+
+			require.EqualError(t, plan.Render(new(bytes.Buffer), false), test.expected)
+
+			require.EqualError(t, client.Records.ApplyPlan(context.Background(), &plan, nil), test.expected)
+
+			assert.NotPanics(t, func() { plan.Changes() })
+		})
+	}
+}
+
+func TestRecordsService_ApplyPlan_json(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var received []RRSet
+
+	mux.HandleFunc("PUT /domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(&received)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	data, err := json.Marshal(testPlan())
+	require.NoError(t, err)
+
+	var plan Plan
+
+	err = json.Unmarshal(data, &plan)
+	require.NoError(t, err)
+
+	require.NoError(t, plan.Validate())
+
+	err = client.Records.ApplyPlan(context.Background(), &plan, nil)
+	require.NoError(t, err)
+
+	expected := []RRSet{
+		{SubName: "new", Type: "TXT", TTL: 3600, Records: []string{`"foo"`}},
+		{SubName: "old", Type: "A", TTL: 3600, Records: []string{}},
+		{SubName: "ttl", Type: "A", TTL: 60, Records: []string{"10.10.10.10"}},
+	}
+	assert.Equal(t, expected, received)
+}
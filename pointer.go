@@ -0,0 +1,39 @@
+package desec
+
+// Pointer creates a pointer of a value.
+func Pointer[T any](v T) *T { return &v }
+
+// PointerOrNil creates a pointer of a value, nil for the zero value (ex: to omit an empty field of a patch).
+func PointerOrNil[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+
+	return &v
+}
+
+// Deref returns the value of a pointer, the zero value if the pointer is nil.
+func Deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+
+	return *p
+}
+
+// DerefOr returns the value of a pointer, def if the pointer is nil.
+func DerefOr[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+
+	return *p
+}
+
+// True returns a pointer to true: a tri-state boolean (nil: unset) set to true.
+func True() *bool { return Pointer(true) }
+
+// False returns a pointer to false: a tri-state boolean (nil: unset) set to false.
+func False() *bool { return Pointer(false) }
@@ -0,0 +1,37 @@
+package desec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointer(t *testing.T) {
+	assert.Equal(t, "example.com", *Pointer("example.com"))
+	assert.Equal(t, 3600, *Pointer(3600))
+}
+
+func TestPointerOrNil(t *testing.T) {
+	assert.Nil(t, PointerOrNil(""))
+	assert.Nil(t, PointerOrNil(0))
+	assert.Nil(t, PointerOrNil(false))
+
+	assert.Equal(t, Pointer("www"), PointerOrNil("www"))
+	assert.Equal(t, Pointer(true), PointerOrNil(true))
+}
+
+func TestDeref(t *testing.T) {
+	assert.Equal(t, "", Deref[string](nil))
+	assert.Equal(t, "www", Deref(Pointer("www")))
+
+	assert.Equal(t, "@", DerefOr(nil, "@"))
+	assert.Equal(t, "www", DerefOr(Pointer("www"), "@"))
+}
+
+func TestTriState(t *testing.T) {
+	assert.True(t, *True())
+	assert.False(t, *False())
+
+	assert.True(t, DerefOr(nil, true))
+	assert.False(t, DerefOr(False(), true))
+}
@@ -0,0 +1,167 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+)
+
+// defaultChunkSize the default number of RRSets sent by request by BulkCreateChunked.
+const defaultChunkSize = 500
+
+// Progress the progress of a bulk operation.
+type Progress struct {
+	// Done the number of RRSets applied.
+	Done int
+	// Total the number of RRSets to apply.
+	Total int
+	// Chunk the current chunk (starts at 1).
+	Chunk int
+	// Chunks the number of chunks.
+	Chunks int
+	// Retries the number of retries of the current chunk.
+	Retries int
+}
+
+// ProgressFunc receives the progress of a bulk operation:
+// after each retry of a chunk, and after each applied chunk.
+type ProgressFunc func(Progress)
+
+type retryObserverKey struct{}
+
+// withRetryObserver returns a context where the retries of the requests are reported to observe.
+func withRetryObserver(ctx context.Context, observe func()) context.Context {
+	return context.WithValue(ctx, retryObserverKey{}, observe)
+}
+
+// observeRetry reports a retry to the observer of the context, if any.
+func observeRetry(ctx context.Context) {
+	if observe, ok := ctx.Value(retryObserverKey{}).(func()); ok && observe != nil {
+		observe()
+	}
+}
+
+// progressTracker reports the progress of the chunks of a bulk operation.
+type progressTracker struct {
+	fn       ProgressFunc
+	progress Progress
+}
+
+func newProgressTracker(fn ProgressFunc, total, chunks int) *progressTracker {
+	return &progressTracker{fn: fn, progress: Progress{Total: total, Chunks: chunks}}
+}
+
+// skip skips the chunks applied by a previous call (Checkpoint).
+func (t *progressTracker) skip(done, chunks int) {
+	t.progress.Done += done
+	t.progress.Chunk += chunks
+}
+
+// start starts a chunk, the retries of the requests sent with the returned context are reported.
+func (t *progressTracker) start(ctx context.Context) context.Context {
+	t.progress.Chunk++
+	t.progress.Retries = 0
+
+	if t.fn == nil {
+		return ctx
+	}
+
+	return withRetryObserver(ctx, func() {
+		t.progress.Retries++
+		t.fn(t.progress)
+	})
+}
+
+// done reports an applied chunk of n RRSets.
+func (t *progressTracker) done(n int) {
+	t.progress.Done += n
+
+	if t.fn != nil {
+		t.fn(t.progress)
+	}
+}
+
+// BulkCreateChunkedOptions the options of BulkCreateChunked.
+type BulkCreateChunkedOptions struct {
+	// ChunkSize the maximum number of RRSets by request (default: 500).
+	ChunkSize int
+
+	// Progress receives the progress of the creation (optional).
+	Progress ProgressFunc
+
+	// Checkpoints records the applied chunks, to resume an interrupted creation instead of re-sending them (optional).
+	// A checkpoint is only resumed with the same domain, RRSets, and chunk size.
+	Checkpoints CheckpointStore
+}
+
+// BulkCreateChunked creates new RRSets in bulk, with one request by chunk of RRSets.
+// Useful to import large zones (tens of thousands of records).
+// Unlike BulkCreate, the creation is not atomic: on error, the RRSets of the previous chunks are created,
+// and returned with the error.
+// With a checkpoint store, the chunks applied by a previous interrupted call are skipped (and not returned).
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#bulk-creation-of-rrsets
+func (s *RecordsService) BulkCreateChunked(ctx context.Context, domainName string, rrSets []RRSet, opts *BulkCreateChunkedOptions) ([]RRSet, error) {
+	if opts == nil {
+		opts = &BulkCreateChunkedOptions{}
+	}
+
+	size := opts.ChunkSize
+	if size <= 0 {
+		size = defaultChunkSize
+	}
+
+	chunks := (len(rrSets) + size - 1) / size
+
+	tracker := newProgressTracker(opts.Progress, len(rrSets), chunks)
+
+	var (
+		fingerprint string
+		applied     int
+	)
+
+	if opts.Checkpoints != nil {
+		var err error
+
+		fingerprint, err = importFingerprint(rrSets, size)
+		if err != nil {
+			return nil, err
+		}
+
+		applied, err = resumeChunks(ctx, opts.Checkpoints, domainName, fingerprint)
+		if err != nil {
+			return nil, err
+		}
+
+		tracker.skip(min(applied*size, len(rrSets)), applied)
+	}
+
+	var created []RRSet
+
+	for start := applied * size; start < len(rrSets); start += size {
+		chunk := rrSets[start:min(start+size, len(rrSets))]
+
+		results, err := s.BulkCreate(tracker.start(ctx), domainName, chunk)
+		if err != nil {
+			return created, fmt.Errorf("chunk %d/%d: %w", tracker.progress.Chunk, chunks, err)
+		}
+
+		created = append(created, results...)
+
+		tracker.done(len(chunk))
+
+		if opts.Checkpoints != nil {
+			err = opts.Checkpoints.Save(ctx, Checkpoint{Domain: domainName, Fingerprint: fingerprint, Chunks: tracker.progress.Chunk})
+			if err != nil {
+				return created, err
+			}
+		}
+	}
+
+	if opts.Checkpoints != nil {
+		err := opts.Checkpoints.Clear(ctx)
+		if err != nil {
+			return created, err
+		}
+	}
+
+	return created, nil
+}
@@ -0,0 +1,105 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsService_BulkCreateChunked(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var calls int
+
+	mux.HandleFunc("POST /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		// The second chunk is throttled once.
+		if calls == 2 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			_, _ = rw.Write([]byte(`{"detail":"Request was throttled."}`))
+
+			return
+		}
+
+		var rrSets []RRSet
+
+		err := json.NewDecoder(req.Body).Decode(&rrSets)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(rw).Encode(rrSets)
+	})
+
+	var rrSets []RRSet
+	for i := range 5 {
+		rrSets = append(rrSets, RRSet{SubName: fmt.Sprintf("host%d", i), Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}})
+	}
+
+	var progress []Progress
+
+	created, err := client.Records.BulkCreateChunked(context.Background(), "example.com", rrSets, &BulkCreateChunkedOptions{
+		ChunkSize: 2,
+		Progress:  func(p Progress) { progress = append(progress, p) },
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, created, 5)
+	assert.Equal(t, 4, calls)
+
+	expected := []Progress{
+		{Done: 2, Total: 5, Chunk: 1, Chunks: 3},
+		{Done: 2, Total: 5, Chunk: 2, Chunks: 3, Retries: 1},
+		{Done: 4, Total: 5, Chunk: 2, Chunks: 3, Retries: 1},
+		{Done: 5, Total: 5, Chunk: 3, Chunks: 3},
+	}
+	assert.Equal(t, expected, progress)
+}
+
+func TestRecordsService_BulkCreateChunked_error(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var calls int
+
+	mux.HandleFunc("POST /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		if calls == 2 {
+			http.Error(rw, "boom", http.StatusInternalServerError)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = rw.Write([]byte(`[{"subname":"host0","type":"A","ttl":3600,"records":["10.10.10.10"]}]`))
+	})
+
+	rrSets := []RRSet{
+		{SubName: "host0", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}},
+		{SubName: "host1", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}},
+	}
+
+	created, err := client.Records.BulkCreateChunked(context.Background(), "example.com", rrSets, &BulkCreateChunkedOptions{ChunkSize: 1})
+	require.ErrorContains(t, err, "chunk 2/2: 500")
+
+	assert.Len(t, created, 1)
+}
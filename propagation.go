@@ -0,0 +1,184 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// DefaultResolvers public resolvers used by the PropagationChecker.
+var DefaultResolvers = []string{
+	"1.1.1.1:53",
+	"8.8.8.8:53",
+	"9.9.9.9:53",
+}
+
+// DeSECNameservers the authoritative nameservers of deSEC.
+var DeSECNameservers = []string{
+	"ns1.desec.io:53",
+	"ns2.desec.org:53",
+}
+
+// ResolverResult the visibility of a RRSet on a resolver.
+type ResolverResult struct {
+	Resolver string
+
+	// Visible is true if the resolver returns the expected records (or no record for a RRSet without records).
+	Visible bool
+
+	// Records the records returned by the resolver.
+	Records []string
+
+	// TTL the remaining TTL returned by the resolver.
+	TTL int
+
+	Err error
+}
+
+// PropagationReport the visibility of a RRSet on several resolvers.
+type PropagationReport struct {
+	RRSet   RRSet
+	Results []ResolverResult
+}
+
+// Propagated reports whether the RRSet is visible on all the resolvers.
+func (r *PropagationReport) Propagated() bool {
+	for _, result := range r.Results {
+		if !result.Visible {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PropagationChecker queries resolvers to check the propagation of a RRSet.
+type PropagationChecker struct {
+	// Resolvers the addresses (host:port) of the resolvers.
+	Resolvers []string
+
+	// Timeout of a query.
+	Timeout time.Duration
+}
+
+// NewPropagationChecker creates a new PropagationChecker using the DefaultResolvers and the DeSECNameservers.
+func NewPropagationChecker() *PropagationChecker {
+	return &PropagationChecker{
+		Resolvers: slices.Concat(DefaultResolvers, DeSECNameservers),
+		Timeout:   5 * time.Second,
+	}
+}
+
+// Check queries all the resolvers concurrently for a RRSet.
+// The RRSet must have a domain, a type, and the expected records (can be empty to check a deletion).
+func (c *PropagationChecker) Check(ctx context.Context, rrSet RRSet) (*PropagationReport, error) {
+	if rrSet.Domain == "" {
+		return nil, errors.New("missing domain")
+	}
+
+	qType, ok := dns.StringToType[rrSet.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown type: %s", rrSet.Type)
+	}
+
+	expected, err := canonicalRecords(rrSet)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := toASCII(OwnerName(rrSet.Domain, rrSet.SubName))
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PropagationReport{
+		RRSet:   rrSet,
+		Results: make([]ResolverResult, len(c.Resolvers)),
+	}
+
+	var wg sync.WaitGroup
+
+	for i, resolver := range c.Resolvers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			report.Results[i] = c.query(ctx, resolver, owner, qType, expected)
+		}()
+	}
+
+	wg.Wait()
+
+	return report, nil
+}
+
+func (c *PropagationChecker) query(ctx context.Context, resolver, owner string, qType uint16, expected []string) ResolverResult {
+	result := ResolverResult{Resolver: resolver}
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(owner, qType)
+
+	client := &dns.Client{Timeout: c.Timeout}
+
+	resp, _, err := client.ExchangeContext(ctx, msg, resolver)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+		result.Err = fmt.Errorf("unexpected response code: %s", dns.RcodeToString[resp.Rcode])
+		return result
+	}
+
+	result.TTL = -1
+
+	for _, rr := range resp.Answer {
+		hdr := rr.Header()
+		if hdr.Rrtype != qType || !dns.IsSubDomain(owner, hdr.Name) || !dns.IsSubDomain(hdr.Name, owner) {
+			continue
+		}
+
+		canonicalizeRR(rr)
+		result.Records = append(result.Records, rdata(rr))
+
+		if result.TTL < 0 || int(hdr.Ttl) < result.TTL {
+			result.TTL = int(hdr.Ttl)
+		}
+	}
+
+	result.TTL = max(result.TTL, 0)
+
+	slices.Sort(result.Records)
+	result.Visible = slices.Equal(result.Records, expected)
+
+	return result
+}
+
+// canonicalRecords returns the sorted canonical rdata of the records (CanonicalRecord).
+func canonicalRecords(rrSet RRSet) ([]string, error) {
+	rrs, err := RRSetToRRs(rrSet)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []string
+	for _, rr := range rrs {
+		canonicalizeRR(rr)
+		records = append(records, rdata(rr))
+	}
+
+	slices.Sort(records)
+
+	return records, nil
+}
+
+func rdata(rr dns.RR) string {
+	return rr.String()[len(rr.Header().String()):]
+}
@@ -0,0 +1,108 @@
+package desec
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startDNSServer(t *testing.T, records ...string) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	handler := dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		resp := new(dns.Msg)
+		resp.SetReply(req)
+
+		for _, record := range records {
+			rr, err := dns.NewRR(record)
+			if err != nil {
+				continue
+			}
+
+			if rr.Header().Name != req.Question[0].Name {
+				continue
+			}
+
+			if sig, ok := rr.(*dns.RRSIG); ok && sig.TypeCovered == req.Question[0].Qtype || rr.Header().Rrtype == req.Question[0].Qtype {
+				resp.Answer = append(resp.Answer, rr)
+			}
+		}
+
+		_ = w.WriteMsg(resp)
+	})
+
+	started := make(chan struct{})
+
+	server := &dns.Server{PacketConn: conn, Handler: handler, NotifyStartedFunc: func() { close(started) }}
+
+	go func() { _ = server.ActivateAndServe() }()
+
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	<-started
+
+	return conn.LocalAddr().String()
+}
+
+func TestPropagationChecker_Check(t *testing.T) {
+	updated := startDNSServer(t,
+		"www.example.dedyn.io. 120 IN A 10.10.10.11",
+		"www.example.dedyn.io. 60 IN A 10.10.10.10",
+	)
+	stale := startDNSServer(t, "www.example.dedyn.io. 300 IN A 10.10.10.10")
+	empty := startDNSServer(t)
+
+	checker := &PropagationChecker{
+		Resolvers: []string{updated, stale, empty},
+		Timeout:   time.Second,
+	}
+
+	rrSet := RRSet{Domain: "example.dedyn.io", SubName: "www", Type: "A", Records: []string{"10.10.10.10", "10.10.10.11"}}
+
+	report, err := checker.Check(context.Background(), rrSet)
+	require.NoError(t, err)
+
+	require.Len(t, report.Results, 3)
+
+	assert.True(t, report.Results[0].Visible)
+	assert.Equal(t, 60, report.Results[0].TTL)
+	require.NoError(t, report.Results[0].Err)
+
+	assert.False(t, report.Results[1].Visible)
+	assert.Equal(t, []string{"10.10.10.10"}, report.Results[1].Records)
+	assert.Equal(t, 300, report.Results[1].TTL)
+
+	assert.False(t, report.Results[2].Visible)
+	assert.Empty(t, report.Results[2].Records)
+
+	assert.False(t, report.Propagated())
+}
+
+func TestPropagationChecker_Check_deletion(t *testing.T) {
+	empty := startDNSServer(t)
+
+	checker := &PropagationChecker{Resolvers: []string{empty}, Timeout: time.Second}
+
+	report, err := checker.Check(context.Background(), RRSet{Domain: "example.dedyn.io", SubName: "_acme-challenge", Type: "TXT"})
+	require.NoError(t, err)
+
+	assert.True(t, report.Propagated())
+}
+
+func TestPropagationChecker_Check_unreachable(t *testing.T) {
+	checker := &PropagationChecker{Resolvers: []string{"127.0.0.1:1"}, Timeout: 100 * time.Millisecond}
+
+	report, err := checker.Check(context.Background(), RRSet{Domain: "example.dedyn.io", Type: "A", Records: []string{"10.10.10.10"}})
+	require.NoError(t, err)
+
+	require.Error(t, report.Results[0].Err)
+	assert.False(t, report.Propagated())
+}
@@ -0,0 +1,52 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+)
+
+// Quota the domain usage of an account.
+type Quota struct {
+	UsedDomains  int
+	LimitDomains int
+}
+
+// Remaining returns the number of domains that can still be created.
+func (q Quota) Remaining() int {
+	return max(q.LimitDomains-q.UsedDomains, 0)
+}
+
+// Quota returns the number of domains used and allowed.
+// The creation of a domain beyond the limit fails with a QuotaExceededError.
+func (s *AccountService) Quota(ctx context.Context) (*Quota, error) {
+	account, err := s.RetrieveInformation(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	domains, err := s.client.Domains.getAllPages(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Quota{
+		UsedDomains:  len(domains),
+		LimitDomains: account.LimitDomains,
+	}, nil
+}
+
+// preflightDomainQuota returns a QuotaExceededError if the account cannot create more domains.
+func (c *Client) preflightDomainQuota(ctx context.Context) error {
+	quota, err := c.Account.Quota(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check the domain quota: %w", err)
+	}
+
+	if quota.LimitDomains > 0 && quota.Remaining() == 0 {
+		return &QuotaExceededError{
+			Detail: fmt.Sprintf("You reached the maximum number of domains allowed for your account (%d/%d).", quota.UsedDomains, quota.LimitDomains),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountService_Quota(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/auth/account/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"email":"youremailaddress@example.com","limit_domains":5}`))
+	})
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"name":"example.com"},{"name":"example.org"}]`))
+	})
+
+	quota, err := client.Account.Quota(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, &Quota{UsedDomains: 2, LimitDomains: 5}, quota)
+	assert.Equal(t, 3, quota.Remaining())
+}
+
+func TestDomainsService_Create_quotaExceeded(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusForbidden)
+		_, _ = rw.Write([]byte(`{"detail":"You reached the maximum number of domains allowed for your account."}`))
+	})
+
+	_, err := client.Domains.Create(context.Background(), "example.com")
+
+	var quotaErr *QuotaExceededError
+	require.ErrorAs(t, err, &quotaErr)
+
+	assert.Contains(t, quotaErr.Detail, "maximum number of domains")
+}
+
+func TestDomainsService_Create_forbidden(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusForbidden)
+		_, _ = rw.Write([]byte(`{"detail":"You do not have permission to perform this action."}`))
+	})
+
+	_, err := client.Domains.Create(context.Background(), "example.com")
+	require.Error(t, err)
+
+	require.NotErrorAs(t, err, new(*QuotaExceededError))
+}
+
+func TestDomainsService_Create_quotaPreflight(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	opts := NewDefaultClientOptions()
+	opts.CheckDomainQuota = true
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/auth/account/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"email":"youremailaddress@example.com","limit_domains":2}`))
+	})
+
+	mux.HandleFunc("GET /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"name":"example.com"},{"name":"example.org"}]`))
+	})
+
+	var created bool
+
+	mux.HandleFunc("POST /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		created = true
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = rw.Write([]byte(`{"name":"example.net"}`))
+	})
+
+	_, err := client.Domains.Create(context.Background(), "example.net")
+
+	var quotaErr *QuotaExceededError
+	require.ErrorAs(t, err, &quotaErr)
+
+	assert.Equal(t, "You reached the maximum number of domains allowed for your account (2/2).", quotaErr.Detail)
+	assert.False(t, created)
+}
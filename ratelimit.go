@@ -0,0 +1,192 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit the rate limit state communicated by the headers of an API response.
+// The deSEC API only sends Retry-After on throttled responses,
+// the RateLimit-* and X-RateLimit-* headers are parsed in case the API adds them.
+type RateLimit struct {
+	// Limit the number of requests allowed in the current window, 0 if unknown.
+	Limit int
+	// Remaining the number of requests remaining in the current window, -1 if unknown.
+	Remaining int
+	// Reset the delay before the reset of the window, 0 if unknown.
+	Reset time.Duration
+	// RetryAfter the delay before the next request is allowed (Retry-After header), 0 if unknown.
+	RetryAfter time.Duration
+}
+
+// parseRateLimit parses the rate limit headers, returns nil if the response doesn't contain any.
+func parseRateLimit(header http.Header) *RateLimit {
+	limit := RateLimit{Remaining: -1}
+
+	found := false
+
+	if value, ok := rateLimitHeader(header, "Limit"); ok {
+		limit.Limit = value
+		found = true
+	}
+
+	if value, ok := rateLimitHeader(header, "Remaining"); ok {
+		limit.Remaining = value
+		found = true
+	}
+
+	if value, ok := rateLimitHeader(header, "Reset"); ok {
+		limit.Reset = resetDelay(value)
+		found = true
+	}
+
+	if header.Get("Retry-After") != "" {
+		limit.RetryAfter = retryAfter(header, "")
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+
+	return &limit
+}
+
+// rateLimitHeader reads the RateLimit-<name> header, or the X-RateLimit-<name> header.
+func rateLimitHeader(header http.Header, name string) (int, bool) {
+	for _, key := range []string{"RateLimit-" + name, "X-RateLimit-" + name} {
+		value, err := strconv.Atoi(header.Get(key))
+		if err == nil {
+			return value, true
+		}
+	}
+
+	return 0, false
+}
+
+// resetDelay converts a reset value to a delay: some APIs send a number of seconds, others a Unix timestamp.
+func resetDelay(value int) time.Duration {
+	const unixThreshold = 1_000_000_000
+
+	if value >= unixThreshold {
+		return max(time.Until(time.Unix(int64(value), 0)), 0)
+	}
+
+	return time.Duration(value) * time.Second
+}
+
+// RateLimitObserver a RateLimiter that adapts to the rate limits communicated by the API (ClientOptions.RateLimiter).
+type RateLimitObserver interface {
+	// ObserveRateLimit is called with the rate limit headers of each API response containing them.
+	ObserveRateLimit(limit RateLimit)
+}
+
+// AdaptiveLimiter a RateLimiter that pauses the API calls when the API communicates an exhausted budget
+// (Retry-After, or no remaining request before the reset of the window).
+type AdaptiveLimiter struct {
+	next RateLimiter
+
+	mu    sync.Mutex
+	until time.Time
+}
+
+// NewAdaptiveLimiter creates a new AdaptiveLimiter.
+// The optional next limiter is applied after the pauses (ex: golang.org/x/time/rate.Limiter).
+func NewAdaptiveLimiter(next RateLimiter) *AdaptiveLimiter {
+	return &AdaptiveLimiter{next: next}
+}
+
+// Wait blocks until the pause communicated by the API is over, or the context is done.
+func (l *AdaptiveLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	delay := time.Until(l.until)
+	l.mu.Unlock()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if l.next != nil {
+		return l.next.Wait(ctx)
+	}
+
+	return nil
+}
+
+// ObserveRateLimit extends the pause according to the rate limit.
+func (l *AdaptiveLimiter) ObserveRateLimit(limit RateLimit) {
+	var delay time.Duration
+
+	switch {
+	case limit.RetryAfter > 0:
+		delay = limit.RetryAfter
+	case limit.Remaining == 0:
+		delay = limit.Reset
+	default:
+		return
+	}
+
+	until := time.Now().Add(delay)
+
+	l.mu.Lock()
+	if until.After(l.until) {
+		l.until = until
+	}
+	l.mu.Unlock()
+}
+
+// rateLimitedTransport waits for the rate limiter before each HTTP request (retries included),
+// and reports the rate limits of the responses to the limiter (RateLimitObserver).
+type rateLimitedTransport struct {
+	next    http.RoundTripper
+	limiter RateLimiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	err := t.limiter.Wait(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if observer, ok := t.limiter.(RateLimitObserver); ok {
+		if limit := parseRateLimit(resp.Header); limit != nil {
+			observer.ObserveRateLimit(*limit)
+		}
+	}
+
+	return resp, nil
+}
+
+// withRateLimiter returns a copy of the HTTP client, with a transport limited by the limiter.
+// The underlying transport is shared.
+func withRateLimiter(client *http.Client, limiter RateLimiter) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	limited := *client
+
+	transport := limited.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	limited.Transport = &rateLimitedTransport{next: transport, limiter: limiter}
+
+	return &limited
+}
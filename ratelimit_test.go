@@ -0,0 +1,111 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseRateLimit(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		header   http.Header
+		expected *RateLimit
+	}{
+		{
+			desc:   "no headers",
+			header: http.Header{},
+		},
+		{
+			desc: "RateLimit headers",
+			header: http.Header{
+				"Ratelimit-Limit":     []string{"10"},
+				"Ratelimit-Remaining": []string{"3"},
+				"Ratelimit-Reset":     []string{"5"},
+			},
+			expected: &RateLimit{Limit: 10, Remaining: 3, Reset: 5 * time.Second},
+		},
+		{
+			desc: "X-RateLimit headers",
+			header: http.Header{
+				"X-Ratelimit-Remaining": []string{"0"},
+			},
+			expected: &RateLimit{Remaining: 0},
+		},
+		{
+			desc: "Retry-After",
+			header: http.Header{
+				"Retry-After": []string{"2"},
+			},
+			expected: &RateLimit{Remaining: -1, RetryAfter: 2 * time.Second},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, parseRateLimit(test.header))
+		})
+	}
+}
+
+func TestAdaptiveLimiter(t *testing.T) {
+	limiter := NewAdaptiveLimiter(nil)
+
+	err := limiter.Wait(context.Background())
+	require.NoError(t, err)
+
+	limiter.ObserveRateLimit(RateLimit{Limit: 10, Remaining: 5, Reset: time.Hour})
+
+	err = limiter.Wait(context.Background())
+	require.NoError(t, err)
+
+	limiter.ObserveRateLimit(RateLimit{Remaining: 0, Reset: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err = limiter.Wait(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_rateLimiter(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("GET /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("RateLimit-Remaining", "0")
+		rw.Header().Set("RateLimit-Reset", "3600")
+
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	limiter := NewAdaptiveLimiter(nil)
+
+	opts := NewDefaultClientOptions()
+	opts.RateLimiter = limiter
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	var resp Response
+
+	_, err := client.Domains.GetAll(WithResponse(context.Background(), &resp))
+	require.NoError(t, err)
+
+	expected := &RateLimit{Remaining: 0, Reset: time.Hour}
+	assert.Equal(t, expected, resp.RateLimit)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = client.Domains.GetAll(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -2,9 +2,12 @@ package desec
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"time"
 )
 
@@ -25,9 +28,58 @@ type RRSet struct {
 	TTL     int        `json:"ttl,omitempty"`
 	Created *time.Time `json:"created,omitempty"`
 	Touched *time.Time `json:"touched,omitempty"`
+
+	// Extra the fields returned by the API but not modeled by the library.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (r *RRSet) UnmarshalJSON(data []byte) error {
+	type alias RRSet
+
+	var a alias
+
+	extra, err := unmarshalWithExtra(data, &a)
+	if err != nil {
+		return err
+	}
+
+	*r = RRSet(a)
+	r.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// The order of the records is not significant: the records are sorted and deduplicated,
+// to avoid the spurious updates and diffs caused by the ordering.
+func (r RRSet) MarshalJSON() ([]byte, error) {
+	type alias RRSet
+
+	a := alias(r)
+	a.Records = normalizeRecords(r.Records)
+
+	return json.Marshal(a)
+}
+
+// normalizeRecords returns a sorted copy of the records without duplicates.
+func normalizeRecords(records []string) []string {
+	if records == nil {
+		return nil
+	}
+
+	sorted := slices.Clone(records)
+	slices.Sort(sorted)
+
+	return slices.Compact(sorted)
+}
+
+func (r *RRSet) extraFields() map[string]json.RawMessage {
+	return r.Extra
 }
 
 // RRSetFilter a RRSets filter.
+// RRSetQuery supports more parameters.
 type RRSetFilter struct {
 	Type    string
 	SubName string
@@ -61,29 +113,42 @@ type RecordsService struct {
 */
 
 // GetAll retrieving all RRSets in a zone.
+// If the zone is too large to be retrieved at once (or the response is too large), all the pages are retrieved.
 // https://desec.readthedocs.io/en/latest/dns/rrsets.html#retrieving-all-rrsets-in-a-zone
-func (s *RecordsService) GetAll(ctx context.Context, domainName string, filter *RRSetFilter) ([]RRSet, error) {
-	rrSets, _, err := s.GetAllPaginated(ctx, domainName, filter, "")
+func (s *RecordsService) GetAll(ctx context.Context, domainName string, filter RRSetSelector) ([]RRSet, error) {
+	query, err := selectorValues(filter)
 	if err != nil {
 		return nil, err
 	}
 
-	return rrSets, nil
+	rrSets, _, err := s.getAll(ctx, domainName, query)
+	if err == nil {
+		return rrSets, nil
+	}
+
+	var cursor string
+
+	var paginationErr *PaginationRequiredError
+
+	switch {
+	case errors.As(err, &paginationErr):
+		cursor = paginationErr.Cursors.First
+	case errors.As(err, new(*ResponseTooLargeError)):
+	default:
+		return nil, err
+	}
+
+	return collectPages(query, cursor, func(query url.Values) ([]RRSet, *Cursors, error) {
+		return s.getAll(ctx, domainName, query)
+	})
 }
 
 // GetAllPaginated retrieving all RRSets in a zone.
 // https://desec.readthedocs.io/en/latest/dns/rrsets.html#retrieving-all-rrsets-in-a-zone
-func (s *RecordsService) GetAllPaginated(ctx context.Context, domainName string, filter *RRSetFilter, cursor string) ([]RRSet, *Cursors, error) {
-	queryValues := url.Values{}
-
-	if filter != nil {
-		if filter.Type != IgnoreFilter {
-			queryValues.Set("type", filter.Type)
-		}
-
-		if filter.SubName != IgnoreFilter {
-			queryValues.Set("subname", filter.SubName)
-		}
+func (s *RecordsService) GetAllPaginated(ctx context.Context, domainName string, filter RRSetSelector, cursor string) ([]RRSet, *Cursors, error) {
+	queryValues, err := selectorValues(filter)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	queryValues.Set("cursor", cursor)
@@ -102,70 +167,48 @@ func (s *RecordsService) getAll(ctx context.Context, domainName string, query ur
 		return nil, nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodGet, endpoint, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	if len(query) > 0 {
-		req.URL.RawQuery = query.Encode()
-	}
-
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to call API: %w", err)
-	}
+	endpoint.RawQuery = query.Encode()
 
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, nil, handleError(resp)
-	}
-
-	cursors, err := parseCursor(resp.Header)
+	rrSets, resp, err := do[[]RRSet](ctx, s.client, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var rrSets []RRSet
-	err = handleResponse(resp, &rrSets)
-	if err != nil {
-		return nil, nil, err
-	}
+	s.client.decodeRRSets(rrSets)
 
-	return rrSets, cursors, nil
+	return rrSets, resp.Cursors, nil
 }
 
 // Create creates a new RRSet.
 // https://desec.readthedocs.io/en/latest/dns/rrsets.html#creating-a-tlsa-rrset
 func (s *RecordsService) Create(ctx context.Context, rrSet RRSet) (*RRSet, error) {
-	endpoint, err := s.client.createEndpoint("domains", rrSet.Domain, "rrsets")
+	err := checkType(rrSet.Type)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+		return nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, endpoint, rrSet)
+	rrSet, err = encodeRRSet(rrSet)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.httpClient.Do(req)
+	err = s.client.applyTTL(ctx, rrSet.Domain, true, &rrSet)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
+		return nil, err
 	}
 
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusCreated {
-		return nil, handleError(resp)
+	endpoint, err := s.client.createEndpoint("domains", rrSet.Domain, "rrsets")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	var newRRSet RRSet
-	err = handleResponse(resp, &newRRSet)
+	newRRSet, _, err := do[RRSet](ctx, s.client, http.MethodPost, endpoint, rrSet)
 	if err != nil {
 		return nil, err
 	}
 
+	s.client.decodeRRSet(&newRRSet)
+
 	return &newRRSet, nil
 }
 
@@ -185,27 +228,12 @@ func (s *RecordsService) Get(ctx context.Context, domainName, subName, recordTyp
 		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodGet, endpoint, nil)
+	rrSet, _, err := do[RRSet](ctx, s.client, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, handleError(resp)
-	}
-
-	var rrSet RRSet
-	err = handleResponse(resp, &rrSet)
-	if err != nil {
-		return nil, err
-	}
+	s.client.decodeRRSet(&rrSet)
 
 	return &rrSet, nil
 }
@@ -217,39 +245,36 @@ func (s *RecordsService) Update(ctx context.Context, domainName, subName, record
 		subName = ApexZone
 	}
 
-	endpoint, err := s.client.createEndpoint("domains", domainName, "rrsets", subName, recordType)
+	err := checkType(recordType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+		return nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPatch, endpoint, rrSet)
+	rrSet, err = encodeRRSet(rrSet)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.httpClient.Do(req)
+	// With a PATCH, a RRSet without TTL keeps its current TTL.
+	err = s.client.applyTTL(ctx, domainName, false, &rrSet)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	// when a RRSet is deleted (empty records)
-	if resp.StatusCode == http.StatusNoContent {
-		return nil, nil
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, handleError(resp)
+	endpoint, err := s.client.createEndpoint("domains", domainName, "rrsets", subName, recordType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	var updatedRRSet RRSet
-	err = handleResponse(resp, &updatedRRSet)
-	if err != nil {
+	// when a RRSet is deleted (empty records), the response is empty (204).
+	updatedRRSet, _, err := do[*RRSet](ctx, s.client, http.MethodPatch, endpoint, rrSet)
+	if err != nil || updatedRRSet == nil {
 		return nil, err
 	}
 
-	return &updatedRRSet, nil
+	s.client.decodeRRSet(updatedRRSet)
+
+	return updatedRRSet, nil
 }
 
 // Replace replaces a RRSet (PUT).
@@ -259,39 +284,35 @@ func (s *RecordsService) Replace(ctx context.Context, domainName, subName, recor
 		subName = ApexZone
 	}
 
-	endpoint, err := s.client.createEndpoint("domains", domainName, "rrsets", subName, recordType)
+	err := checkType(recordType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+		return nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPut, endpoint, rrSet)
+	rrSet, err = encodeRRSet(rrSet)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.httpClient.Do(req)
+	err = s.client.applyTTL(ctx, domainName, true, &rrSet)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	// when a RRSet is deleted (empty records)
-	if resp.StatusCode == http.StatusNoContent {
-		return nil, nil
+		return nil, err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, handleError(resp)
+	endpoint, err := s.client.createEndpoint("domains", domainName, "rrsets", subName, recordType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	var updatedRRSet RRSet
-	err = handleResponse(resp, &updatedRRSet)
-	if err != nil {
+	// when a RRSet is deleted (empty records), the response is empty (204).
+	updatedRRSet, _, err := do[*RRSet](ctx, s.client, http.MethodPut, endpoint, rrSet)
+	if err != nil || updatedRRSet == nil {
 		return nil, err
 	}
 
-	return &updatedRRSet, nil
+	s.client.decodeRRSet(updatedRRSet)
+
+	return updatedRRSet, nil
 }
 
 // Delete deletes a RRSet.
@@ -306,23 +327,9 @@ func (s *RecordsService) Delete(ctx context.Context, domainName, subName, record
 		return fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodDelete, endpoint, nil)
-	if err != nil {
-		return err
-	}
-
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusNoContent {
-		return handleError(resp)
-	}
+	_, _, err = do[struct{}](ctx, s.client, http.MethodDelete, endpoint, nil)
 
-	return nil
+	return err
 }
 
 /*
@@ -342,70 +349,76 @@ const (
 // BulkCreate creates new RRSets in bulk.
 // https://desec.readthedocs.io/en/latest/dns/rrsets.html#bulk-creation-of-rrsets
 func (s *RecordsService) BulkCreate(ctx context.Context, domainName string, rrSets []RRSet) ([]RRSet, error) {
-	endpoint, err := s.client.createEndpoint("domains", domainName, "rrsets")
+	err := checkTypes(rrSets)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+		return nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, endpoint, rrSets)
+	rrSets, err = encodeRRSets(rrSets)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.httpClient.Do(req)
+	err = s.client.applyTTLs(ctx, domainName, true, rrSets)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
+		return nil, err
 	}
 
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusCreated {
-		return nil, handleError(resp)
+	endpoint, err := s.client.createEndpoint("domains", domainName, "rrsets")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	var newRRSets []RRSet
-	err = handleResponse(resp, &newRRSets)
+	newRRSets, _, err := doWithErrorHandler[[]RRSet](ctx, s.client, http.MethodPost, endpoint, rrSets, func(resp *http.Response) error {
+		return handleBulkError(resp, rrSets)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	s.client.decodeRRSets(newRRSets)
+
 	return newRRSets, nil
 }
 
 // BulkUpdate updates RRSets in bulk.
 // https://desec.readthedocs.io/en/latest/dns/rrsets.html#bulk-modification-of-rrsets
 func (s *RecordsService) BulkUpdate(ctx context.Context, mode UpdateMode, domainName string, rrSets []RRSet) ([]RRSet, error) {
-	endpoint, err := s.client.createEndpoint("domains", domainName, "rrsets")
+	err := checkTypes(rrSets)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+		return nil, err
 	}
 
-	req, err := s.client.newRequest(ctx, string(mode), endpoint, rrSets)
+	rrSets, err = encodeRRSets(rrSets)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.httpClient.Do(req)
+	// With OnlyFields, a RRSet without TTL keeps its current TTL.
+	err = s.client.applyTTLs(ctx, domainName, mode == FullResource, rrSets)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
+		return nil, err
 	}
 
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, handleError(resp)
+	endpoint, err := s.client.createEndpoint("domains", domainName, "rrsets")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	var results []RRSet
-	err = handleResponse(resp, &results)
+	results, _, err := doWithErrorHandler[[]RRSet](ctx, s.client, string(mode), endpoint, rrSets, func(resp *http.Response) error {
+		return handleBulkError(resp, rrSets)
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	s.client.decodeRRSets(results)
+
 	return results, nil
 }
 
 // BulkDelete deletes RRSets in bulk (uses FullResourceUpdateMode).
+// BulkDeleteWithReport reports the deleted RRSets.
 // https://desec.readthedocs.io/en/latest/dns/rrsets.html#bulk-deletion-of-rrsets
 func (s *RecordsService) BulkDelete(ctx context.Context, domainName string, rrSets []RRSet) error {
 	deleteRRSets := make([]RRSet, len(rrSets))
@@ -421,3 +434,17 @@ func (s *RecordsService) BulkDelete(ctx context.Context, domainName string, rrSe
 
 	return nil
 }
+
+// upsert creates or replaces a RRSet.
+func (s *RecordsService) upsert(ctx context.Context, rrSet RRSet) (*RRSet, error) {
+	results, err := s.BulkUpdate(ctx, FullResource, rrSet.Domain, []RRSet{rrSet})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	return &results[0], nil
+}
@@ -440,3 +440,86 @@ func mustParseTime(value string) *time.Time {
 	date, _ := time.Parse(time.RFC3339, value)
 	return &date
 }
+
+func TestRecordsService_GetAll_pagination(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+
+		assert.Equal(t, "A", query.Get("type"))
+
+		switch {
+		case !query.Has("cursor"):
+			rw.Header().Set("Link", `<`+server.URL+`/domains/example.dedyn.io/rrsets/?cursor=>; rel="first"`)
+			rw.WriteHeader(http.StatusBadRequest)
+			_, _ = rw.Write([]byte(`{"detail":"Pagination required. You can query up to 500 items at a time."}`))
+
+		case query.Get("cursor") == "":
+			rw.Header().Set("Link", `<`+server.URL+`/domains/example.dedyn.io/rrsets/?cursor=>; rel="first", <`+server.URL+`/domains/example.dedyn.io/rrsets/?cursor=next>; rel="next"`)
+			_, _ = rw.Write([]byte(`[{"domain":"example.dedyn.io","subname":"a","type":"A","records":["10.0.0.1"]}]`))
+
+		default:
+			rw.Header().Set("Link", `<`+server.URL+`/domains/example.dedyn.io/rrsets/?cursor=>; rel="first", <`+server.URL+`/domains/example.dedyn.io/rrsets/?cursor=>; rel="prev"`)
+			_, _ = rw.Write([]byte(`[{"domain":"example.dedyn.io","subname":"b","type":"A","records":["10.0.0.2"]}]`))
+		}
+	})
+
+	records, err := client.Records.GetAll(context.Background(), "example.dedyn.io", &RRSetFilter{Type: "A", SubName: IgnoreFilter})
+	require.NoError(t, err)
+
+	expected := []RRSet{
+		{Domain: "example.dedyn.io", SubName: "a", Type: "A", Records: []string{"10.0.0.1"}},
+		{Domain: "example.dedyn.io", SubName: "b", Type: "A", Records: []string{"10.0.0.2"}},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestRRSet_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rrSet    RRSet
+		expected string
+	}{
+		{
+			desc:     "sorted and deduplicated",
+			rrSet:    RRSet{SubName: "www", Type: "A", TTL: 3600, Records: []string{"192.0.2.2", "192.0.2.1", "192.0.2.2"}},
+			expected: `{"subname":"www","type":"A","records":["192.0.2.1","192.0.2.2"],"ttl":3600}`,
+		},
+		{
+			desc:     "empty records",
+			rrSet:    RRSet{SubName: "www", Type: "A", Records: []string{}},
+			expected: `{"subname":"www","type":"A","records":[]}`,
+		},
+		{
+			desc:     "nil records",
+			rrSet:    RRSet{SubName: "www", Type: "A"},
+			expected: `{"subname":"www","type":"A","records":null}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := json.Marshal(test.rrSet)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, test.expected, string(data))
+		})
+	}
+}
+
+func TestRRSet_MarshalJSON_doesNotModify(t *testing.T) {
+	rrSet := RRSet{Type: "A", Records: []string{"192.0.2.2", "192.0.2.1"}}
+
+	_, err := json.Marshal(rrSet)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"192.0.2.2", "192.0.2.1"}, rrSet.Records)
+}
@@ -0,0 +1,138 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+	"unicode"
+)
+
+// ReplaceValueOptions the options of ReplaceValue.
+type ReplaceValueOptions struct {
+	// DryRun computes the changes without applying them.
+	DryRun bool
+}
+
+// ReplaceValue replaces a value (ex: an IP address, a hostname) wherever it appears in the records of the RRSets
+// matching the filter (all the RRSets if the filter is nil), and returns the applied changes.
+// A value matches a whole record, or the target field of the MX, SRV, HTTPS, and SVCB records (ex: the exchange of a MX record):
+// the other fields (ex: priority, weight, port) and the content of the TXT records are not modified.
+// The changes are applied with a single bulk request, the deSEC API applies them atomically.
+func (s *RecordsService) ReplaceValue(ctx context.Context, domainName string, filter RRSetSelector, oldValue, newValue string, opts *ReplaceValueOptions) ([]RRSetChange, error) {
+	if opts == nil {
+		opts = &ReplaceValueOptions{}
+	}
+
+	current, err := s.GetAll(ctx, domainName, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current state: %w", err)
+	}
+
+	changes := planReplaceValue(domainName, current, oldValue, newValue)
+
+	if opts.DryRun || len(changes) == 0 {
+		return changes, nil
+	}
+
+	_, err = s.BulkUpdate(ctx, OnlyFields, domainName, changesToRRSets(changes))
+	if err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// planReplaceValue computes the updates of the RRSets containing the old value.
+func planReplaceValue(domainName string, rrSets []RRSet, oldValue, newValue string) []RRSetChange {
+	var changes []RRSetChange
+
+	for _, rrSet := range rrSets {
+		if IsManagedType(rrSet.Type) {
+			continue
+		}
+
+		records := make([]string, len(rrSet.Records))
+
+		var replaced bool
+
+		for i, record := range rrSet.Records {
+			var ok bool
+
+			records[i], ok = replaceValue(rrSet.Type, record, oldValue, newValue)
+			replaced = replaced || ok
+		}
+
+		if !replaced {
+			continue
+		}
+
+		changes = append(changes, RRSetChange{Action: ChangeUpdate, RRSet: RRSet{
+			Domain:  domainName,
+			SubName: normalizeSubName(rrSet.SubName),
+			Type:    rrSet.Type,
+			TTL:     rrSet.TTL,
+			Records: records,
+		}})
+	}
+
+	return changes
+}
+
+// targetFields the index of the target field (a hostname) of the records, by type.
+var targetFields = map[string]int{
+	"MX":    1, // priority exchange
+	"SRV":   3, // priority weight port target
+	"HTTPS": 1, // priority target params
+	"SVCB":  1, // priority target params
+}
+
+// replaceValue replaces the old value in a record: the whole record, or the target field of the record.
+// The rest of the record is kept as is (whitespaces, quoting).
+func replaceValue(rrType, record, oldValue, newValue string) (string, bool) {
+	if record == oldValue {
+		return newValue, true
+	}
+
+	index, ok := targetFields[rrType]
+	if !ok {
+		return record, false
+	}
+
+	start, end, ok := fieldBounds(record, index)
+	if !ok || record[start:end] != oldValue {
+		return record, false
+	}
+
+	return record[:start] + newValue + record[end:], true
+}
+
+// fieldBounds returns the byte offsets of the n-th field (0-based, separated by whitespaces) of a record.
+func fieldBounds(record string, n int) (int, int, bool) {
+	start, field := -1, 0
+
+	for i, r := range record {
+		if !unicode.IsSpace(r) {
+			if start < 0 {
+				start = i
+			}
+
+			continue
+		}
+
+		if start < 0 {
+			continue
+		}
+
+		if field == n {
+			return start, i, true
+		}
+
+		start = -1
+		field++
+	}
+
+	if start >= 0 && field == n {
+		return start, len(record), true
+	}
+
+	return 0, 0, false
+}
@@ -0,0 +1,97 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsService_ReplaceValue(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[
+{"subname":"","type":"MX","ttl":3600,"records":["10 mail.example.net.","20 backup.example.org."]},
+{"subname":"www","type":"CNAME","ttl":3600,"records":["mail.example.net."]},
+{"subname":"api","type":"A","ttl":3600,"records":["10.0.0.1"]}
+]`))
+	})
+
+	var received []RRSet
+
+	mux.HandleFunc("PATCH /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(&received)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(rw).Encode(received)
+	})
+
+	expected := []RRSetChange{
+		{Action: ChangeUpdate, RRSet: RRSet{Domain: "example.com", Type: "MX", TTL: 3600, Records: []string{"10 mail.example.com.", "20 backup.example.org."}}},
+		{Action: ChangeUpdate, RRSet: RRSet{Domain: "example.com", SubName: "www", Type: "CNAME", TTL: 3600, Records: []string{"mail.example.com."}}},
+	}
+
+	changes, err := client.Records.ReplaceValue(context.Background(), "example.com", nil, "mail.example.net.", "mail.example.com.", &ReplaceValueOptions{DryRun: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, changes)
+	assert.Nil(t, received)
+
+	changes, err = client.Records.ReplaceValue(context.Background(), "example.com", nil, "mail.example.net.", "mail.example.com.", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, changes)
+
+	expectedRRSets := []RRSet{
+		{Type: "MX", TTL: 3600, Records: []string{"10 mail.example.com.", "20 backup.example.org."}},
+		{SubName: "www", Type: "CNAME", TTL: 3600, Records: []string{"mail.example.com."}},
+	}
+	assert.Equal(t, expectedRRSets, received)
+}
+
+func Test_replaceValue(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		rrType   string
+		record   string
+		oldValue string
+		newValue string
+		expected string
+		replaced bool
+	}{
+		{desc: "whole record", rrType: "A", record: "10.0.0.1", oldValue: "10.0.0.1", newValue: "10.0.0.2", expected: "10.0.0.2", replaced: true},
+		{desc: "partial field", rrType: "A", record: "10.0.0.10", oldValue: "10.0.0.1", newValue: "10.0.0.2", expected: "10.0.0.10"},
+		{desc: "no match", rrType: "A", record: "10.0.0.3", oldValue: "10.0.0.1", newValue: "10.0.0.2", expected: "10.0.0.3"},
+		{desc: "MX exchange", rrType: "MX", record: "10 mail.example.net.", oldValue: "mail.example.net.", newValue: "mail.example.com.", expected: "10 mail.example.com.", replaced: true},
+		{desc: "MX priority", rrType: "MX", record: "10 10.", oldValue: "10", newValue: "20", expected: "10 10."},
+		{desc: "MX exchange equal to the priority", rrType: "MX", record: "10 10.", oldValue: "10.", newValue: "mail.example.com.", expected: "10 mail.example.com.", replaced: true},
+		{desc: "SRV weight and port", rrType: "SRV", record: "10 5060 5060 sip.example.net.", oldValue: "5060", newValue: "5061", expected: "10 5060 5060 sip.example.net."},
+		{desc: "SRV target", rrType: "SRV", record: "10 5  5060  sip.example.net.", oldValue: "sip.example.net.", newValue: "sip.example.com.", expected: "10 5  5060  sip.example.com.", replaced: true},
+		{desc: "TXT content", rrType: "TXT", record: `"v=spf1 ip4:10.0.0.1  -all"`, oldValue: "-all", newValue: "~all", expected: `"v=spf1 ip4:10.0.0.1  -all"`},
+		{desc: "TXT whole record", rrType: "TXT", record: `"hello  world"`, oldValue: `"hello  world"`, newValue: `"hello   world"`, expected: `"hello   world"`, replaced: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			record, replaced := replaceValue(test.rrType, test.record, test.oldValue, test.newValue)
+
+			assert.Equal(t, test.expected, record)
+			assert.Equal(t, test.replaced, replaced)
+		})
+	}
+}
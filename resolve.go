@@ -0,0 +1,41 @@
+package desec
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// responsibleCacheTTL the duration of the cache of the responsible domains.
+const responsibleCacheTTL = 5 * time.Minute
+
+// ResolveRRSetTarget splits a fully qualified domain name into the responsible deSEC domain and the subname.
+// The responsible domains are cached in memory, per token.
+//
+//	domain, subName, err := client.Domains.ResolveRRSetTarget(ctx, "_acme-challenge.www.example.com.")
+//	// domain: "example.com", subName: "_acme-challenge.www"
+func (s *DomainsService) ResolveRRSetTarget(ctx context.Context, fqdn string) (string, string, error) {
+	name, err := toASCII(strings.ToLower(strings.TrimSuffix(fqdn, ".")))
+	if err != nil {
+		return "", "", err
+	}
+
+	domain, err := s.responsibleDomain(ctx, name)
+	if err != nil {
+		return "", "", err
+	}
+
+	domainName := domain.Name
+
+	asciiDomainName, err := toASCII(domainName)
+	if err != nil {
+		return "", "", err
+	}
+
+	subName, err := SubNameFromOwner(asciiDomainName, name)
+	if err != nil {
+		return "", "", err
+	}
+
+	return domainName, subName, nil
+}
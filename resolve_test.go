@@ -0,0 +1,46 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainsService_ResolveRRSetTarget(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var calls int
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		if req.URL.Query().Get("owns_qname") != "_acme-challenge.www.example.com" {
+			_, _ = rw.Write([]byte(`[]`))
+			return
+		}
+
+		_, _ = rw.Write([]byte(`[{"name":"example.com"}]`))
+	})
+
+	for range 2 {
+		domainName, subName, err := client.Domains.ResolveRRSetTarget(context.Background(), "_acme-challenge.www.Example.com.")
+		require.NoError(t, err)
+
+		assert.Equal(t, "example.com", domainName)
+		assert.Equal(t, "_acme-challenge.www", subName)
+	}
+
+	assert.Equal(t, 1, calls)
+
+	_, _, err := client.Domains.ResolveRRSetTarget(context.Background(), "example.org")
+	require.Error(t, err)
+}
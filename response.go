@@ -0,0 +1,75 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type responseKey struct{}
+
+// Response the metadata of an API response.
+type Response struct {
+	// StatusCode the HTTP status code.
+	StatusCode int
+	// Header the HTTP headers.
+	Header http.Header
+	// Date the value of the Date header.
+	Date time.Time
+	// RequestID the value of the X-Request-Id header, if any.
+	RequestID string
+	// Cursors the pagination cursors (Link header).
+	Cursors *Cursors
+	// RateLimit the rate limit headers, nil if the response doesn't contain any.
+	RateLimit *RateLimit
+}
+
+// WithResponse returns a context that captures the metadata of the API responses into resp.
+// When a method performs several API calls, the metadata of the last response are captured.
+//
+//	var resp desec.Response
+//	domains, err := client.Domains.GetAll(desec.WithResponse(ctx, &resp))
+func WithResponse(ctx context.Context, resp *Response) context.Context {
+	return context.WithValue(ctx, responseKey{}, resp)
+}
+
+// responseRecorder fills the Response of the request context.
+type responseRecorder struct {
+	next httpDoer
+}
+
+func (r responseRecorder) Do(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.Do(req)
+	if resp == nil {
+		return resp, err
+	}
+
+	meta, ok := req.Context().Value(responseKey{}).(*Response)
+	if !ok || meta == nil {
+		return resp, err
+	}
+
+	*meta = newResponse(resp)
+
+	return resp, err
+}
+
+func newResponse(resp *http.Response) Response {
+	meta := Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+
+	if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+		meta.Date = date
+	}
+
+	if cursors, err := parseCursor(resp.Header); err == nil {
+		meta.Cursors = cursors
+	}
+
+	meta.RateLimit = parseRateLimit(resp.Header)
+
+	return meta
+}
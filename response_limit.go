@@ -0,0 +1,68 @@
+package desec
+
+import (
+	"io"
+	"net/http"
+)
+
+// DefaultMaxResponseSize the default maximum size of the response bodies (ClientOptions.MaxResponseSize): 16 MiB.
+const DefaultMaxResponseSize int64 = 16 << 20
+
+// limitDoer limits the size of the response bodies,
+// to protect the client against a misbehaving endpoint or proxy sending an endless body.
+type limitDoer struct {
+	next  httpDoer
+	limit int64
+}
+
+func (d limitDoer) Do(req *http.Request) (*http.Response, error) {
+	resp, err := d.next.Do(req)
+	if resp == nil || resp.Body == nil {
+		return resp, err
+	}
+
+	resp.Body = &limitedBody{
+		reader: io.LimitReader(resp.Body, d.limit+1),
+		closer: resp.Body,
+		limit:  d.limit,
+	}
+
+	return resp, err
+}
+
+// withResponseLimit returns the doer with a limit of the response bodies,
+// 0 uses DefaultMaxResponseSize, a negative value disables the limit.
+func withResponseLimit(next httpDoer, limit int64) httpDoer {
+	if limit < 0 {
+		return next
+	}
+
+	if limit == 0 {
+		limit = DefaultMaxResponseSize
+	}
+
+	return limitDoer{next: next, limit: limit}
+}
+
+// limitedBody returns a ResponseTooLargeError when more than limit bytes are read.
+type limitedBody struct {
+	reader io.Reader
+	closer io.Closer
+	limit  int64
+	read   int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.reader.Read(p)
+	b.read += int64(n)
+
+	if b.read > b.limit {
+		return n - int(b.read-b.limit), &ResponseTooLargeError{Limit: b.limit}
+	}
+
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	return b.closer.Close()
+}
@@ -0,0 +1,69 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_maxResponseSize(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("GET /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"name":"` + strings.Repeat("a", 1000) + `.com"}]`))
+	})
+
+	mux.HandleFunc("GET /domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(strings.Repeat("a", 1000)))
+	})
+
+	opts := NewDefaultClientOptions()
+	opts.MaxResponseSize = 512
+	opts.RetryMax = 0
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	_, err := client.Domains.GetAll(context.Background())
+	require.Error(t, err)
+
+	var tooLargeErr *ResponseTooLargeError
+	require.True(t, errors.As(err, &tooLargeErr))
+
+	assert.Equal(t, int64(512), tooLargeErr.Limit)
+
+	_, err = client.Domains.Get(context.Background(), "example.com")
+	require.Error(t, err)
+
+	assert.True(t, errors.As(err, &tooLargeErr))
+}
+
+func TestClient_maxResponseSize_disabled(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("GET /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"name":"` + strings.Repeat("a", 1000) + `.com"}]`))
+	})
+
+	opts := NewDefaultClientOptions()
+	opts.MaxResponseSize = -1
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	domains, err := client.Domains.GetAll(context.Background())
+	require.NoError(t, err)
+
+	assert.Len(t, domains, 1)
+}
@@ -0,0 +1,40 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithResponse(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Date", "Wed, 06 May 2020 11:46:07 GMT")
+		rw.Header().Set("X-Request-Id", "123")
+		rw.Header().Set("Link", `<https://desec.io/api/v1/domains/?cursor=>; rel="first", <https://desec.io/api/v1/domains/?cursor=:next_cursor>; rel="next"`)
+
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	var resp Response
+
+	_, err := client.Domains.GetAll(WithResponse(context.Background(), &resp))
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "123", resp.RequestID)
+	assert.Equal(t, time.Date(2020, time.May, 6, 11, 46, 7, 0, time.UTC), resp.Date)
+	assert.Equal(t, &Cursors{Next: ":next_cursor"}, resp.Cursors)
+	assert.Equal(t, "123", resp.Header.Get("X-Request-Id"))
+}
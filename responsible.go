@@ -0,0 +1,107 @@
+package desec
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+)
+
+// GetResponsibleBatch returns the responsible domains of many DNS query names, keyed by query name (as given).
+// The names without responsible domain are absent from the result.
+//
+// The names are deduplicated (the wildcard label is ignored), and the responsible domains are cached in memory, per token.
+// A lookup also resolves the names between the query name and its responsible domain
+// (ex: www.example.com and example.com for _acme-challenge.www.example.com):
+// the longest names are looked up first, so a certificate with a wildcard and many SANs needs few API calls.
+//
+//	domains, err := client.Domains.GetResponsibleBatch(ctx, []string{"_acme-challenge.www.example.com", "*.example.com", "www.example.com"})
+//	// a single API call: domains["*.example.com"].Name == "example.com"
+func (s *DomainsService) GetResponsibleBatch(ctx context.Context, names []string) (map[string]*Domain, error) {
+	queries := make(map[string][]string)
+
+	for _, name := range names {
+		qname, err := responsibleQueryName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		queries[qname] = append(queries[qname], name)
+	}
+
+	qnames := make([]string, 0, len(queries))
+	for qname := range queries {
+		qnames = append(qnames, qname)
+	}
+
+	// The longest names first: their lookups resolve the shorter names.
+	slices.SortFunc(qnames, func(a, b string) int {
+		return cmp.Or(cmp.Compare(strings.Count(b, "."), strings.Count(a, ".")), cmp.Compare(a, b))
+	})
+
+	result := make(map[string]*Domain, len(names))
+
+	for _, qname := range qnames {
+		domain, err := s.responsibleDomain(ctx, qname)
+		if err != nil {
+			if errors.As(err, new(*NotFoundError)) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		for _, name := range queries[qname] {
+			d := *domain
+			result[name] = &d
+		}
+	}
+
+	return result, nil
+}
+
+// responsibleDomain returns the responsible domain of a query name (ASCII, lower case), cached in memory per token.
+// The names between the query name and the responsible domain have the same responsible domain, they are cached too:
+// a more specific domain owning one of them would also own the query name.
+func (s *DomainsService) responsibleDomain(ctx context.Context, qname string) (*Domain, error) {
+	scope, err := s.client.tokenScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if domain, ok := s.client.responsible.get(scope + "/" + qname); ok {
+		return &domain, nil
+	}
+
+	domain, err := s.GetResponsible(ctx, qname)
+	if err != nil {
+		return nil, err
+	}
+
+	domainName, err := toASCII(strings.ToLower(domain.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	for name := qname; ; {
+		s.client.responsible.set(scope+"/"+name, *domain)
+
+		var ok bool
+
+		_, name, ok = strings.Cut(name, ".")
+		if !ok || (name != domainName && !strings.HasSuffix(name, "."+domainName)) {
+			break
+		}
+	}
+
+	return domain, nil
+}
+
+// responsibleQueryName normalizes a name, the wildcard label is removed: the domain owning *.example.com owns example.com.
+func responsibleQueryName(name string) (string, error) {
+	qname := strings.ToLower(strings.TrimSuffix(name, "."))
+	qname = strings.TrimPrefix(qname, "*.")
+
+	return toASCII(qname)
+}
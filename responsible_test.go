@@ -0,0 +1,100 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainsService_GetResponsibleBatch(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	// Nested zones: the most specific domain is responsible.
+	domains := []string{"dev.example.com", "example.com"}
+
+	var qnames []string
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		qname := req.URL.Query().Get("owns_qname")
+		qnames = append(qnames, qname)
+
+		for _, domain := range domains {
+			if qname == domain || strings.HasSuffix(qname, "."+domain) {
+				_, _ = fmt.Fprintf(rw, `[{"name":%q,"minimum_ttl":3600}]`, domain)
+				return
+			}
+		}
+
+		_, _ = rw.Write([]byte("[]"))
+	})
+
+	names := []string{
+		"_acme-challenge.www.example.com.",
+		"*.example.com",
+		"example.com",
+		"www.example.com",
+		"WWW.example.com",
+		"foo.dev.example.com",
+		"dev.example.com",
+		"unknown.org",
+	}
+
+	result, err := client.Domains.GetResponsibleBatch(context.Background(), names)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"_acme-challenge.www.example.com", "foo.dev.example.com", "unknown.org"}, qnames)
+
+	expected := map[string]string{
+		"_acme-challenge.www.example.com.": "example.com",
+		"*.example.com":                    "example.com",
+		"example.com":                      "example.com",
+		"www.example.com":                  "example.com",
+		"WWW.example.com":                  "example.com",
+		"foo.dev.example.com":              "dev.example.com",
+		"dev.example.com":                  "dev.example.com",
+	}
+
+	actual := make(map[string]string, len(result))
+	for name, domain := range result {
+		actual[name] = domain.Name
+	}
+
+	assert.Equal(t, expected, actual)
+
+	// The resolved names are cached, the other names are looked up: a more specific domain may own them.
+	qnames = nil
+
+	result, err = client.Domains.GetResponsibleBatch(context.Background(), []string{"www.example.com", "bar.dev.example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"bar.dev.example.com"}, qnames)
+	assert.Equal(t, "example.com", result["www.example.com"].Name)
+	assert.Equal(t, "dev.example.com", result["bar.dev.example.com"].Name)
+}
+
+func TestDomainsService_GetResponsibleBatch_error(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", ClientOptions{HTTPClient: http.DefaultClient})
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, _ *http.Request) {
+		http.Error(rw, "boom", http.StatusInternalServerError)
+	})
+
+	_, err := client.Domains.GetResponsibleBatch(context.Background(), []string{"example.com"})
+	require.Error(t, err)
+}
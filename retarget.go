@@ -0,0 +1,124 @@
+package desec
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"slices"
+)
+
+// RetargetOptions the options of RetargetAddress.
+type RetargetOptions struct {
+	// DryRun computes the changes without applying them (preview).
+	DryRun bool
+
+	// Concurrency the maximum number of domains processed concurrently (default: 4).
+	Concurrency int
+
+	// RateLimiter limits the rate of the API calls shared by all the domains (optional).
+	RateLimiter RateLimiter
+}
+
+// RetargetAddress replaces an IP address by another one in the A or AAAA RRSets of all the domains of the account,
+// ex: when a server moves.
+// The addresses must have the same family.
+// The changes of a domain are applied with a single bulk request (ApplyAcrossDomains),
+// an error on a domain doesn't stop the processing of the others.
+func (s *RecordsService) RetargetAddress(ctx context.Context, oldAddr, newAddr netip.Addr, opts *RetargetOptions) (*ApplyAcrossDomainsReport, error) {
+	if opts == nil {
+		opts = &RetargetOptions{}
+	}
+
+	if !oldAddr.IsValid() || !newAddr.IsValid() {
+		return nil, errors.New("invalid address")
+	}
+
+	recordType := addrType(oldAddr)
+	if addrType(newAddr) != recordType {
+		return nil, fmt.Errorf("mixed address families: %s and %s", oldAddr, newAddr)
+	}
+
+	domains, err := s.client.Domains.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+
+	domainNames := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domainNames = append(domainNames, domain.Name)
+	}
+
+	rrSetsByDomain, err := s.GetAllDomains(ctx, domainNames, opts.Concurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	changesByDomain := map[string][]RRSetChange{}
+
+	for domainName, rrSets := range rrSetsByDomain {
+		changes := planRetarget(domainName, rrSets, recordType, oldAddr.Unmap(), newAddr.Unmap())
+		if len(changes) > 0 {
+			changesByDomain[domainName] = changes
+		}
+	}
+
+	if !opts.DryRun {
+		return s.ApplyAcrossDomains(ctx, changesByDomain, &ApplyAcrossDomainsOptions{
+			Concurrency: opts.Concurrency,
+			RateLimiter: opts.RateLimiter,
+		}), nil
+	}
+
+	report := &ApplyAcrossDomainsReport{}
+
+	for domainName, changes := range changesByDomain {
+		report.Results = append(report.Results, DomainApplyResult{Domain: domainName, Changes: changes})
+	}
+
+	slices.SortFunc(report.Results, func(a, b DomainApplyResult) int {
+		return cmp.Compare(a.Domain, b.Domain)
+	})
+
+	return report, nil
+}
+
+// planRetarget computes the updates of the RRSets of the type containing the old address.
+func planRetarget(domainName string, rrSets []RRSet, recordType string, oldAddr, newAddr netip.Addr) []RRSetChange {
+	var changes []RRSetChange
+
+	for _, rrSet := range rrSets {
+		if rrSet.Type != recordType {
+			continue
+		}
+
+		records := make([]string, len(rrSet.Records))
+
+		var replaced bool
+
+		for i, record := range rrSet.Records {
+			records[i] = record
+
+			addr, err := netip.ParseAddr(record)
+			if err == nil && addr.Unmap() == oldAddr {
+				records[i] = newAddr.String()
+				replaced = true
+			}
+		}
+
+		if !replaced {
+			continue
+		}
+
+		changes = append(changes, RRSetChange{Action: ChangeUpdate, RRSet: RRSet{
+			Domain:  domainName,
+			SubName: normalizeSubName(rrSet.SubName),
+			Type:    rrSet.Type,
+			TTL:     rrSet.TTL,
+			Records: records,
+		}})
+	}
+
+	return changes
+}
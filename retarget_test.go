@@ -0,0 +1,99 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsService_RetargetAddress(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"name":"example.com"},{"name":"example.org"},{"name":"example.net"}]`))
+	})
+
+	zones := map[string]string{
+		"example.com": `[{"subname":"","type":"A","ttl":3600,"records":["192.0.2.1","192.0.2.2"]},{"subname":"www","type":"A","ttl":300,"records":["192.0.2.1"]}]`,
+		"example.org": `[{"subname":"","type":"A","ttl":3600,"records":["192.0.2.3"]},{"subname":"","type":"TXT","ttl":3600,"records":["\"192.0.2.1\""]}]`,
+		"example.net": `[{"subname":"api","type":"A","ttl":3600,"records":["192.0.2.1"]}]`,
+	}
+
+	mux.HandleFunc("GET /domains/{domain}/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(zones[req.PathValue("domain")]))
+	})
+
+	var mu sync.Mutex
+
+	received := map[string][]RRSet{}
+
+	mux.HandleFunc("PUT /domains/{domain}/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		var rrSets []RRSet
+
+		err := json.NewDecoder(req.Body).Decode(&rrSets)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		received[req.PathValue("domain")] = rrSets
+		mu.Unlock()
+
+		_ = json.NewEncoder(rw).Encode(rrSets)
+	})
+
+	oldAddr := netip.MustParseAddr("192.0.2.1")
+	newAddr := netip.MustParseAddr("192.0.2.10")
+
+	expected := &ApplyAcrossDomainsReport{Results: []DomainApplyResult{
+		{
+			Domain: "example.com",
+			Changes: []RRSetChange{
+				{Action: ChangeUpdate, RRSet: RRSet{Domain: "example.com", Type: "A", TTL: 3600, Records: []string{"192.0.2.10", "192.0.2.2"}}},
+				{Action: ChangeUpdate, RRSet: RRSet{Domain: "example.com", SubName: "www", Type: "A", TTL: 300, Records: []string{"192.0.2.10"}}},
+			},
+		},
+		{
+			Domain: "example.net",
+			Changes: []RRSetChange{
+				{Action: ChangeUpdate, RRSet: RRSet{Domain: "example.net", SubName: "api", Type: "A", TTL: 3600, Records: []string{"192.0.2.10"}}},
+			},
+		},
+	}}
+
+	report, err := client.Records.RetargetAddress(context.Background(), oldAddr, newAddr, &RetargetOptions{DryRun: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, report)
+	assert.Empty(t, received)
+
+	report, err = client.Records.RetargetAddress(context.Background(), oldAddr, newAddr, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, report)
+	assert.True(t, report.OK())
+
+	assert.Len(t, received, 2)
+	assert.Len(t, received["example.com"], 2)
+	assert.Len(t, received["example.net"], 1)
+}
+
+func TestRecordsService_RetargetAddress_mixedFamilies(t *testing.T) {
+	client := New("token", NewDefaultClientOptions())
+
+	_, err := client.Records.RetargetAddress(context.Background(), netip.MustParseAddr("192.0.2.1"), netip.MustParseAddr("2001:db8::1"), nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,180 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// RetryPolicy decides whether a failed API call must be retried (ClientOptions.RetryPolicy).
+// resp is nil if err is not nil.
+// A non-nil error stops the retries and is returned instead of the response.
+// A canceled context always stops the retries, whatever the policy: the API call returns a CanceledError.
+type RetryPolicy func(ctx context.Context, method string, resp *http.Response, err error) (bool, error)
+
+// DefaultRetryPolicy the default RetryPolicy.
+// The throttled requests (429) are always retried: the API has not processed them.
+// The idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE) are also retried on the connection errors and on the statuses 502, 503, and 504.
+// The other requests (POST, PATCH) are not retried on these errors: the API may have processed them (ex: duplicate RRSet creation).
+func DefaultRetryPolicy(ctx context.Context, method string, resp *http.Response, err error) (bool, error) {
+	return classifyRetry(ctx, IsIdempotent(method), resp, err)
+}
+
+// RetryAllMethodsPolicy a RetryPolicy that retries all the requests like the idempotent ones (DefaultRetryPolicy).
+// Opt-in for the callers that can handle the duplicated POST and PATCH requests.
+func RetryAllMethodsPolicy(ctx context.Context, _ string, resp *http.Response, err error) (bool, error) {
+	return classifyRetry(ctx, true, resp, err)
+}
+
+// IsIdempotent returns true if requests with the HTTP method can be safely repeated.
+func IsIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func classifyRetry(ctx context.Context, idempotent bool, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if err != nil {
+		if !idempotent {
+			return false, nil
+		}
+
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true, nil
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent, nil
+	default:
+		return false, nil
+	}
+}
+
+// CanceledError the context of an API call has been canceled, or its deadline exceeded,
+// during an attempt or while waiting between the retries.
+// It wraps the error of the context: errors.Is(err, context.DeadlineExceeded) works.
+type CanceledError struct {
+	Method string
+	// URL the URL of the request, without credentials.
+	URL string
+
+	// Attempts the number of attempts started before the cancellation.
+	Attempts int
+	// LastStatusCode the status code of the last response received, 0 if none.
+	LastStatusCode int
+
+	// Err the error of the context.
+	Err error
+}
+
+func (e CanceledError) Error() string {
+	msg := fmt.Sprintf("%s %s: canceled after %d attempt(s)", e.Method, e.URL, e.Attempts)
+
+	if e.LastStatusCode != 0 {
+		msg += fmt.Sprintf(" (last status code: %d)", e.LastStatusCode)
+	}
+
+	return fmt.Sprintf("%s: %v", msg, e.Err)
+}
+
+// Unwrap unwraps error.
+func (e CanceledError) Unwrap() error {
+	return e.Err
+}
+
+type (
+	requestMethodKey struct{}
+	attemptsKey      struct{}
+)
+
+// attempts the attempts of an API call, recorded by the retry client.
+type attempts struct {
+	mu             sync.Mutex
+	count          int
+	lastStatusCode int
+}
+
+// recordAttempt records the start of an attempt (attempt starts at 0).
+func recordAttempt(ctx context.Context, attempt int) {
+	if a, ok := ctx.Value(attemptsKey{}).(*attempts); ok {
+		a.mu.Lock()
+		a.count = attempt + 1
+		a.mu.Unlock()
+	}
+}
+
+// recordResponse records the response of an attempt.
+func recordResponse(ctx context.Context, resp *http.Response) {
+	if a, ok := ctx.Value(attemptsKey{}).(*attempts); ok && resp != nil {
+		a.mu.Lock()
+		a.lastStatusCode = resp.StatusCode
+		a.mu.Unlock()
+	}
+}
+
+// methodDoer stores the method of the request in its context: the retry policy doesn't receive the request.
+// It also tracks the attempts, to return a CanceledError when the context is canceled.
+type methodDoer struct {
+	next httpDoer
+}
+
+func (d methodDoer) Do(req *http.Request) (*http.Response, error) {
+	a := &attempts{}
+
+	ctx := context.WithValue(req.Context(), requestMethodKey{}, req.Method)
+	ctx = context.WithValue(ctx, attemptsKey{}, a)
+
+	resp, err := d.next.Do(req.WithContext(ctx))
+	if err == nil || ctx.Err() == nil {
+		return resp, err
+	}
+
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return nil, &CanceledError{
+		Method:         req.Method,
+		URL:            sanitizeURL(req.URL),
+		Attempts:       a.count,
+		LastStatusCode: a.lastStatusCode,
+		Err:            ctx.Err(),
+	}
+}
+
+// methodRetryPolicy adapts a RetryPolicy to the retry client.
+// A canceled context always stops the retries, whatever the policy.
+func methodRetryPolicy(policy RetryPolicy) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		recordResponse(ctx, resp)
+
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+
+		// A misconfiguration of the client is not retried, whatever the policy.
+		if errors.Is(err, ErrDialerTransport) {
+			return false, nil
+		}
+
+		method, _ := ctx.Value(requestMethodKey{}).(string)
+
+		return policy(ctx, method, resp, err)
+	}
+}
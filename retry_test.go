@@ -0,0 +1,171 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		method   string
+		status   int
+		err      error
+		expected bool
+	}{
+		{desc: "GET 503", method: http.MethodGet, status: http.StatusServiceUnavailable, expected: true},
+		{desc: "PUT 502", method: http.MethodPut, status: http.StatusBadGateway, expected: true},
+		{desc: "DELETE 504", method: http.MethodDelete, status: http.StatusGatewayTimeout, expected: true},
+		{desc: "GET 500", method: http.MethodGet, status: http.StatusInternalServerError, expected: false},
+		{desc: "GET 400", method: http.MethodGet, status: http.StatusBadRequest, expected: false},
+		{desc: "GET connection error", method: http.MethodGet, err: errors.New("connection reset"), expected: true},
+		{desc: "POST 503", method: http.MethodPost, status: http.StatusServiceUnavailable, expected: false},
+		{desc: "PATCH 502", method: http.MethodPatch, status: http.StatusBadGateway, expected: false},
+		{desc: "POST connection error", method: http.MethodPost, err: errors.New("connection reset"), expected: false},
+		{desc: "POST 429", method: http.MethodPost, status: http.StatusTooManyRequests, expected: true},
+		{desc: "PATCH 429", method: http.MethodPatch, status: http.StatusTooManyRequests, expected: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var resp *http.Response
+			if test.err == nil {
+				resp = &http.Response{StatusCode: test.status}
+			}
+
+			retry, err := DefaultRetryPolicy(context.Background(), test.method, resp, test.err)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, retry)
+		})
+	}
+}
+
+func TestRetryAllMethodsPolicy(t *testing.T) {
+	retry, err := RetryAllMethodsPolicy(context.Background(), http.MethodPost, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil)
+	require.NoError(t, err)
+
+	assert.True(t, retry)
+}
+
+func TestClient_retryPolicy(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var calls atomic.Int32
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+
+		rw.Header().Set("Retry-After", "0")
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	opts := NewDefaultClientOptions()
+	opts.RetryMax = 2
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	_, err := client.Domains.Create(context.Background(), "example.com")
+	require.Error(t, err)
+
+	assert.EqualValues(t, 1, calls.Load())
+
+	calls.Store(0)
+
+	_, err = client.Domains.GetAll(context.Background())
+	require.Error(t, err)
+
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestClient_canceledDuringBackoff(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Retry-After", "30")
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	t.Cleanup(cancel)
+
+	start := time.Now()
+
+	_, err := client.Domains.GetAll(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	var canceledErr *CanceledError
+	require.ErrorAs(t, err, &canceledErr)
+
+	assert.Equal(t, http.MethodGet, canceledErr.Method)
+	assert.Equal(t, 1, canceledErr.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, canceledErr.LastStatusCode)
+}
+
+func TestClient_canceledInFlight(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var calls atomic.Int32
+
+	inFlight := make(chan struct{})
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		if calls.Add(1) == 1 {
+			rw.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		close(inFlight)
+
+		<-req.Context().Done()
+	})
+
+	opts := NewDefaultClientOptions()
+	// The policy ignores the context: the cancellation must stop the retries anyway.
+	opts.RetryPolicy = func(_ context.Context, _ string, _ *http.Response, _ error) (bool, error) {
+		return true, nil
+	}
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	go func() {
+		<-inFlight
+		cancel()
+	}()
+
+	_, err := client.Domains.GetAll(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	var canceledErr *CanceledError
+	require.ErrorAs(t, err, &canceledErr)
+
+	assert.Equal(t, 2, canceledErr.Attempts)
+	assert.Equal(t, http.StatusBadGateway, canceledErr.LastStatusCode)
+	assert.EqualValues(t, 2, calls.Load())
+}
@@ -0,0 +1,101 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/miekg/dns"
+)
+
+// maxPTRPrefixSize the maximum number of addresses handled by PTRRRSetsForPrefix.
+const maxPTRPrefixSize = 1 << 16
+
+// ReverseName returns the in-addr.arpa or ip6.arpa name of an IP address.
+func ReverseName(addr netip.Addr) (string, error) {
+	if !addr.IsValid() {
+		return "", errors.New("invalid address")
+	}
+
+	return dns.ReverseAddr(addr.Unmap().String())
+}
+
+// ReverseSubName returns the subname of the reverse name of an IP address inside a reverse zone.
+// ex: 10.10.10.10 inside 10.10.in-addr.arpa is 10.10.
+func ReverseSubName(zone string, addr netip.Addr) (string, error) {
+	name, err := ReverseName(addr)
+	if err != nil {
+		return "", err
+	}
+
+	return SubNameFromOwner(zone, name)
+}
+
+// NewPTRRRSet creates a PTR RRSet for an IP address inside a reverse zone.
+func NewPTRRRSet(zone string, addr netip.Addr, target string, ttl int) (RRSet, error) {
+	subName, err := ReverseSubName(zone, addr)
+	if err != nil {
+		return RRSet{}, err
+	}
+
+	return RRSet{
+		Domain:  zone,
+		SubName: subName,
+		Type:    "PTR",
+		Records: []string{dns.Fqdn(target)},
+		TTL:     ttl,
+	}, nil
+}
+
+// PTRRRSetsForPrefix creates the PTR RRSets of all the addresses of a CIDR block inside a reverse zone.
+// The target function returns the target of the PTR record for an address, an empty target skips the address.
+func PTRRRSetsForPrefix(zone string, prefix netip.Prefix, ttl int, target func(addr netip.Addr) string) ([]RRSet, error) {
+	if !prefix.IsValid() {
+		return nil, errors.New("invalid prefix")
+	}
+
+	prefix = prefix.Masked()
+
+	if prefix.Addr().BitLen()-prefix.Bits() > 16 {
+		return nil, fmt.Errorf("prefix too large: %s (maximum %d addresses)", prefix, maxPTRPrefixSize)
+	}
+
+	var rrSets []RRSet
+
+	for addr := prefix.Addr(); addr.IsValid() && prefix.Contains(addr); addr = addr.Next() {
+		t := target(addr)
+		if t == "" {
+			continue
+		}
+
+		rrSet, err := NewPTRRRSet(zone, addr, t, ttl)
+		if err != nil {
+			return nil, err
+		}
+
+		rrSets = append(rrSets, rrSet)
+	}
+
+	return rrSets, nil
+}
+
+// SetPTR creates or replaces the PTR RRSet of an IP address inside a reverse zone.
+func (s *RecordsService) SetPTR(ctx context.Context, zone string, addr netip.Addr, target string, ttl int) (*RRSet, error) {
+	rrSet, err := NewPTRRRSet(zone, addr, target, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.upsert(ctx, rrSet)
+}
+
+// DeletePTR deletes the PTR RRSet of an IP address inside a reverse zone.
+func (s *RecordsService) DeletePTR(ctx context.Context, zone string, addr netip.Addr) error {
+	subName, err := ReverseSubName(zone, addr)
+	if err != nil {
+		return err
+	}
+
+	return s.Delete(ctx, zone, subName, "PTR")
+}
@@ -0,0 +1,111 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseSubName(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		zone     string
+		addr     string
+		expected string
+	}{
+		{
+			desc:     "IPv4",
+			zone:     "10.10.in-addr.arpa",
+			addr:     "10.10.20.30",
+			expected: "30.20",
+		},
+		{
+			desc:     "IPv6",
+			zone:     "8.b.d.0.1.0.0.2.ip6.arpa",
+			addr:     "2001:db8::1",
+			expected: "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			subName, err := ReverseSubName(test.zone, netip.MustParseAddr(test.addr))
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, subName)
+		})
+	}
+}
+
+func TestReverseSubName_outside(t *testing.T) {
+	_, err := ReverseSubName("10.10.in-addr.arpa", netip.MustParseAddr("10.20.0.1"))
+	require.Error(t, err)
+}
+
+func TestPTRRRSetsForPrefix(t *testing.T) {
+	rrSets, err := PTRRRSetsForPrefix("10.10.in-addr.arpa", netip.MustParsePrefix("10.10.20.0/30"), 3600, func(addr netip.Addr) string {
+		if addr.As4()[3] == 0 {
+			return ""
+		}
+
+		return "host-" + addr.String() + ".example.com"
+	})
+	require.NoError(t, err)
+
+	expected := []RRSet{
+		{Domain: "10.10.in-addr.arpa", SubName: "1.20", Type: "PTR", Records: []string{"host-10.10.20.1.example.com."}, TTL: 3600},
+		{Domain: "10.10.in-addr.arpa", SubName: "2.20", Type: "PTR", Records: []string{"host-10.10.20.2.example.com."}, TTL: 3600},
+		{Domain: "10.10.in-addr.arpa", SubName: "3.20", Type: "PTR", Records: []string{"host-10.10.20.3.example.com."}, TTL: 3600},
+	}
+	assert.Equal(t, expected, rrSets)
+}
+
+func TestPTRRRSetsForPrefix_tooLarge(t *testing.T) {
+	_, err := PTRRRSetsForPrefix("10.in-addr.arpa", netip.MustParsePrefix("10.0.0.0/8"), 3600, func(netip.Addr) string { return "example.com" })
+	require.Error(t, err)
+}
+
+func TestRecordsService_SetPTR(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/10.10.in-addr.arpa/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut {
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var rrSets []RRSet
+		err := json.NewDecoder(req.Body).Decode(&rrSets)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(rw).Encode(rrSets)
+	})
+
+	rrSet, err := client.Records.SetPTR(context.Background(), "10.10.in-addr.arpa", netip.MustParseAddr("10.10.20.30"), "host.example.com", 3600)
+	require.NoError(t, err)
+
+	expected := &RRSet{
+		Domain:  "10.10.in-addr.arpa",
+		SubName: "30.20",
+		Type:    "PTR",
+		Records: []string{"host.example.com."},
+		TTL:     3600,
+	}
+	assert.Equal(t, expected, rrSet)
+}
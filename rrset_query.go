@@ -0,0 +1,140 @@
+package desec
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var rrTypePattern = regexp.MustCompile(`^[A-Z][A-Z0-9-]*$`)
+
+// RRSetSelector selects the RRSets listed by the API: RRSetFilter or RRSetQuery.
+// A nil selector selects all the RRSets.
+type RRSetSelector interface {
+	// Values returns the query parameters of the listing.
+	Values() (url.Values, error)
+}
+
+// Values implements RRSetSelector.
+func (f *RRSetFilter) Values() (url.Values, error) {
+	queryValues := url.Values{}
+
+	if f == nil {
+		return queryValues, nil
+	}
+
+	if f.Type != IgnoreFilter {
+		queryValues.Set("type", f.Type)
+	}
+
+	if f.SubName != IgnoreFilter {
+		subName, err := toASCII(f.SubName)
+		if err != nil {
+			return nil, err
+		}
+
+		queryValues.Set("subname", subName)
+	}
+
+	return queryValues, nil
+}
+
+// RRSetQuery a builder of the query parameters of the RRSets listing.
+// The parameters are validated when the query is used, the first invalid parameters are reported together.
+//
+//	query := desec.NewRRSetQuery().Type("A").SubName("www")
+//	rrSets, err := client.Records.GetAll(ctx, "example.com", query)
+//
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#filtering
+type RRSetQuery struct {
+	values url.Values
+	errs   []error
+}
+
+// NewRRSetQuery creates a new RRSetQuery, without parameters: all the RRSets are selected.
+func NewRRSetQuery() *RRSetQuery {
+	return &RRSetQuery{values: url.Values{}}
+}
+
+// Type only selects the RRSets of the type (ex: "A", "txt").
+func (q *RRSetQuery) Type(rrType string) *RRSetQuery {
+	rrType = strings.ToUpper(rrType)
+
+	if !rrTypePattern.MatchString(rrType) {
+		q.errs = append(q.errs, fmt.Errorf("invalid type %q", rrType))
+		return q
+	}
+
+	return q.Param("type", rrType)
+}
+
+// SubName only selects the RRSets of the subname ("" or "@" for the zone apex).
+func (q *RRSetQuery) SubName(subName string) *RRSetQuery {
+	subName, err := toASCII(normalizeSubName(subName))
+	if err != nil {
+		q.errs = append(q.errs, fmt.Errorf("invalid subname: %w", err))
+		return q
+	}
+
+	return q.Param("subname", subName)
+}
+
+// Cursor selects a page of the RRSets (pagination).
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#pagination
+func (q *RRSetQuery) Cursor(cursor string) *RRSetQuery {
+	return q.Param("cursor", cursor)
+}
+
+// PageSize sets the number of RRSets of a page (pagination), the default is PageSize.
+// When the RRSets are retrieved page by page, the page size is reduced if a page is too large (ClientOptions.MaxResponseSize).
+func (q *RRSetQuery) PageSize(size int) *RRSetQuery {
+	if size <= 0 {
+		q.errs = append(q.errs, fmt.Errorf("invalid page size: %d", size))
+		return q
+	}
+
+	return q.Param(pageSizeParam, strconv.Itoa(size))
+}
+
+// Param sets a query parameter, ex: a filter supported by the API but not yet by the library.
+// The last value of a parameter wins.
+func (q *RRSetQuery) Param(name, value string) *RRSetQuery {
+	if name == "" {
+		q.errs = append(q.errs, errors.New("empty parameter name"))
+		return q
+	}
+
+	q.values.Set(name, value)
+
+	return q
+}
+
+// Values implements RRSetSelector.
+func (q *RRSetQuery) Values() (url.Values, error) {
+	if q == nil {
+		return url.Values{}, nil
+	}
+
+	if len(q.errs) > 0 {
+		return nil, fmt.Errorf("invalid RRSet query: %w", errors.Join(q.errs...))
+	}
+
+	values := url.Values{}
+	for name, v := range q.values {
+		values[name] = append([]string(nil), v...)
+	}
+
+	return values, nil
+}
+
+// selectorValues returns the query parameters of a selector, nil selects all the RRSets.
+func selectorValues(selector RRSetSelector) (url.Values, error) {
+	if selector == nil {
+		return url.Values{}, nil
+	}
+
+	return selector.Values()
+}
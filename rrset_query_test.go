@@ -0,0 +1,102 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRRSetFilter_Values(t *testing.T) {
+	values, err := (&RRSetFilter{Type: "A", SubName: "bücher"}).Values()
+	require.NoError(t, err)
+
+	assert.Equal(t, url.Values{"type": {"A"}, "subname": {"xn--bcher-kva"}}, values)
+
+	values, err = (&RRSetFilter{Type: IgnoreFilter, SubName: IgnoreFilter}).Values()
+	require.NoError(t, err)
+
+	assert.Equal(t, url.Values{}, values)
+}
+
+func TestRRSetQuery_Values(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		query    *RRSetQuery
+		expected url.Values
+	}{
+		{
+			desc:     "empty",
+			query:    NewRRSetQuery(),
+			expected: url.Values{},
+		},
+		{
+			desc:     "nil",
+			query:    nil,
+			expected: url.Values{},
+		},
+		{
+			desc:     "type and subname",
+			query:    NewRRSetQuery().Type("txt").SubName("www"),
+			expected: url.Values{"type": {"TXT"}, "subname": {"www"}},
+		},
+		{
+			desc:     "apex",
+			query:    NewRRSetQuery().SubName(ApexZone),
+			expected: url.Values{"subname": {""}},
+		},
+		{
+			desc:     "IDN subname",
+			query:    NewRRSetQuery().SubName("bücher"),
+			expected: url.Values{"subname": {"xn--bcher-kva"}},
+		},
+		{
+			desc:     "cursor and custom parameter",
+			query:    NewRRSetQuery().Cursor("abc").Param("future", "1"),
+			expected: url.Values{"cursor": {"abc"}, "future": {"1"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			values, err := test.query.Values()
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestRRSetQuery_Values_errors(t *testing.T) {
+	_, err := NewRRSetQuery().Type("A A").Param("", "x").Values()
+	require.EqualError(t, err, "invalid RRSet query: invalid type \"A A\"\nempty parameter name")
+}
+
+func TestRecordsService_GetAll_query(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var query url.Values
+
+	mux.HandleFunc("GET /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		query = req.URL.Query()
+
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	_, err := client.Records.GetAll(context.Background(), "example.com", NewRRSetQuery().Type("MX").SubName("@"))
+	require.NoError(t, err)
+
+	assert.Equal(t, url.Values{"type": {"MX"}, "subname": {""}}, query)
+
+	_, err = client.Records.GetAll(context.Background(), "example.com", NewRRSetQuery().Type("#"))
+	require.Error(t, err)
+}
@@ -0,0 +1,103 @@
+package desec
+
+import "strings"
+
+// RRSet types supported by deSEC.
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#supported-types
+const (
+	RRTypeA          = "A"
+	RRTypeAAAA       = "AAAA"
+	RRTypeAFSDB      = "AFSDB"
+	RRTypeAPL        = "APL"
+	RRTypeCAA        = "CAA"
+	RRTypeCDNSKEY    = "CDNSKEY"
+	RRTypeCDS        = "CDS"
+	RRTypeCERT       = "CERT"
+	RRTypeCNAME      = "CNAME"
+	RRTypeCSYNC      = "CSYNC"
+	RRTypeDHCID      = "DHCID"
+	RRTypeDLV        = "DLV"
+	RRTypeDNAME      = "DNAME"
+	RRTypeDNSKEY     = "DNSKEY"
+	RRTypeDS         = "DS"
+	RRTypeEUI48      = "EUI48"
+	RRTypeEUI64      = "EUI64"
+	RRTypeHINFO      = "HINFO"
+	RRTypeHTTPS      = "HTTPS"
+	RRTypeKX         = "KX"
+	RRTypeL32        = "L32"
+	RRTypeL64        = "L64"
+	RRTypeLOC        = "LOC"
+	RRTypeLP         = "LP"
+	RRTypeMX         = "MX"
+	RRTypeNAPTR      = "NAPTR"
+	RRTypeNID        = "NID"
+	RRTypeNS         = "NS"
+	RRTypeOPENPGPKEY = "OPENPGPKEY"
+	RRTypePTR        = "PTR"
+	RRTypeRP         = "RP"
+	RRTypeSMIMEA     = "SMIMEA"
+	RRTypeSPF        = "SPF"
+	RRTypeSRV        = "SRV"
+	RRTypeSSHFP      = "SSHFP"
+	RRTypeSVCB       = "SVCB"
+	RRTypeTLSA       = "TLSA"
+	RRTypeTXT        = "TXT"
+	RRTypeURI        = "URI"
+)
+
+// RRSet types managed by deSEC: they cannot be written through the API.
+const (
+	RRTypeSOA        = "SOA"
+	RRTypeRRSIG      = "RRSIG"
+	RRTypeNSEC       = "NSEC"
+	RRTypeNSEC3      = "NSEC3"
+	RRTypeNSEC3PARAM = "NSEC3PARAM"
+)
+
+var supportedTypes = map[string]struct{}{
+	RRTypeA: {}, RRTypeAAAA: {}, RRTypeAFSDB: {}, RRTypeAPL: {}, RRTypeCAA: {}, RRTypeCDNSKEY: {}, RRTypeCDS: {},
+	RRTypeCERT: {}, RRTypeCNAME: {}, RRTypeCSYNC: {}, RRTypeDHCID: {}, RRTypeDLV: {}, RRTypeDNAME: {}, RRTypeDNSKEY: {},
+	RRTypeDS: {}, RRTypeEUI48: {}, RRTypeEUI64: {}, RRTypeHINFO: {}, RRTypeHTTPS: {}, RRTypeKX: {}, RRTypeL32: {},
+	RRTypeL64: {}, RRTypeLOC: {}, RRTypeLP: {}, RRTypeMX: {}, RRTypeNAPTR: {}, RRTypeNID: {}, RRTypeNS: {},
+	RRTypeOPENPGPKEY: {}, RRTypePTR: {}, RRTypeRP: {}, RRTypeSMIMEA: {}, RRTypeSPF: {}, RRTypeSRV: {}, RRTypeSSHFP: {},
+	RRTypeSVCB: {}, RRTypeTLSA: {}, RRTypeTXT: {}, RRTypeURI: {},
+}
+
+var managedTypes = map[string]struct{}{
+	RRTypeSOA: {}, RRTypeRRSIG: {}, RRTypeNSEC: {}, RRTypeNSEC3: {}, RRTypeNSEC3PARAM: {},
+}
+
+// IsSupportedType returns true if the RRSet type can be written through the API.
+func IsSupportedType(recordType string) bool {
+	_, ok := supportedTypes[strings.ToUpper(recordType)]
+	return ok
+}
+
+// IsManagedType returns true if the RRSet type is managed by deSEC (SOA, DNSSEC records),
+// and then rejected by the API.
+func IsManagedType(recordType string) bool {
+	_, ok := managedTypes[strings.ToUpper(recordType)]
+	return ok
+}
+
+// checkType rejects the managed types before calling the API.
+// The unknown types are left to the API: the list of the supported types can grow.
+func checkType(recordType string) error {
+	if IsManagedType(recordType) {
+		return &UnsupportedTypeError{Type: recordType}
+	}
+
+	return nil
+}
+
+func checkTypes(rrSets []RRSet) error {
+	for _, rrSet := range rrSets {
+		err := checkType(rrSet.Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsSupportedType(t *testing.T) {
+	testCases := []struct {
+		recordType string
+		supported  bool
+		managed    bool
+	}{
+		{recordType: RRTypeA, supported: true},
+		{recordType: "txt", supported: true},
+		{recordType: RRTypeHTTPS, supported: true},
+		{recordType: RRTypeDNSKEY, supported: true},
+		{recordType: RRTypeSOA, managed: true},
+		{recordType: "nsec3param", managed: true},
+		{recordType: RRTypeRRSIG, managed: true},
+		{recordType: "ALIAS"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.recordType, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.supported, IsSupportedType(test.recordType))
+			assert.Equal(t, test.managed, IsManagedType(test.recordType))
+		})
+	}
+}
+
+func TestRecordsService_managedType(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+		t.Errorf("unexpected API call: %s %s", req.Method, req.URL)
+	})
+
+	_, err := client.Records.Create(context.Background(), RRSet{Domain: "example.com", Type: RRTypeSOA})
+	require.ErrorAs(t, err, new(*UnsupportedTypeError))
+
+	_, err = client.Records.Replace(context.Background(), "example.com", "", RRTypeNSEC3PARAM, RRSet{})
+	require.ErrorAs(t, err, new(*UnsupportedTypeError))
+
+	_, err = client.Records.BulkCreate(context.Background(), "example.com", []RRSet{
+		{Type: RRTypeA, Records: []string{"10.0.0.1"}},
+		{Type: RRTypeRRSIG},
+	})
+	require.EqualError(t, err, "the RRSet type RRSIG is managed by deSEC and cannot be written")
+}
@@ -0,0 +1,171 @@
+package desec
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+)
+
+// Priority the priority of the API calls of a context (WithPriority), used by PriorityScheduler.
+type Priority int
+
+// Priorities.
+const (
+	// PriorityLow background work (ex: synchronization, inventory).
+	PriorityLow Priority = -1
+	// PriorityNormal the default priority.
+	PriorityNormal Priority = 0
+	// PriorityHigh time-sensitive work (ex: ACME challenge records).
+	PriorityHigh Priority = 1
+)
+
+type priorityKey struct{}
+
+// WithPriority returns a context where the API calls have the priority p (PriorityScheduler).
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+func priorityFrom(ctx context.Context) Priority {
+	p, _ := ctx.Value(priorityKey{}).(Priority)
+	return p
+}
+
+// PriorityScheduler a RateLimiter that serves the API calls waiting for the next limiter by priority (WithPriority),
+// then in order of arrival: during throttling, the high priority calls don't wait behind the background work.
+type PriorityScheduler struct {
+	next RateLimiter
+
+	mu    sync.Mutex
+	busy  bool
+	queue waiterQueue
+	seq   uint64
+}
+
+// NewPriorityScheduler creates a new PriorityScheduler.
+// The next limiter is the limiter shared by the API calls (ex: AdaptiveLimiter, golang.org/x/time/rate.Limiter).
+func NewPriorityScheduler(next RateLimiter) *PriorityScheduler {
+	return &PriorityScheduler{next: next}
+}
+
+// Wait blocks until the calls with a higher priority have been served and the next limiter allows the call,
+// or the context is done.
+func (s *PriorityScheduler) Wait(ctx context.Context) error {
+	err := s.acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer s.release()
+
+	if s.next == nil {
+		return nil
+	}
+
+	return s.next.Wait(ctx)
+}
+
+// ObserveRateLimit forwards the rate limit to the next limiter, if it's a RateLimitObserver.
+func (s *PriorityScheduler) ObserveRateLimit(limit RateLimit) {
+	if observer, ok := s.next.(RateLimitObserver); ok {
+		observer.ObserveRateLimit(limit)
+	}
+}
+
+// acquire waits for the turn of the call.
+func (s *PriorityScheduler) acquire(ctx context.Context) error {
+	s.mu.Lock()
+
+	if !s.busy {
+		s.busy = true
+		s.mu.Unlock()
+
+		return nil
+	}
+
+	s.seq++
+	w := &waiter{priority: priorityFrom(ctx), seq: s.seq, ready: make(chan struct{})}
+	heap.Push(&s.queue, w)
+
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+
+	case <-ctx.Done():
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if w.index >= 0 {
+			heap.Remove(&s.queue, w.index)
+			return ctx.Err()
+		}
+
+		// The turn has been given concurrently: it's passed to the next call.
+		s.releaseLocked()
+
+		return ctx.Err()
+	}
+}
+
+// release gives the turn to the next call.
+func (s *PriorityScheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.releaseLocked()
+}
+
+func (s *PriorityScheduler) releaseLocked() {
+	if s.queue.Len() == 0 {
+		s.busy = false
+		return
+	}
+
+	w := heap.Pop(&s.queue).(*waiter)
+	close(w.ready)
+}
+
+type waiter struct {
+	priority Priority
+	seq      uint64
+	ready    chan struct{}
+	index    int
+}
+
+// waiterQueue a heap of waiters, by priority then in order of arrival.
+type waiterQueue []*waiter
+
+func (q waiterQueue) Len() int { return len(q) }
+
+func (q waiterQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+
+	return q[i].seq < q[j].seq
+}
+
+func (q waiterQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waiterQueue) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waiterQueue) Pop() any {
+	old := *q
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	w.index = -1
+	*q = old[:n-1]
+
+	return w
+}
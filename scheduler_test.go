@@ -0,0 +1,98 @@
+package desec
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gateLimiter allows a call for each value sent to gate, and records the priorities of the allowed calls.
+type gateLimiter struct {
+	gate    chan struct{}
+	allowed []Priority
+}
+
+func (l *gateLimiter) Wait(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-l.gate:
+		l.allowed = append(l.allowed, priorityFrom(ctx))
+		return nil
+	}
+}
+
+func (s *PriorityScheduler) isBusy() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.busy
+}
+
+func (s *PriorityScheduler) queued() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.queue.Len()
+}
+
+func TestPriorityScheduler(t *testing.T) {
+	limiter := &gateLimiter{gate: make(chan struct{})}
+	scheduler := NewPriorityScheduler(limiter)
+
+	errs := make(chan error)
+
+	wait := func(p Priority) {
+		go func() { errs <- scheduler.Wait(WithPriority(context.Background(), p)) }()
+	}
+
+	wait(PriorityNormal)
+	require.Eventually(t, func() bool { return scheduler.isBusy() }, time.Second, time.Millisecond)
+
+	wait(PriorityLow)
+	require.Eventually(t, func() bool { return scheduler.queued() == 1 }, time.Second, time.Millisecond)
+
+	wait(PriorityNormal)
+	require.Eventually(t, func() bool { return scheduler.queued() == 2 }, time.Second, time.Millisecond)
+
+	wait(PriorityHigh)
+	require.Eventually(t, func() bool { return scheduler.queued() == 3 }, time.Second, time.Millisecond)
+
+	for range 4 {
+		limiter.gate <- struct{}{}
+		require.NoError(t, <-errs)
+	}
+
+	assert.Equal(t, []Priority{PriorityNormal, PriorityHigh, PriorityNormal, PriorityLow}, limiter.allowed)
+	assert.False(t, scheduler.isBusy())
+}
+
+func TestPriorityScheduler_canceled(t *testing.T) {
+	limiter := &gateLimiter{gate: make(chan struct{})}
+	scheduler := NewPriorityScheduler(limiter)
+
+	errs := make(chan error)
+
+	go func() { errs <- scheduler.Wait(context.Background()) }()
+
+	require.Eventually(t, func() bool { return scheduler.isBusy() }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() { errs <- scheduler.Wait(WithPriority(ctx, PriorityHigh)) }()
+
+	require.Eventually(t, func() bool { return scheduler.queued() == 1 }, time.Second, time.Millisecond)
+
+	cancel()
+
+	require.ErrorIs(t, <-errs, context.Canceled)
+	assert.Equal(t, 0, scheduler.queued())
+
+	limiter.gate <- struct{}{}
+	require.NoError(t, <-errs)
+
+	assert.False(t, scheduler.isBusy())
+}
@@ -0,0 +1,71 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// ttlPatch the body of a bulk update of the TTL only: the records are left unchanged by the API.
+type ttlPatch struct {
+	SubName string `json:"subname"`
+	Type    string `json:"type"`
+	TTL     int    `json:"ttl"`
+}
+
+// SetTTL sets the TTL of the RRSets matching the filter (all the RRSets if the filter is nil),
+// ex: to lower the TTLs before a planned migration, and to restore them after.
+// The RRSets are updated with a single bulk request, the RRSets already using the TTL and the managed types are skipped.
+// Only the TTL is sent: the records changed since the read are kept.
+// Returns the updated RRSets.
+func (s *RecordsService) SetTTL(ctx context.Context, domainName string, filter RRSetSelector, ttl int) ([]RRSet, error) {
+	current, err := s.GetAll(ctx, domainName, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current state: %w", err)
+	}
+
+	var rrSets []RRSet
+
+	for _, rrSet := range current {
+		if rrSet.TTL == ttl || IsManagedType(rrSet.Type) {
+			continue
+		}
+
+		rrSets = append(rrSets, RRSet{SubName: rrSet.SubName, Type: rrSet.Type, TTL: ttl})
+	}
+
+	if len(rrSets) == 0 {
+		return nil, nil
+	}
+
+	rrSets, err = encodeRRSets(rrSets)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.client.applyTTLs(ctx, domainName, false, rrSets)
+	if err != nil {
+		return nil, err
+	}
+
+	patches := make([]ttlPatch, len(rrSets))
+	for i, rrSet := range rrSets {
+		patches[i] = ttlPatch{SubName: rrSet.SubName, Type: rrSet.Type, TTL: rrSet.TTL}
+	}
+
+	endpoint, err := s.client.createEndpoint("domains", domainName, "rrsets")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+	}
+
+	results, _, err := doWithErrorHandler[[]RRSet](ctx, s.client, http.MethodPatch, endpoint, patches, func(resp *http.Response) error {
+		return handleBulkError(resp, rrSets)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.client.decodeRRSets(results)
+
+	return results, nil
+}
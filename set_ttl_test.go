@@ -0,0 +1,69 @@
+package desec
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsService_SetTTL(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "A", req.URL.Query().Get("type"))
+
+		_, _ = rw.Write([]byte(`[{"subname":"www","type":"A","ttl":3600,"records":["10.0.0.1"]},{"subname":"api","type":"A","ttl":300,"records":["10.0.0.2"]}]`))
+	})
+
+	mux.HandleFunc("PATCH /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// only the TTL is sent, the records are left unchanged.
+		if !assert.JSONEq(t, `[{"subname":"www","type":"A","ttl":300}]`, string(body)) {
+			http.Error(rw, "invalid body", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`[{"subname":"www","type":"A","ttl":300,"records":["10.0.0.3"]}]`))
+	})
+
+	filter := FilterRRSetOnlyOnType("A")
+
+	updated, err := client.Records.SetTTL(context.Background(), "example.com", &filter, 300)
+	require.NoError(t, err)
+
+	expected := []RRSet{{SubName: "www", Type: "A", TTL: 300, Records: []string{"10.0.0.3"}}}
+	assert.Equal(t, expected, updated)
+}
+
+func TestRecordsService_SetTTL_noChanges(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"subname":"www","type":"A","ttl":300,"records":["10.0.0.1"]}]`))
+	})
+
+	updated, err := client.Records.SetTTL(context.Background(), "example.com", nil, 300)
+	require.NoError(t, err)
+
+	assert.Empty(t, updated)
+}
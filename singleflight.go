@@ -0,0 +1,59 @@
+package desec
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// sharedResponse a response shared by the callers of identical in-flight requests.
+type sharedResponse struct {
+	resp *http.Response
+	body []byte
+}
+
+// singleflightDoer coalesces identical in-flight GET requests.
+type singleflightDoer struct {
+	next  httpDoer
+	group singleflight.Group
+}
+
+func (d *singleflightDoer) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return d.next.Do(req)
+	}
+
+	// The token is part of the key: the same URL can return different results depending on the token.
+	key := req.Header.Get("Authorization") + " " + req.URL.String()
+
+	v, err, _ := d.group.Do(key, func() (any, error) {
+		resp, err := d.next.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		defer func() { _ = resp.Body.Close() }()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return &sharedResponse{resp: resp, body: body}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	shared := v.(*sharedResponse)
+
+	resp := new(http.Response)
+	*resp = *shared.resp
+	resp.Header = shared.resp.Header.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(shared.body))
+	resp.Request = req
+
+	return resp, nil
+}
@@ -0,0 +1,61 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_deduplicateGETs(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	opts := NewDefaultClientOptions()
+	opts.DeduplicateGETs = true
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	var calls atomic.Int32
+
+	mux.HandleFunc("/domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		calls.Add(1)
+
+		// keeps the request in-flight.
+		time.Sleep(100 * time.Millisecond)
+
+		_, _ = rw.Write([]byte(`{"name":"example.com"}`))
+	})
+
+	var wg sync.WaitGroup
+
+	domains := make([]*Domain, 10)
+	errs := make([]error, 10)
+
+	for i := range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			domains[i], errs[i] = client.Domains.Get(context.Background(), "example.com")
+		}()
+	}
+
+	wg.Wait()
+
+	for i := range 10 {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "example.com", domains[i].Name)
+	}
+
+	assert.Less(t, calls.Load(), int32(10))
+}
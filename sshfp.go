@@ -0,0 +1,99 @@
+package desec
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // SHA-1 is one of the fingerprint types defined by RFC 4255.
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHFPFingerprintType the fingerprint type of SSHFP records.
+// https://www.iana.org/assignments/dns-sshfp-rr-parameters/dns-sshfp-rr-parameters.xhtml
+type SSHFPFingerprintType int
+
+const (
+	// SSHFPSHA1 SHA-1 fingerprint.
+	SSHFPSHA1 SSHFPFingerprintType = 1
+	// SSHFPSHA256 SHA-256 fingerprint.
+	SSHFPSHA256 SSHFPFingerprintType = 2
+)
+
+// sshfpAlgorithms the SSHFP algorithm numbers by SSH key type.
+// https://www.iana.org/assignments/dns-sshfp-rr-parameters/dns-sshfp-rr-parameters.xhtml
+var sshfpAlgorithms = map[string]int{
+	ssh.KeyAlgoRSA:      1,
+	ssh.KeyAlgoDSA:      2, //nolint:staticcheck // DSA is still a valid SSHFP algorithm.
+	ssh.KeyAlgoECDSA256: 3,
+	ssh.KeyAlgoECDSA384: 3,
+	ssh.KeyAlgoECDSA521: 3,
+	ssh.KeyAlgoED25519:  4,
+}
+
+// SSHFPRecord returns the content of the SSHFP record of an SSH public key.
+func SSHFPRecord(key ssh.PublicKey, fpType SSHFPFingerprintType) (string, error) {
+	if key == nil {
+		return "", errors.New("missing key")
+	}
+
+	algorithm, ok := sshfpAlgorithms[key.Type()]
+	if !ok {
+		return "", fmt.Errorf("unsupported key type: %s", key.Type())
+	}
+
+	var fingerprint []byte
+
+	switch fpType {
+	case SSHFPSHA1:
+		sum := sha1.Sum(key.Marshal()) //nolint:gosec // SHA-1 is one of the fingerprint types defined by RFC 4255.
+		fingerprint = sum[:]
+
+	case SSHFPSHA256:
+		sum := sha256.Sum256(key.Marshal())
+		fingerprint = sum[:]
+
+	default:
+		return "", fmt.Errorf("unsupported fingerprint type: %d", fpType)
+	}
+
+	return fmt.Sprintf("%d %d %s", algorithm, fpType, hex.EncodeToString(fingerprint)), nil
+}
+
+// NewSSHFPRRSet creates an SSHFP RRSet with the SHA-256 fingerprints of SSH host keys.
+func NewSSHFPRRSet(domainName, subName string, ttl int, keys ...ssh.PublicKey) (RRSet, error) {
+	if len(keys) == 0 {
+		return RRSet{}, errors.New("no keys")
+	}
+
+	records := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		record, err := SSHFPRecord(key, SSHFPSHA256)
+		if err != nil {
+			return RRSet{}, err
+		}
+
+		records = append(records, record)
+	}
+
+	return RRSet{
+		Domain:  domainName,
+		SubName: subName,
+		Type:    "SSHFP",
+		Records: records,
+		TTL:     ttl,
+	}, nil
+}
+
+// PublishSSHFP creates or replaces the SSHFP RRSet of a host with the SHA-256 fingerprints of its SSH host keys.
+func (s *RecordsService) PublishSSHFP(ctx context.Context, domainName, subName string, ttl int, keys ...ssh.PublicKey) (*RRSet, error) {
+	rrSet, err := NewSSHFPRRSet(domainName, subName, ttl, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.upsert(ctx, rrSet)
+}
@@ -0,0 +1,101 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+const testSSHHostKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAILlVu2xrUU+HNCPGPcNzIL0jitPCoNv0ylEWV9DZ6N5J"
+
+func TestSSHFPRecord(t *testing.T) {
+	key := mustParseSSHKey(t, testSSHHostKey)
+
+	testCases := []struct {
+		desc     string
+		fpType   SSHFPFingerprintType
+		expected string
+	}{
+		{
+			desc:     "SHA-1",
+			fpType:   SSHFPSHA1,
+			expected: "4 1 75ce5674a9d3c89a8982d406df5b8223f166a9c1",
+		},
+		{
+			desc:     "SHA-256",
+			fpType:   SSHFPSHA256,
+			expected: "4 2 2fa056877c71724d00655b2684434e7e3b0a950d362ac37592e47550c6881636",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			record, err := SSHFPRecord(key, test.fpType)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, record)
+		})
+	}
+}
+
+func TestSSHFPRecord_errors(t *testing.T) {
+	_, err := SSHFPRecord(nil, SSHFPSHA256)
+	require.Error(t, err)
+
+	_, err = SSHFPRecord(mustParseSSHKey(t, testSSHHostKey), 3)
+	require.Error(t, err)
+}
+
+func TestRecordsService_PublishSSHFP(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut {
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var rrSets []RRSet
+		err := json.NewDecoder(req.Body).Decode(&rrSets)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(rw).Encode(rrSets)
+	})
+
+	rrSet, err := client.Records.PublishSSHFP(context.Background(), "example.dedyn.io", "host", 3600, mustParseSSHKey(t, testSSHHostKey))
+	require.NoError(t, err)
+
+	expected := &RRSet{
+		Domain:  "example.dedyn.io",
+		SubName: "host",
+		Type:    "SSHFP",
+		Records: []string{"4 2 2fa056877c71724d00655b2684434e7e3b0a950d362ac37592e47550c6881636"},
+		TTL:     3600,
+	}
+	assert.Equal(t, expected, rrSet)
+}
+
+func mustParseSSHKey(t *testing.T, s string) ssh.PublicKey {
+	t.Helper()
+
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(s))
+	require.NoError(t, err)
+
+	return key
+}
@@ -0,0 +1,198 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// RRSetSeq a sequence of RRSets (same shape as iter.Seq[RRSet]).
+type RRSetSeq func(yield func(RRSet) bool)
+
+// rrSetProducer produces RRSets until yield returns false or an error occurs.
+type rrSetProducer func(yield func(RRSet) bool) error
+
+// BulkCreateStream creates new RRSets in bulk, the RRSets are encoded while the request body is sent.
+// The request is not retried.
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#bulk-creation-of-rrsets
+func (s *RecordsService) BulkCreateStream(ctx context.Context, domainName string, rrSets RRSetSeq) ([]RRSet, error) {
+	return s.bulkStream(ctx, http.MethodPost, http.StatusCreated, domainName, seqProducer(rrSets))
+}
+
+// BulkCreateFromReader creates new RRSets in bulk from a JSON array of RRSets, streamed into the request body.
+// The request is not retried.
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#bulk-creation-of-rrsets
+func (s *RecordsService) BulkCreateFromReader(ctx context.Context, domainName string, r io.Reader) ([]RRSet, error) {
+	return s.bulkStream(ctx, http.MethodPost, http.StatusCreated, domainName, readerProducer(r))
+}
+
+// BulkUpdateStream updates RRSets in bulk, the RRSets are encoded while the request body is sent.
+// The request is not retried.
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#bulk-modification-of-rrsets
+func (s *RecordsService) BulkUpdateStream(ctx context.Context, mode UpdateMode, domainName string, rrSets RRSetSeq) ([]RRSet, error) {
+	return s.bulkStream(ctx, string(mode), http.StatusOK, domainName, seqProducer(rrSets))
+}
+
+// BulkUpdateFromReader updates RRSets in bulk from a JSON array of RRSets, streamed into the request body.
+// The request is not retried.
+// https://desec.readthedocs.io/en/latest/dns/rrsets.html#bulk-modification-of-rrsets
+func (s *RecordsService) BulkUpdateFromReader(ctx context.Context, mode UpdateMode, domainName string, r io.Reader) ([]RRSet, error) {
+	return s.bulkStream(ctx, string(mode), http.StatusOK, domainName, readerProducer(r))
+}
+
+func (s *RecordsService) bulkStream(ctx context.Context, method string, expectedStatus int, domainName string, produce rrSetProducer) ([]RRSet, error) {
+	endpoint, err := s.client.createEndpoint("domains", domainName, "rrsets")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+	}
+
+	req, err := s.client.newRequest(ctx, method, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	defer func() { _ = pr.Close() }()
+
+	// With OnlyFields, a RRSet without TTL keeps its current TTL.
+	prepare := func(rrSet *RRSet) error {
+		return s.client.applyTTL(ctx, domainName, method != string(OnlyFields), rrSet)
+	}
+
+	go func() { _ = pw.CloseWithError(writeRRSets(pw, produce, prepare)) }()
+
+	req.Body = pr
+	req.GetBody = nil
+	req.ContentLength = -1
+
+	resp, err := s.client.streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call API: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != expectedStatus {
+		return nil, handleError(resp)
+	}
+
+	var results []RRSet
+	err = s.client.handleResponse(resp, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	s.client.decodeRRSets(results)
+
+	return results, nil
+}
+
+// writeRRSets writes the RRSets as a JSON array, each RRSet is prepared (default and minimum TTLs) after its encoding.
+func writeRRSets(w io.Writer, produce rrSetProducer, prepare func(rrSet *RRSet) error) error {
+	_, err := io.WriteString(w, "[")
+	if err != nil {
+		return err
+	}
+
+	var (
+		writeErr error
+		first    = true
+	)
+
+	err = produce(func(rrSet RRSet) bool {
+		writeErr = writeRRSet(w, rrSet, first, prepare)
+		first = false
+
+		return writeErr == nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if writeErr != nil {
+		return writeErr
+	}
+
+	_, err = io.WriteString(w, "]")
+
+	return err
+}
+
+func writeRRSet(w io.Writer, rrSet RRSet, first bool, prepare func(rrSet *RRSet) error) error {
+	err := checkType(rrSet.Type)
+	if err != nil {
+		return err
+	}
+
+	rrSet, err = encodeRRSet(rrSet)
+	if err != nil {
+		return err
+	}
+
+	err = prepare(&rrSet)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(rrSet)
+	if err != nil {
+		return fmt.Errorf("failed to marshal RRSet: %w", err)
+	}
+
+	if !first {
+		_, err = io.WriteString(w, ",")
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
+
+func seqProducer(rrSets RRSetSeq) rrSetProducer {
+	return func(yield func(RRSet) bool) error {
+		rrSets(yield)
+		return nil
+	}
+}
+
+// readerProducer decodes the RRSets of a JSON array one by one.
+func readerProducer(r io.Reader) rrSetProducer {
+	return func(yield func(RRSet) bool) error {
+		decoder := json.NewDecoder(r)
+
+		token, err := decoder.Token()
+		if err != nil {
+			return fmt.Errorf("failed to read RRSets: %w", err)
+		}
+
+		if token != json.Delim('[') {
+			return errors.New("failed to read RRSets: JSON array expected")
+		}
+
+		for decoder.More() {
+			var rrSet RRSet
+
+			err = decoder.Decode(&rrSet)
+			if err != nil {
+				return fmt.Errorf("failed to read RRSets: %w", err)
+			}
+
+			if !yield(rrSet) {
+				return nil
+			}
+		}
+
+		_, err = decoder.Token()
+		if err != nil {
+			return fmt.Errorf("failed to read RRSets: %w", err)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,95 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupStreamTest(t *testing.T, method string, status int) (*Client, *[]RRSet) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var received []RRSet
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.ContentLength != -1 {
+			http.Error(rw, "streamed body expected", http.StatusBadRequest)
+			return
+		}
+
+		err := json.NewDecoder(req.Body).Decode(&received)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(status)
+		_ = json.NewEncoder(rw).Encode(received)
+	})
+
+	return client, &received
+}
+
+func TestRecordsService_BulkCreateStream(t *testing.T) {
+	client, received := setupStreamTest(t, http.MethodPost, http.StatusCreated)
+
+	seq := func(yield func(RRSet) bool) {
+		for _, sub := range []string{"a", "b", "c"} {
+			if !yield(RRSet{SubName: sub, Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}}) {
+				return
+			}
+		}
+	}
+
+	rrSets, err := client.Records.BulkCreateStream(context.Background(), "example.dedyn.io", seq)
+	require.NoError(t, err)
+
+	assert.Len(t, rrSets, 3)
+
+	require.Len(t, *received, 3)
+	assert.Equal(t, "c", (*received)[2].SubName)
+}
+
+func TestRecordsService_BulkUpdateFromReader(t *testing.T) {
+	client, received := setupStreamTest(t, http.MethodPatch, http.StatusOK)
+
+	r := strings.NewReader(`[{"subname":"www","type":"A","ttl":3600,"records":["10.10.10.10"]},{"subname":"","type":"TXT","ttl":3600,"records":[]}]`)
+
+	rrSets, err := client.Records.BulkUpdateFromReader(context.Background(), OnlyFields, "example.dedyn.io", r)
+	require.NoError(t, err)
+
+	assert.Len(t, rrSets, 2)
+
+	expected := []RRSet{
+		{SubName: "www", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}},
+		{SubName: "", Type: "TXT", TTL: 3600, Records: []string{}},
+	}
+	assert.Equal(t, expected, *received)
+}
+
+func TestRecordsService_BulkCreateFromReader_invalid(t *testing.T) {
+	client, _ := setupStreamTest(t, http.MethodPost, http.StatusCreated)
+
+	r := strings.NewReader(`[{"subname":"www","type":"A","ttl":3600,"records":["10.10.10.10"]},{"subname":`)
+
+	_, err := client.Records.BulkCreateFromReader(context.Background(), "example.dedyn.io", r)
+	require.Error(t, err)
+}
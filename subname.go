@@ -0,0 +1,77 @@
+package desec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxLabels the maximum number of labels of a DNS name.
+const maxLabels = 127
+
+// ValidateSubName checks the syntax of a subname, as validated by deSEC:
+// lowercase letters, digits, hyphens, and underscores, labels of 63 characters at most separated by single dots,
+// and an optional leading wildcard label ("*").
+// The empty subname and ApexZone designate the zone apex, the Unicode subnames are checked once converted to punycode.
+// The length of the full name depends on the domain: it's checked by Lint.
+func ValidateSubName(subName string) error {
+	if subName == "" || subName == ApexZone {
+		return nil
+	}
+
+	ascii, err := toASCII(subName)
+	if err != nil {
+		return &InvalidSubNameError{SubName: subName, Reason: err.Error()}
+	}
+
+	reason := subNameProblem(ascii)
+	if reason != "" {
+		return &InvalidSubNameError{SubName: subName, Reason: reason}
+	}
+
+	return nil
+}
+
+func subNameProblem(subName string) string {
+	if len(subName) > maxNameLength {
+		return fmt.Sprintf("longer than %d characters", maxNameLength)
+	}
+
+	if strings.HasPrefix(subName, ".") || strings.HasSuffix(subName, ".") {
+		return "leading or trailing dot"
+	}
+
+	labels := strings.Split(subName, ".")
+	if len(labels) > maxLabels {
+		return fmt.Sprintf("more than %d labels", maxLabels)
+	}
+
+	for i, label := range labels {
+		if label == "" {
+			return "empty label"
+		}
+
+		if len(label) > maxLabelLength {
+			return fmt.Sprintf("the label %q is longer than %d characters", label, maxLabelLength)
+		}
+
+		if label == "*" {
+			if i > 0 {
+				return "the wildcard must be the leftmost label"
+			}
+
+			continue
+		}
+
+		for _, c := range label {
+			switch {
+			case 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_':
+			case 'A' <= c && c <= 'Z':
+				return "must be lowercase"
+			default:
+				return fmt.Sprintf("invalid character %q in the label %q", c, label)
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,98 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSubName(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		subName  string
+		expected string
+	}{
+		{desc: "apex", subName: ""},
+		{desc: "apex @", subName: ApexZone},
+		{desc: "simple", subName: "www"},
+		{desc: "nested", subName: "a.b-c.d_e"},
+		{desc: "underscore", subName: "_acme-challenge.www"},
+		{desc: "wildcard", subName: "*"},
+		{desc: "nested wildcard", subName: "*.www"},
+		{desc: "IDN", subName: "bücher"},
+		{
+			desc:     "leading dot",
+			subName:  ".www",
+			expected: `invalid subname ".www": leading or trailing dot`,
+		},
+		{
+			desc:     "trailing dot",
+			subName:  "www.",
+			expected: `invalid subname "www.": leading or trailing dot`,
+		},
+		{
+			desc:     "empty label",
+			subName:  "a..b",
+			expected: `invalid subname "a..b": empty label`,
+		},
+		{
+			desc:     "uppercase",
+			subName:  "WWW",
+			expected: `invalid subname "WWW": must be lowercase`,
+		},
+		{
+			desc:     "invalid character",
+			subName:  "w w",
+			expected: `invalid subname "w w": invalid character ' ' in the label "w w"`,
+		},
+		{
+			desc:     "wildcard not leftmost",
+			subName:  "www.*",
+			expected: `invalid subname "www.*": the wildcard must be the leftmost label`,
+		},
+		{
+			desc:     "partial wildcard",
+			subName:  "w*",
+			expected: `invalid subname "w*": invalid character '*' in the label "w*"`,
+		},
+		{
+			desc:     "label too long",
+			subName:  strings.Repeat("a", 64),
+			expected: `invalid subname "` + strings.Repeat("a", 64) + `": the label "` + strings.Repeat("a", 64) + `" is longer than 63 characters`,
+		},
+		{
+			desc:     "too long",
+			subName:  strings.Repeat("a.", 127) + "a",
+			expected: `invalid subname "` + strings.Repeat("a.", 127) + `a": longer than 253 characters`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			err := ValidateSubName(test.subName)
+			if test.expected == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestRecordsService_Create_invalidSubName(t *testing.T) {
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = "http://desec.invalid/"
+
+	_, err := client.Records.Create(context.Background(), RRSet{Domain: "example.com", SubName: "www.", Type: "A", Records: []string{"10.10.10.10"}})
+	require.Error(t, err)
+
+	var subNameErr *InvalidSubNameError
+	require.True(t, errors.As(err, &subNameErr))
+
+	assert.Equal(t, "www.", subNameErr.SubName)
+}
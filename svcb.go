@@ -0,0 +1,204 @@
+package desec
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// SvcParam keys.
+// https://www.iana.org/assignments/dns-svcb/dns-svcb.xhtml
+const (
+	SvcParamMandatory     = "mandatory"
+	SvcParamALPN          = "alpn"
+	SvcParamNoDefaultALPN = "no-default-alpn"
+	SvcParamPort          = "port"
+	SvcParamIPv4Hint      = "ipv4hint"
+	SvcParamECH           = "ech"
+	SvcParamIPv6Hint      = "ipv6hint"
+)
+
+// SvcParams the service parameters of SVCB and HTTPS records.
+// https://www.rfc-editor.org/rfc/rfc9460.html#section-7
+type SvcParams struct {
+	// Mandatory the keys that must be understood by the clients.
+	Mandatory []string
+	// ALPN the supported protocols (ex: h2, h3).
+	ALPN []string
+	// NoDefaultALPN the default protocol is not supported.
+	NoDefaultALPN bool
+	// Port the alternative port (0 means not set).
+	Port uint16
+	// IPv4Hint IPv4 address hints.
+	IPv4Hint []netip.Addr
+	// ECH Encrypted ClientHello configuration list.
+	ECH []byte
+	// IPv6Hint IPv6 address hints.
+	IPv6Hint []netip.Addr
+}
+
+// SVCBRecord a SVCB or HTTPS record.
+// https://www.rfc-editor.org/rfc/rfc9460.html
+type SVCBRecord struct {
+	// Priority 0 is the AliasMode, other values are the ServiceMode.
+	Priority uint16
+	// Target the target name ("." means the owner name).
+	Target string
+	Params SvcParams
+}
+
+// Format returns the presentation format of the record.
+func (r SVCBRecord) Format() (string, error) {
+	target := r.Target
+	if target == "" {
+		target = "."
+	}
+
+	if target != "." {
+		target = dns.Fqdn(target)
+	}
+
+	params, err := r.Params.format()
+	if err != nil {
+		return "", err
+	}
+
+	if r.Priority == 0 && len(params) > 0 {
+		return "", errors.New("SvcParams are not allowed in AliasMode (priority 0)")
+	}
+
+	return strings.Join(append([]string{strconv.Itoa(int(r.Priority)), target}, params...), " "), nil
+}
+
+func (p SvcParams) format() ([]string, error) {
+	var params []string
+
+	if len(p.Mandatory) > 0 {
+		for _, key := range p.Mandatory {
+			if !p.has(key) {
+				return nil, fmt.Errorf("mandatory key %q is not set", key)
+			}
+		}
+
+		params = append(params, SvcParamMandatory+"="+strings.Join(p.Mandatory, ","))
+	}
+
+	if len(p.ALPN) > 0 {
+		for _, id := range p.ALPN {
+			if id == "" || strings.ContainsAny(id, `,\" `) {
+				return nil, fmt.Errorf("invalid ALPN identifier: %q", id)
+			}
+		}
+
+		params = append(params, SvcParamALPN+"="+strings.Join(p.ALPN, ","))
+	}
+
+	if p.NoDefaultALPN {
+		if len(p.ALPN) == 0 {
+			return nil, errors.New("no-default-alpn requires alpn")
+		}
+
+		params = append(params, SvcParamNoDefaultALPN)
+	}
+
+	if p.Port != 0 {
+		params = append(params, SvcParamPort+"="+strconv.Itoa(int(p.Port)))
+	}
+
+	if len(p.IPv4Hint) > 0 {
+		hint, err := formatHint(p.IPv4Hint, netip.Addr.Is4)
+		if err != nil {
+			return nil, err
+		}
+
+		params = append(params, SvcParamIPv4Hint+"="+hint)
+	}
+
+	if len(p.ECH) > 0 {
+		params = append(params, SvcParamECH+"="+strconv.Quote(base64.StdEncoding.EncodeToString(p.ECH)))
+	}
+
+	if len(p.IPv6Hint) > 0 {
+		hint, err := formatHint(p.IPv6Hint, func(addr netip.Addr) bool { return addr.Is6() && !addr.Is4In6() })
+		if err != nil {
+			return nil, err
+		}
+
+		params = append(params, SvcParamIPv6Hint+"="+hint)
+	}
+
+	return params, nil
+}
+
+func (p SvcParams) has(key string) bool {
+	switch key {
+	case SvcParamALPN:
+		return len(p.ALPN) > 0
+	case SvcParamNoDefaultALPN:
+		return p.NoDefaultALPN
+	case SvcParamPort:
+		return p.Port != 0
+	case SvcParamIPv4Hint:
+		return len(p.IPv4Hint) > 0
+	case SvcParamECH:
+		return len(p.ECH) > 0
+	case SvcParamIPv6Hint:
+		return len(p.IPv6Hint) > 0
+	default:
+		return false
+	}
+}
+
+func formatHint(addrs []netip.Addr, valid func(netip.Addr) bool) (string, error) {
+	values := make([]string, 0, len(addrs))
+
+	for _, addr := range addrs {
+		if !addr.IsValid() || !valid(addr) {
+			return "", fmt.Errorf("invalid address hint: %s", addr)
+		}
+
+		values = append(values, addr.String())
+	}
+
+	return strings.Join(values, ","), nil
+}
+
+// NewHTTPSRRSet creates an HTTPS RRSet.
+func NewHTTPSRRSet(domainName, subName string, ttl int, records ...SVCBRecord) (RRSet, error) {
+	return newSVCBRRSet("HTTPS", domainName, subName, ttl, records)
+}
+
+// NewSVCBRRSet creates a SVCB RRSet.
+func NewSVCBRRSet(domainName, subName string, ttl int, records ...SVCBRecord) (RRSet, error) {
+	return newSVCBRRSet("SVCB", domainName, subName, ttl, records)
+}
+
+func newSVCBRRSet(recordType, domainName, subName string, ttl int, records []SVCBRecord) (RRSet, error) {
+	if len(records) == 0 {
+		return RRSet{}, errors.New("no records")
+	}
+
+	values := make([]string, 0, len(records))
+
+	for _, record := range records {
+		value, err := record.Format()
+		if err != nil {
+			return RRSet{}, err
+		}
+
+		values = append(values, value)
+	}
+
+	return RRSet{
+		Domain:  domainName,
+		SubName: subName,
+		Type:    recordType,
+		Records: values,
+		TTL:     ttl,
+	}, nil
+}
@@ -0,0 +1,118 @@
+package desec
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSVCBRecord_Format(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		record   SVCBRecord
+		expected string
+	}{
+		{
+			desc:     "alias mode",
+			record:   SVCBRecord{Priority: 0, Target: "svc.example.com"},
+			expected: "0 svc.example.com.",
+		},
+		{
+			desc:     "service mode without params",
+			record:   SVCBRecord{Priority: 1},
+			expected: "1 .",
+		},
+		{
+			desc: "all params",
+			record: SVCBRecord{
+				Priority: 1,
+				Target:   ".",
+				Params: SvcParams{
+					Mandatory:     []string{SvcParamALPN},
+					ALPN:          []string{"h2", "h3"},
+					NoDefaultALPN: true,
+					Port:          8443,
+					IPv4Hint:      []netip.Addr{netip.MustParseAddr("192.0.2.1"), netip.MustParseAddr("192.0.2.2")},
+					ECH:           []byte("ech"),
+					IPv6Hint:      []netip.Addr{netip.MustParseAddr("2001:db8::1")},
+				},
+			},
+			expected: `1 . mandatory=alpn alpn=h2,h3 no-default-alpn port=8443 ipv4hint=192.0.2.1,192.0.2.2 ech="ZWNo" ipv6hint=2001:db8::1`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			value, err := test.record.Format()
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, value)
+
+			// the value must be parsable.
+			_, err = dns.NewRR("example.com. 3600 IN HTTPS " + value)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSVCBRecord_Format_errors(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		record SVCBRecord
+	}{
+		{
+			desc:   "params in alias mode",
+			record: SVCBRecord{Priority: 0, Target: "svc.example.com", Params: SvcParams{Port: 443}},
+		},
+		{
+			desc:   "missing mandatory key",
+			record: SVCBRecord{Priority: 1, Params: SvcParams{Mandatory: []string{SvcParamPort}}},
+		},
+		{
+			desc:   "no-default-alpn without alpn",
+			record: SVCBRecord{Priority: 1, Params: SvcParams{NoDefaultALPN: true}},
+		},
+		{
+			desc:   "invalid ALPN",
+			record: SVCBRecord{Priority: 1, Params: SvcParams{ALPN: []string{"h2,h3"}}},
+		},
+		{
+			desc:   "IPv6 in ipv4hint",
+			record: SVCBRecord{Priority: 1, Params: SvcParams{IPv4Hint: []netip.Addr{netip.MustParseAddr("2001:db8::1")}}},
+		},
+		{
+			desc:   "IPv4 in ipv6hint",
+			record: SVCBRecord{Priority: 1, Params: SvcParams{IPv6Hint: []netip.Addr{netip.MustParseAddr("192.0.2.1")}}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := test.record.Format()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewHTTPSRRSet(t *testing.T) {
+	rrSet, err := NewHTTPSRRSet("example.com", "", 3600,
+		SVCBRecord{Priority: 1, Params: SvcParams{ALPN: []string{"h3"}}},
+		SVCBRecord{Priority: 2, Target: "fallback.example.com", Params: SvcParams{ALPN: []string{"h2"}}},
+	)
+	require.NoError(t, err)
+
+	expected := RRSet{
+		Domain:  "example.com",
+		Type:    "HTTPS",
+		Records: []string{"1 . alpn=h3", "2 fallback.example.com. alpn=h2"},
+		TTL:     3600,
+	}
+	assert.Equal(t, expected, rrSet)
+}
@@ -0,0 +1,127 @@
+package desec
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// SyncOptions the options of Sync.
+type SyncOptions struct {
+	// DryRun computes the changes without applying them.
+	DryRun bool
+
+	// KeepUnmanaged keeps the live RRSets absent from the desired state, instead of deleting them.
+	KeepUnmanaged bool
+
+	// Progress receives the progress of the application of the changes (optional).
+	Progress ProgressFunc
+}
+
+// DiffZone computes the changes to apply to the current RRSets of a domain to reach the desired RRSets.
+// The records are compared regardless of their order and formatting.
+// The apex NS RRSet, managed by deSEC, is never deleted.
+// The changes are sorted by subname and type.
+func DiffZone(domainName string, current, desired []RRSet) []RRSetChange {
+	currentIndex := NewZone(current)
+	desiredIndex := NewZone(desired)
+
+	var changes []RRSetChange
+
+	for key, want := range desiredIndex {
+		want.Domain = domainName
+		want.SubName = normalizeSubName(want.SubName)
+
+		have, ok := currentIndex[key]
+		if !ok {
+			changes = append(changes, RRSetChange{Action: ChangeCreate, RRSet: want})
+			continue
+		}
+
+		have.Domain = domainName
+
+		if have.TTL != want.TTL || !sameRecords(have, want) {
+			changes = append(changes, RRSetChange{Action: ChangeUpdate, RRSet: want})
+		}
+	}
+
+	for key, have := range currentIndex {
+		if _, ok := desiredIndex[key]; ok {
+			continue
+		}
+
+		if normalizeSubName(have.SubName) == "" && have.Type == "NS" {
+			continue
+		}
+
+		changes = append(changes, RRSetChange{Action: ChangeDelete, RRSet: RRSet{
+			Domain:  domainName,
+			SubName: normalizeSubName(have.SubName),
+			Type:    have.Type,
+			TTL:     have.TTL,
+			Records: have.Records,
+		}})
+	}
+
+	slices.SortFunc(changes, func(a, b RRSetChange) int {
+		return cmp.Or(
+			cmp.Compare(a.RRSet.SubName, b.RRSet.SubName),
+			cmp.Compare(a.RRSet.Type, b.RRSet.Type),
+		)
+	})
+
+	return changes
+}
+
+// Sync reconciles the RRSets of a domain with the desired RRSets, and returns the applied changes.
+// The changes are applied with a single bulk request, the deSEC API applies them atomically.
+// Plan and ApplyPlan split the computation and the application of the changes.
+func (s *RecordsService) Sync(ctx context.Context, domainName string, desired []RRSet, opts *SyncOptions) ([]RRSetChange, error) {
+	plan, err := s.Plan(ctx, domainName, desired, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.ApplyPlan(ctx, plan, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.Changes(), nil
+}
+
+// changesToRRSets converts changes to the RRSets of a bulk request: the deleted RRSets have no records.
+func changesToRRSets(changes []RRSetChange) []RRSet {
+	rrSets := make([]RRSet, 0, len(changes))
+
+	for _, change := range changes {
+		rrSet := RRSet{
+			SubName: change.RRSet.SubName,
+			Type:    change.RRSet.Type,
+			TTL:     change.RRSet.TTL,
+			Records: change.RRSet.Records,
+		}
+
+		if change.Action == ChangeDelete {
+			rrSet.Records = []string{}
+		}
+
+		rrSets = append(rrSets, rrSet)
+	}
+
+	return rrSets
+}
+
+// sameRecords compares the records of two RRSets regardless of their order and formatting.
+func sameRecords(a, b RRSet) bool {
+	ra, errA := canonicalRecords(a)
+	rb, errB := canonicalRecords(b)
+
+	if errA != nil || errB != nil {
+		ra, rb = slices.Clone(a.Records), slices.Clone(b.Records)
+		slices.Sort(ra)
+		slices.Sort(rb)
+	}
+
+	return slices.Equal(ra, rb)
+}
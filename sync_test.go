@@ -0,0 +1,98 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffZone(t *testing.T) {
+	current := []RRSet{
+		{SubName: "", Type: "NS", TTL: 3600, Records: []string{"ns1.desec.io.", "ns2.desec.org."}},
+		{SubName: "", Type: "A", TTL: 3600, Records: []string{"10.10.10.10", "10.10.10.11"}},
+		{SubName: "www", Type: "AAAA", TTL: 3600, Records: []string{"2001:db8::1"}},
+		{SubName: "old", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}},
+		{SubName: "ttl", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}},
+	}
+
+	desired := []RRSet{
+		{SubName: "@", Type: "A", TTL: 3600, Records: []string{"10.10.10.11", "10.10.10.10"}},
+		{SubName: "www", Type: "AAAA", TTL: 3600, Records: []string{"2001:0db8:0::1"}},
+		{SubName: "ttl", Type: "A", TTL: 60, Records: []string{"10.10.10.10"}},
+		{SubName: "new", Type: "TXT", TTL: 3600, Records: []string{`"foo"`}},
+	}
+
+	changes := DiffZone("example.dedyn.io", current, desired)
+
+	expected := []RRSetChange{
+		{Action: ChangeCreate, RRSet: RRSet{Domain: "example.dedyn.io", SubName: "new", Type: "TXT", TTL: 3600, Records: []string{`"foo"`}}},
+		{Action: ChangeDelete, RRSet: RRSet{Domain: "example.dedyn.io", SubName: "old", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}}},
+		{Action: ChangeUpdate, RRSet: RRSet{Domain: "example.dedyn.io", SubName: "ttl", Type: "A", TTL: 60, Records: []string{"10.10.10.10"}}},
+	}
+	assert.Equal(t, expected, changes)
+}
+
+func TestRecordsService_Sync(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var received []RRSet
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			_, _ = rw.Write([]byte(`[{"subname":"old","type":"A","ttl":3600,"records":["10.10.10.10"]},{"subname":"www","type":"A","ttl":3600,"records":["10.10.10.10"]}]`))
+
+		case http.MethodPut:
+			err := json.NewDecoder(req.Body).Decode(&received)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			_, _ = rw.Write([]byte(`[]`))
+
+		default:
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	desired := []RRSet{
+		{SubName: "www", Type: "A", TTL: 3600, Records: []string{"10.10.10.11"}},
+	}
+
+	changes, err := client.Records.Sync(context.Background(), "example.dedyn.io", desired, &SyncOptions{DryRun: true})
+	require.NoError(t, err)
+
+	assert.Len(t, changes, 2)
+	assert.Nil(t, received)
+
+	changes, err = client.Records.Sync(context.Background(), "example.dedyn.io", desired, &SyncOptions{KeepUnmanaged: true})
+	require.NoError(t, err)
+
+	assert.Len(t, changes, 1)
+
+	var progress []Progress
+
+	_, err = client.Records.Sync(context.Background(), "example.dedyn.io", desired, &SyncOptions{
+		Progress: func(p Progress) { progress = append(progress, p) },
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []Progress{{Done: 2, Total: 2, Chunk: 1, Chunks: 1}}, progress)
+
+	expected := []RRSet{
+		{SubName: "old", Type: "A", TTL: 3600, Records: []string{}},
+		{SubName: "www", Type: "A", TTL: 3600, Records: []string{"10.10.10.11"}},
+	}
+	assert.Equal(t, expected, received)
+}
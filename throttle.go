@@ -0,0 +1,133 @@
+package desec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// throttleDetail matches the throttle detail of the API (Django REST framework).
+var throttleDetail = regexp.MustCompile(`available in (\d+) seconds?`)
+
+// ThrottledError the request has been throttled by the API (429).
+// https://desec.readthedocs.io/en/latest/rate-limits.html
+type ThrottledError struct {
+	Detail string `json:"detail"`
+
+	// RetryAfter the delay before the next request is allowed (Retry-After header, or parsed from the detail).
+	RetryAfter time.Duration `json:"-"`
+}
+
+func (e ThrottledError) Error() string {
+	return e.Detail
+}
+
+func readThrottledError(resp *http.Response) error {
+	err := readError(resp, &ThrottledError{})
+
+	var throttledErr *ThrottledError
+	if errors.As(err, &throttledErr) {
+		throttledErr.RetryAfter = retryAfter(resp.Header, throttledErr.Detail)
+	}
+
+	return err
+}
+
+// retryAfter returns the delay of the Retry-After header (seconds or HTTP date), or the delay of the throttle detail.
+func retryAfter(header http.Header, detail string) time.Duration {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+
+		if date, err := http.ParseTime(value); err == nil {
+			return max(time.Until(date), 0)
+		}
+	}
+
+	return parseThrottleDetail(detail)
+}
+
+// parseThrottleDetail parses the delay of a throttle detail ("Request was throttled. Expected available in 3 seconds.").
+func parseThrottleDetail(detail string) time.Duration {
+	match := throttleDetail.FindStringSubmatch(detail)
+	if match == nil {
+		return 0
+	}
+
+	seconds, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// throttleRetryPolicy fills the missing Retry-After header of the throttled responses with the delay of the throttle detail,
+// so the delay is used by the backoff of the retries.
+func throttleRetryPolicy(next retryablehttp.CheckRetry) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests && resp.Header.Get("Retry-After") == "" {
+			fillRetryAfter(resp)
+		}
+
+		return next(ctx, resp, err)
+	}
+}
+
+// throttleErrorHandler returns the last throttled response (429) when the retries are exhausted, to surface the ThrottledError.
+// The other failures keep the behavior of the retry client: the response is discarded and a "giving up" error is returned.
+func throttleErrorHandler(resp *http.Response, err error, numTries int) (*http.Response, error) {
+	if err == nil && resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return resp, nil
+	}
+
+	prefix := ""
+
+	if resp != nil {
+		if resp.Request != nil {
+			prefix = fmt.Sprintf("%s %s ", resp.Request.Method, sanitizeURL(resp.Request.URL))
+		}
+
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodySnippet))
+		_ = resp.Body.Close()
+	}
+
+	if err == nil {
+		return nil, fmt.Errorf("%sgiving up after %d attempt(s)", prefix, numTries)
+	}
+
+	return nil, fmt.Errorf("%sgiving up after %d attempt(s): %w", prefix, numTries, err)
+}
+
+func fillRetryAfter(resp *http.Response) {
+	body, err := io.ReadAll(resp.Body)
+
+	_ = resp.Body.Close()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return
+	}
+
+	var throttledErr ThrottledError
+
+	err = json.Unmarshal(body, &throttledErr)
+	if err != nil {
+		return
+	}
+
+	if delay := parseThrottleDetail(throttledErr.Detail); delay > 0 {
+		resp.Header.Set("Retry-After", strconv.Itoa(int(delay.Seconds())))
+	}
+}
@@ -0,0 +1,132 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseThrottleDetail(t *testing.T) {
+	testCases := []struct {
+		detail   string
+		expected time.Duration
+	}{
+		{detail: "Request was throttled. Expected available in 3 seconds.", expected: 3 * time.Second},
+		{detail: "Request was throttled. Expected available in 1 second.", expected: time.Second},
+		{detail: "Request was throttled.", expected: 0},
+		{detail: "", expected: 0},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.detail, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, parseThrottleDetail(test.detail))
+		})
+	}
+}
+
+func TestClient_throttled(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		retryAfter string
+		expected   time.Duration
+	}{
+		{desc: "detail", expected: 42 * time.Second},
+		{desc: "Retry-After", retryAfter: "7", expected: 7 * time.Second},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			t.Cleanup(server.Close)
+
+			opts := NewDefaultClientOptions()
+			opts.RetryMax = 0
+
+			client := New("token", opts)
+			client.BaseURL = server.URL
+
+			mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+				if test.retryAfter != "" {
+					rw.Header().Set("Retry-After", test.retryAfter)
+				}
+
+				rw.WriteHeader(http.StatusTooManyRequests)
+				_, _ = rw.Write([]byte(`{"detail":"Request was throttled. Expected available in 42 seconds."}`))
+			})
+
+			_, err := client.Domains.GetAll(context.Background())
+
+			var throttledErr *ThrottledError
+			require.ErrorAs(t, err, &throttledErr)
+
+			assert.Equal(t, test.expected, throttledErr.RetryAfter)
+			assert.EqualError(t, err, "429: Request was throttled. Expected available in 42 seconds.")
+		})
+	}
+}
+
+func TestClient_throttled_retry(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	opts := NewDefaultClientOptions()
+	opts.RetryMax = 1
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	var calls int
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		if calls == 1 {
+			rw.WriteHeader(http.StatusTooManyRequests)
+			_, _ = rw.Write([]byte(`{"detail":"Request was throttled. Expected available in 1 second."}`))
+
+			return
+		}
+
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	start := time.Now()
+
+	_, err := client.Domains.GetAll(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestClient_retriesExhausted(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	opts := NewDefaultClientOptions()
+	opts.RetryMax = 0
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := client.Domains.GetAll(context.Background())
+	require.Error(t, err)
+
+	assert.ErrorContains(t, err, "giving up after 1 attempt(s)")
+	assert.False(t, errors.As(err, new(*APIError)))
+}
@@ -0,0 +1,31 @@
+package desec
+
+import (
+	"context"
+	"errors"
+)
+
+type inspectingTokenKey struct{}
+
+// inspectToken adds the state of the token (ClientOptions.InspectInvalidToken) to a TokenInvalidError.
+// The calls made by the lookup are not inspected: a rejected lookup doesn't recurse.
+func (c *Client) inspectToken(ctx context.Context, err error) error {
+	if c.inspectInvalidToken == nil || ctx.Value(inspectingTokenKey{}) != nil {
+		return err
+	}
+
+	var tokenErr *TokenInvalidError
+	if !errors.As(err, &tokenErr) {
+		return err
+	}
+
+	token, errI := c.inspectInvalidToken(context.WithValue(ctx, inspectingTokenKey{}, true))
+	if errI != nil || token == nil {
+		return err
+	}
+
+	tokenErr.IsValid = token.IsValid
+	tokenErr.LastUsed = token.LastUsed
+
+	return err
+}
@@ -0,0 +1,103 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_inspectInvalidToken(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var client *Client
+
+	opts := NewDefaultClientOptions()
+	opts.InspectInvalidToken = func(ctx context.Context) (*Token, error) {
+		tokens, err := client.Tokens.GetAll(WithRequestToken(ctx, "admin"))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, token := range tokens {
+			if token.ID == "expired" {
+				return &token, nil
+			}
+		}
+
+		return nil, errors.New("token not found")
+	}
+
+	client = New("token", opts)
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, `{"detail":"Invalid token."}`, http.StatusUnauthorized)
+	})
+
+	mux.HandleFunc("GET /auth/tokens/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Token admin" {
+			http.Error(rw, `{"detail":"Invalid token."}`, http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`[{"id":"expired","is_valid":false,"last_used":"2025-05-06T12:00:00Z"}]`))
+	})
+
+	_, err := client.Domains.Get(context.Background(), "example.com")
+	require.ErrorIs(t, err, ErrTokenInvalid)
+
+	var tokenErr *TokenInvalidError
+	require.ErrorAs(t, err, &tokenErr)
+
+	require.NotNil(t, tokenErr.IsValid)
+	assert.False(t, *tokenErr.IsValid)
+	assert.Equal(t, Pointer(time.Date(2025, time.May, 6, 12, 0, 0, 0, time.UTC)), tokenErr.LastUsed)
+
+	assert.EqualError(t, tokenErr, "invalid token: Invalid token. (is_valid: false, last used: 2025-05-06T12:00:00Z)")
+}
+
+func TestClient_inspectInvalidToken_rejected(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var (
+		client  *Client
+		lookups int
+	)
+
+	opts := NewDefaultClientOptions()
+	opts.InspectInvalidToken = func(ctx context.Context) (*Token, error) {
+		lookups++
+
+		tokens, err := client.Tokens.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return &tokens[0], nil
+	}
+
+	client = New("token", opts)
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, `{"detail":"Invalid token."}`, http.StatusUnauthorized)
+	})
+
+	_, err := client.Domains.Get(context.Background(), "example.com")
+
+	var tokenErr *TokenInvalidError
+	require.ErrorAs(t, err, &tokenErr)
+
+	assert.Nil(t, tokenErr.IsValid)
+	assert.Equal(t, 1, lookups)
+}
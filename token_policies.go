@@ -30,29 +30,9 @@ func (s *TokenPoliciesService) Get(ctx context.Context, tokenID string) ([]Token
 		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodGet, endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
-	}
+	policies, _, err := do[[]TokenPolicy](ctx, s.client, http.MethodGet, endpoint, nil)
 
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, handleError(resp)
-	}
-
-	var policies []TokenPolicy
-	err = handleResponse(resp, &policies)
-	if err != nil {
-		return nil, err
-	}
-
-	return policies, nil
+	return policies, err
 }
 
 // Create creates token policy.
@@ -63,24 +43,23 @@ func (s *TokenPoliciesService) Create(ctx context.Context, tokenID string, polic
 		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, endpoint, policy)
+	tokenPolicy, _, err := do[TokenPolicy](ctx, s.client, http.MethodPost, endpoint, policy)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
+	return &tokenPolicy, nil
+}
 
-	if resp.StatusCode != http.StatusCreated {
-		return nil, handleError(resp)
+// Update updates a token rrset's policy (PATCH).
+// https://desec.readthedocs.io/en/latest/auth/tokens.html#token-policy-management
+func (s *TokenPoliciesService) Update(ctx context.Context, tokenID, policyID string, policy TokenPolicy) (*TokenPolicy, error) {
+	endpoint, err := s.client.createEndpoint("auth", "tokens", tokenID, "policies", "rrsets", policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	var tokenPolicy TokenPolicy
-	err = handleResponse(resp, &tokenPolicy)
+	tokenPolicy, _, err := do[TokenPolicy](ctx, s.client, http.MethodPatch, endpoint, policy)
 	if err != nil {
 		return nil, err
 	}
@@ -96,20 +75,7 @@ func (s *TokenPoliciesService) Delete(ctx context.Context, tokenID, policyID str
 		return fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodDelete, endpoint, nil)
-	if err != nil {
-		return err
-	}
+	_, _, err = do[struct{}](ctx, s.client, http.MethodDelete, endpoint, nil)
 
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusNoContent {
-		return handleError(resp)
-	}
-	return nil
+	return err
 }
@@ -0,0 +1,157 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// PolicyTemplateResult the changes applied to the policies of a token by ApplyTemplate.
+type PolicyTemplateResult struct {
+	TokenID string
+	Created []TokenPolicy
+	Updated []TokenPolicy
+	Deleted []TokenPolicy
+
+	// Err the error that stopped the application of the template, if any.
+	Err error
+}
+
+// ApplyTemplate applies a set of policies to several tokens:
+// the missing policies are created, the drifted policies (permission) are updated, and the extraneous policies are deleted.
+// The policies are identified by their scope (domain, subname, type).
+// The tokens are processed sequentially, an error on a token doesn't stop the processing of the others.
+// https://desec.readthedocs.io/en/latest/auth/tokens.html#token-scoping-policies
+func (s *TokenPoliciesService) ApplyTemplate(ctx context.Context, tokenIDs []string, template []TokenPolicy) []PolicyTemplateResult {
+	results := make([]PolicyTemplateResult, 0, len(tokenIDs))
+
+	for _, tokenID := range tokenIDs {
+		result := PolicyTemplateResult{TokenID: tokenID}
+		result.Err = s.applyTemplate(ctx, tokenID, template, &result)
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func (s *TokenPoliciesService) applyTemplate(ctx context.Context, tokenID string, template []TokenPolicy, result *PolicyTemplateResult) error {
+	current, err := s.Get(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]TokenPolicy, len(current))
+	for _, policy := range current {
+		existing[policyScope(policy)] = policy
+	}
+
+	desired := make(map[string]TokenPolicy, len(template))
+
+	// The default policy must be created before the other policies.
+	for _, policy := range sortDefaultPolicy(template, true) {
+		scope := policyScope(policy)
+		desired[scope] = policy
+
+		have, ok := existing[scope]
+		if !ok {
+			created, err := s.Create(ctx, tokenID, TokenPolicy{
+				Domain:          policy.Domain,
+				SubName:         policy.SubName,
+				Type:            policy.Type,
+				WritePermission: policy.WritePermission,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create policy %s: %w", scope, err)
+			}
+
+			result.Created = append(result.Created, *created)
+
+			continue
+		}
+
+		if have.WritePermission != policy.WritePermission {
+			updated, err := s.updatePermission(ctx, tokenID, have.ID, policy.WritePermission)
+			if err != nil {
+				return fmt.Errorf("failed to update policy %s: %w", scope, err)
+			}
+
+			result.Updated = append(result.Updated, *updated)
+		}
+	}
+
+	// The default policy must be deleted after the other policies.
+	for _, policy := range sortDefaultPolicy(current, false) {
+		if _, ok := desired[policyScope(policy)]; ok {
+			continue
+		}
+
+		err = s.Delete(ctx, tokenID, policy.ID)
+		if err != nil {
+			return fmt.Errorf("failed to delete policy %s: %w", policyScope(policy), err)
+		}
+
+		result.Deleted = append(result.Deleted, policy)
+	}
+
+	return nil
+}
+
+// policyPermissionPatch the body of the update of the permission of a policy.
+// The permission is always sent: false is omitted by TokenPolicy (omitempty).
+type policyPermissionPatch struct {
+	WritePermission *bool `json:"perm_write"`
+}
+
+// updatePermission updates only the permission of a policy (PATCH).
+func (s *TokenPoliciesService) updatePermission(ctx context.Context, tokenID, policyID string, writePermission bool) (*TokenPolicy, error) {
+	endpoint, err := s.client.createEndpoint("auth", "tokens", tokenID, "policies", "rrsets", policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint: %w", err)
+	}
+
+	tokenPolicy, _, err := do[TokenPolicy](ctx, s.client, http.MethodPatch, endpoint, policyPermissionPatch{WritePermission: &writePermission})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenPolicy, nil
+}
+
+// sortDefaultPolicy moves the default policy (without scope) first or last.
+func sortDefaultPolicy(policies []TokenPolicy, first bool) []TokenPolicy {
+	sorted := make([]TokenPolicy, 0, len(policies))
+
+	var defaults []TokenPolicy
+
+	for _, policy := range policies {
+		if isDefaultPolicy(policy) {
+			defaults = append(defaults, policy)
+		} else {
+			sorted = append(sorted, policy)
+		}
+	}
+
+	if first {
+		return append(defaults, sorted...)
+	}
+
+	return append(sorted, defaults...)
+}
+
+func isDefaultPolicy(policy TokenPolicy) bool {
+	return policy.Domain == nil && policy.SubName == nil && policy.Type == nil
+}
+
+// policyScope returns a key identifying the scope of a policy.
+func policyScope(policy TokenPolicy) string {
+	return fmt.Sprintf("%s/%s/%s", scopePart(policy.Domain), scopePart(policy.SubName), scopePart(policy.Type))
+}
+
+func scopePart(value *string) string {
+	if value == nil {
+		return "*"
+	}
+
+	return *value
+}
@@ -0,0 +1,140 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenPoliciesService_ApplyTemplate(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var calls []string
+
+	mux.HandleFunc("/auth/tokens/a/policies/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			_, _ = rw.Write([]byte(`[
+				{"id":"default","domain":null,"subname":null,"type":null,"perm_write":false},
+				{"id":"txt","domain":"example.com","subname":"_acme-challenge","type":"TXT","perm_write":false},
+				{"id":"extra","domain":"example.org","subname":null,"type":null,"perm_write":true}
+			]`))
+
+		default:
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/auth/tokens/a/policies/rrsets/{id}/", func(rw http.ResponseWriter, req *http.Request) {
+		calls = append(calls, req.Method+" "+req.URL.Path)
+
+		switch req.Method {
+		case http.MethodPatch:
+			var policy TokenPolicy
+			_ = json.NewDecoder(req.Body).Decode(&policy)
+			policy.ID = req.PathValue("id")
+			_ = json.NewEncoder(rw).Encode(policy)
+
+		case http.MethodDelete:
+			rw.WriteHeader(http.StatusNoContent)
+
+		default:
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/auth/tokens/b/policies/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			_, _ = rw.Write([]byte(`[]`))
+
+		case http.MethodPost:
+			var policy TokenPolicy
+			_ = json.NewDecoder(req.Body).Decode(&policy)
+
+			calls = append(calls, req.Method+" "+req.URL.Path+" "+policyScope(policy))
+
+			rw.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(rw).Encode(policy)
+
+		default:
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	template := []TokenPolicy{
+		{Domain: Pointer("example.com"), SubName: Pointer("_acme-challenge"), Type: Pointer("TXT"), WritePermission: true},
+		{},
+	}
+
+	results := client.TokenPolicies.ApplyTemplate(context.Background(), []string{"a", "b", "c"}, template)
+	require.Len(t, results, 3)
+
+	require.NoError(t, results[0].Err)
+	assert.Empty(t, results[0].Created)
+	assert.Len(t, results[0].Updated, 1)
+	assert.Len(t, results[0].Deleted, 1)
+
+	require.NoError(t, results[1].Err)
+	assert.Len(t, results[1].Created, 2)
+
+	require.Error(t, results[2].Err)
+
+	expected := []string{
+		"PATCH /auth/tokens/a/policies/rrsets/txt/",
+		"DELETE /auth/tokens/a/policies/rrsets/extra/",
+		"POST /auth/tokens/b/policies/rrsets/ */*/*",
+		"POST /auth/tokens/b/policies/rrsets/ example.com/_acme-challenge/TXT",
+	}
+	assert.Equal(t, expected, calls)
+}
+
+func TestTokenPoliciesService_ApplyTemplate_revokeWrite(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /auth/tokens/a/policies/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[
+			{"id":"default","domain":null,"subname":null,"type":null,"perm_write":false},
+			{"id":"txt","domain":"example.com","subname":"_acme-challenge","type":"TXT","perm_write":true}
+		]`))
+	})
+
+	var body string
+
+	mux.HandleFunc("PATCH /auth/tokens/a/policies/rrsets/txt/", func(rw http.ResponseWriter, req *http.Request) {
+		raw, _ := io.ReadAll(req.Body)
+		body = string(raw)
+
+		_, _ = rw.Write([]byte(`{"id":"txt","domain":"example.com","subname":"_acme-challenge","type":"TXT","perm_write":false}`))
+	})
+
+	template := []TokenPolicy{
+		{},
+		{Domain: Pointer("example.com"), SubName: Pointer("_acme-challenge"), Type: Pointer("TXT")},
+	}
+
+	results := client.TokenPolicies.ApplyTemplate(context.Background(), []string{"a"}, template)
+	require.Len(t, results, 1)
+
+	require.NoError(t, results[0].Err)
+	require.Len(t, results[0].Updated, 1)
+	assert.False(t, results[0].Updated[0].WritePermission)
+
+	assert.JSONEq(t, `{"perm_write":false}`, body)
+}
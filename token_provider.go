@@ -0,0 +1,96 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenProvider provides the token used to authenticate the requests.
+// The provider is consulted for each request, it allows to rotate tokens without recreating the client.
+type TokenProvider interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken a TokenProvider that always returns the same token.
+type StaticToken string
+
+// Token returns the token.
+func (t StaticToken) Token(_ context.Context) (string, error) {
+	return string(t), nil
+}
+
+type requestTokenKey struct{}
+
+// WithRequestToken returns a context that overrides the token of the client (and its TokenProvider) for the calls made with it.
+// Useful to issue calls with a scoped token (ex: a customer token) without creating another client.
+//
+//	domains, err := client.Domains.GetAll(desec.WithRequestToken(ctx, customerToken))
+func WithRequestToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, requestTokenKey{}, token)
+}
+
+// requestToken returns the token of the request: the token from the context (WithRequestToken), or the token of the provider.
+func requestToken(ctx context.Context, provider TokenProvider) (string, error) {
+	if token, ok := ctx.Value(requestTokenKey{}).(string); ok {
+		return token, nil
+	}
+
+	return provider.Token(ctx)
+}
+
+// TokenFunc an adapter to allow the use of ordinary functions as TokenProvider.
+type TokenFunc func(ctx context.Context) (string, error)
+
+// Token calls f(ctx).
+func (f TokenFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// FileTokenProvider a TokenProvider that reads the token from a file.
+// The file is reloaded when its modification time changes (ex: Kubernetes secrets, Vault agent).
+type FileTokenProvider struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+}
+
+// NewFileTokenProvider creates a new FileTokenProvider.
+func NewFileTokenProvider(path string) *FileTokenProvider {
+	return &FileTokenProvider{path: path}
+}
+
+// Token returns the token contained in the file.
+func (p *FileTokenProvider) Token(_ context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	if p.token != "" && info.ModTime().Equal(p.modTime) {
+		return p.token, nil
+	}
+
+	content, err := os.ReadFile(p.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("empty token file: %s", p.path)
+	}
+
+	p.token = token
+	p.modTime = info.ModTime()
+
+	return p.token, nil
+}
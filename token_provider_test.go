@@ -0,0 +1,162 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileTokenProvider_Token(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+
+	err := os.WriteFile(path, []byte("first\n"), 0o600)
+	require.NoError(t, err)
+
+	provider := NewFileTokenProvider(path)
+
+	token, err := provider.Token(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "first", token)
+
+	err = os.WriteFile(path, []byte("second\n"), 0o600)
+	require.NoError(t, err)
+
+	// ensures the modification time changes.
+	modTime := time.Now().Add(time.Second)
+	err = os.Chtimes(path, modTime, modTime)
+	require.NoError(t, err)
+
+	token, err = provider.Token(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "second", token)
+}
+
+func TestFileTokenProvider_Token_errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewFileTokenProvider(filepath.Join(dir, "missing")).Token(context.Background())
+	require.Error(t, err)
+
+	path := filepath.Join(dir, "empty")
+
+	err = os.WriteFile(path, []byte("\n"), 0o600)
+	require.NoError(t, err)
+
+	_, err = NewFileTokenProvider(path).Token(context.Background())
+	require.Error(t, err)
+}
+
+func TestClient_tokenProvider(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	tokens := []string{"first", "second"}
+
+	opts := NewDefaultClientOptions()
+	opts.TokenProvider = TokenFunc(func(context.Context) (string, error) {
+		token := tokens[0]
+		tokens = tokens[1:]
+
+		return token, nil
+	})
+
+	client := New("", opts)
+	client.BaseURL = server.URL
+
+	var received []string
+
+	mux.HandleFunc("/domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		received = append(received, req.Header.Get("Authorization"))
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	for range 2 {
+		err := client.Domains.Delete(context.Background(), "example.com")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"Token first", "Token second"}, received)
+}
+
+func TestClient_requestToken(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var authorizations []string
+
+	mux.HandleFunc("GET /auth/tokens/", func(rw http.ResponseWriter, req *http.Request) {
+		authorizations = append(authorizations, req.Header.Get("Authorization"))
+
+		_, _ = rw.Write([]byte(`[]`))
+	})
+
+	client := New("default", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	_, err := client.Tokens.GetAll(WithRequestToken(context.Background(), "customer"))
+	require.NoError(t, err)
+
+	_, err = client.Tokens.GetAll(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Token customer", "Token default"}, authorizations)
+}
+
+func TestClient_requestToken_caches(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token-a", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	// Each account owns a different domain for the same name.
+	domains := map[string]string{
+		"Token token-a": `{"name":"example.com","minimum_ttl":3600}`,
+		"Token token-b": `{"name":"dev.example.com","minimum_ttl":60}`,
+	}
+
+	mux.HandleFunc("GET /domains/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte("[" + domains[req.Header.Get("Authorization")] + "]"))
+	})
+
+	mux.HandleFunc("GET /domains/example.com/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(domains[req.Header.Get("Authorization")]))
+	})
+
+	ctxB := WithRequestToken(context.Background(), "token-b")
+
+	domainName, subName, err := client.Domains.ResolveRRSetTarget(context.Background(), "www.dev.example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", domainName)
+	assert.Equal(t, "www.dev", subName)
+
+	domainName, subName, err = client.Domains.ResolveRRSetTarget(ctxB, "www.dev.example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, "dev.example.com", domainName)
+	assert.Equal(t, "www", subName)
+
+	minimumTTL, err := client.domainMinimumTTL(context.Background(), "example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3600, minimumTTL)
+
+	minimumTTL, err = client.domainMinimumTTL(ctxB, "example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, 60, minimumTTL)
+}
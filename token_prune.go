@@ -0,0 +1,110 @@
+package desec
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PruneOptions the selection of the tokens deleted by Prune.
+// A token is selected if its name matches NamePrefix, and it is unused or invalid.
+type PruneOptions struct {
+	// UnusedFor selects the tokens not used for this duration (the creation date is used for the never used tokens).
+	// 0 disables the selection on the usage.
+	UnusedFor time.Duration
+
+	// Invalid selects the invalid tokens (expired).
+	// Only the tokens explicitly reported invalid by the API are selected.
+	Invalid bool
+
+	// NamePrefix restricts the selection to the tokens with this name prefix.
+	NamePrefix string
+
+	// KeepIDs the IDs of the tokens never deleted.
+	// The token used by the client is always kept if its ID is known (AccountService.Login).
+	KeepIDs []string
+
+	// DryRun selects the tokens without deleting them.
+	DryRun bool
+}
+
+// Prune deletes the stale tokens, and returns the deleted (or selected, with DryRun) tokens.
+// If a deletion fails, the tokens deleted before the failure are returned with the error.
+func (s *TokensService) Prune(ctx context.Context, opts PruneOptions) ([]Token, error) {
+	tokens, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if id := s.client.loginTokenID; id != "" {
+		opts.KeepIDs = append(slices.Clip(opts.KeepIDs), id)
+	}
+
+	var pruned []Token
+
+	for _, token := range tokens {
+		if !opts.selects(token, now) {
+			continue
+		}
+
+		if !opts.DryRun {
+			err = s.Delete(ctx, token.ID)
+			if err != nil {
+				return pruned, err
+			}
+		}
+
+		pruned = append(pruned, token)
+	}
+
+	return pruned, nil
+}
+
+func (o PruneOptions) selects(token Token, now time.Time) bool {
+	if !strings.HasPrefix(token.Name, o.NamePrefix) || slices.Contains(o.KeepIDs, token.ID) {
+		return false
+	}
+
+	if o.Invalid && isInvalid(token) {
+		return true
+	}
+
+	if o.UnusedFor <= 0 {
+		return false
+	}
+
+	lastUsed := token.LastUsed
+	if lastUsed == nil {
+		lastUsed = token.Created
+	}
+
+	return lastUsed != nil && now.Sub(*lastUsed) >= o.UnusedFor
+}
+
+// ListInvalid returns the invalid tokens: expired (max_age, max_unused_period) or otherwise rejected by the API.
+// Only the tokens explicitly reported invalid (is_valid: false) are returned.
+// The token used by the client authenticates the listing: it is never reported invalid.
+// https://desec.readthedocs.io/en/latest/auth/tokens.html#token-scoping-expiration
+func (s *TokensService) ListInvalid(ctx context.Context) ([]Token, error) {
+	tokens, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(tokens, func(token Token) bool { return !isInvalid(token) }), nil
+}
+
+// isInvalid returns true if the API explicitly reported the token invalid.
+func isInvalid(token Token) bool {
+	return token.IsValid != nil && !*token.IsValid
+}
+
+// PurgeInvalid deletes the invalid tokens (ListInvalid), except the tokens with the keepIDs and the token used by the client,
+// and returns the deleted tokens.
+// If a deletion fails, the tokens deleted before the failure are returned with the error.
+func (s *TokensService) PurgeInvalid(ctx context.Context, keepIDs ...string) ([]Token, error) {
+	return s.Prune(ctx, PruneOptions{Invalid: true, KeepIDs: keepIDs})
+}
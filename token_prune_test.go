@@ -0,0 +1,141 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokensService_Prune(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	recent := time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+	old := time.Now().Add(-200 * 24 * time.Hour).UTC().Format(time.RFC3339)
+
+	body := `[
+		{"id":"1","name":"ci-recent","created":"` + old + `","last_used":"` + recent + `","is_valid":true},
+		{"id":"2","name":"ci-old","created":"` + old + `","last_used":"` + old + `","is_valid":true},
+		{"id":"3","name":"ci-never-used","created":"` + old + `","is_valid":true},
+		{"id":"4","name":"ci-invalid","created":"` + recent + `","is_valid":false},
+		{"id":"5","name":"admin","created":"` + old + `","is_valid":true},
+		{"id":"6","name":"ci-current","created":"` + old + `","is_valid":true},
+		{"id":"7","name":"ci-partial","created":"` + recent + `"}
+	]`
+
+	mux.HandleFunc("/auth/tokens/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(body))
+	})
+
+	var deleted []string
+
+	for _, id := range []string{"1", "2", "3", "4", "5", "6", "7"} {
+		mux.HandleFunc("/auth/tokens/"+id+"/", func(rw http.ResponseWriter, req *http.Request) {
+			if req.Method != http.MethodDelete {
+				http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+				return
+			}
+
+			deleted = append(deleted, strings.Trim(strings.TrimPrefix(req.URL.Path, "/auth/tokens/"), "/"))
+
+			rw.WriteHeader(http.StatusNoContent)
+		})
+	}
+
+	opts := PruneOptions{
+		UnusedFor:  90 * 24 * time.Hour,
+		Invalid:    true,
+		NamePrefix: "ci-",
+		KeepIDs:    []string{"6"},
+		DryRun:     true,
+	}
+
+	pruned, err := client.Tokens.Prune(context.Background(), opts)
+	require.NoError(t, err)
+
+	assert.Len(t, pruned, 3)
+	assert.Empty(t, deleted)
+
+	opts.DryRun = false
+
+	pruned, err = client.Tokens.Prune(context.Background(), opts)
+	require.NoError(t, err)
+
+	assert.Len(t, pruned, 3)
+	assert.Equal(t, []string{"2", "3", "4"}, deleted)
+}
+
+func TestTokensService_ListInvalid(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /auth/tokens/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"id":"1","name":"valid","is_valid":true},{"id":"2","name":"expired","is_valid":false},{"id":"3","name":"current","is_valid":false},{"id":"4","name":"unknown"}]`))
+	})
+
+	var deleted []string
+
+	mux.HandleFunc("DELETE /auth/tokens/{id}/", func(rw http.ResponseWriter, req *http.Request) {
+		deleted = append(deleted, req.PathValue("id"))
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	tokens, err := client.Tokens.ListInvalid(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []Token{{ID: "2", Name: "expired", IsValid: False()}, {ID: "3", Name: "current", IsValid: False()}}, tokens)
+
+	purged, err := client.Tokens.PurgeInvalid(context.Background(), "3")
+	require.NoError(t, err)
+
+	assert.Equal(t, []Token{{ID: "2", Name: "expired", IsValid: False()}}, purged)
+	assert.Equal(t, []string{"2"}, deleted)
+}
+
+func TestTokensService_PurgeInvalid_loginToken(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("POST /auth/login/", func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(`{"id":"3","token":"secret","is_valid":true}`))
+	})
+
+	mux.HandleFunc("GET /auth/tokens/", func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(`[{"id":"2","name":"expired","is_valid":false},{"id":"3","name":"current","is_valid":false}]`))
+	})
+
+	var deleted []string
+
+	mux.HandleFunc("DELETE /auth/tokens/{id}/", func(rw http.ResponseWriter, req *http.Request) {
+		deleted = append(deleted, req.PathValue("id"))
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	_, err := client.Account.Login(context.Background(), "email@example.com", "secret")
+	require.NoError(t, err)
+
+	_, err = client.Tokens.PurgeInvalid(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"2"}, deleted)
+}
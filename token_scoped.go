@@ -0,0 +1,77 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+)
+
+// ScopedTokenOptions the scope of a token created by CreateScoped.
+type ScopedTokenOptions struct {
+	// SubNames the writable subnames (all the subnames if empty).
+	SubNames []string
+	// Types the writable record types (all the types if empty).
+	Types []string
+}
+
+// CreateScoped creates a token restricted to a domain:
+// a default policy denies everything, and write policies allow the combinations of subnames and types.
+// The token is deleted if a policy cannot be created.
+// https://desec.readthedocs.io/en/latest/auth/tokens.html#token-scoping-policies
+func (s *TokensService) CreateScoped(ctx context.Context, name, domainName string, opts ScopedTokenOptions) (*Token, error) {
+	token, err := s.Create(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = createScopedPolicies(ctx, s.client.TokenPolicies, token.ID, domainName, opts)
+	if err != nil {
+		errD := s.Delete(context.WithoutCancel(ctx), token.ID)
+		if errD != nil {
+			return nil, fmt.Errorf("failed to create policies: %w (and failed to delete the token %s: %w)", err, token.ID, errD)
+		}
+
+		return nil, fmt.Errorf("failed to create policies: %w", err)
+	}
+
+	return token, nil
+}
+
+func createScopedPolicies(ctx context.Context, policies *TokenPoliciesService, tokenID, domainName string, opts ScopedTokenOptions) error {
+	// The default policy is required before the other policies.
+	_, err := policies.Create(ctx, tokenID, TokenPolicy{})
+	if err != nil {
+		return err
+	}
+
+	subNames := []*string{nil}
+	if len(opts.SubNames) > 0 {
+		subNames = subNames[:0]
+		for _, subName := range opts.SubNames {
+			subNames = append(subNames, Pointer(normalizeSubName(subName)))
+		}
+	}
+
+	types := []*string{nil}
+	if len(opts.Types) > 0 {
+		types = types[:0]
+		for _, recordType := range opts.Types {
+			types = append(types, Pointer(recordType))
+		}
+	}
+
+	for _, subName := range subNames {
+		for _, recordType := range types {
+			_, err = policies.Create(ctx, tokenID, TokenPolicy{
+				Domain:          Pointer(domainName),
+				SubName:         subName,
+				Type:            recordType,
+				WritePermission: true,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupScopedTokenTest(t *testing.T, failPolicy int) (*Client, *[]TokenPolicy, *bool) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/auth/tokens/", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = rw.Write([]byte(`{"id":"token-id","name":"acme","token":"secret"}`))
+	})
+
+	var deleted bool
+
+	mux.HandleFunc("/auth/tokens/token-id/", func(rw http.ResponseWriter, req *http.Request) {
+		deleted = req.Method == http.MethodDelete
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	var policies []TokenPolicy
+
+	mux.HandleFunc("/auth/tokens/token-id/policies/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		if len(policies) == failPolicy {
+			http.Error(rw, `{"detail":"fail"}`, http.StatusBadRequest)
+			return
+		}
+
+		var policy TokenPolicy
+
+		err := json.NewDecoder(req.Body).Decode(&policy)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		policies = append(policies, policy)
+
+		rw.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(rw).Encode(policy)
+	})
+
+	return client, &policies, &deleted
+}
+
+func TestTokensService_CreateScoped(t *testing.T) {
+	client, policies, deleted := setupScopedTokenTest(t, -1)
+
+	token, err := client.Tokens.CreateScoped(context.Background(), "acme", "example.dedyn.io", ScopedTokenOptions{
+		SubNames: []string{"_acme-challenge", "_acme-challenge.www"},
+		Types:    []string{"TXT"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "secret", token.Value)
+	assert.False(t, *deleted)
+
+	expected := []TokenPolicy{
+		{},
+		{Domain: Pointer("example.dedyn.io"), SubName: Pointer("_acme-challenge"), Type: Pointer("TXT"), WritePermission: true},
+		{Domain: Pointer("example.dedyn.io"), SubName: Pointer("_acme-challenge.www"), Type: Pointer("TXT"), WritePermission: true},
+	}
+	assert.Equal(t, expected, *policies)
+}
+
+func TestTokensService_CreateScoped_domain(t *testing.T) {
+	client, policies, _ := setupScopedTokenTest(t, -1)
+
+	_, err := client.Tokens.CreateScoped(context.Background(), "acme", "example.dedyn.io", ScopedTokenOptions{})
+	require.NoError(t, err)
+
+	expected := []TokenPolicy{
+		{},
+		{Domain: Pointer("example.dedyn.io"), WritePermission: true},
+	}
+	assert.Equal(t, expected, *policies)
+}
+
+func TestTokensService_CreateScoped_error(t *testing.T) {
+	client, _, deleted := setupScopedTokenTest(t, 1)
+
+	_, err := client.Tokens.CreateScoped(context.Background(), "acme", "example.dedyn.io", ScopedTokenOptions{Types: []string{"TXT"}})
+	require.Error(t, err)
+
+	assert.True(t, *deleted)
+}
@@ -2,6 +2,7 @@ package desec
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -9,10 +10,37 @@ import (
 
 // Token a token representation.
 type Token struct {
-	ID      string     `json:"id,omitempty"`
-	Name    string     `json:"name,omitempty"`
-	Value   string     `json:"token,omitempty"`
-	Created *time.Time `json:"created,omitempty"`
+	ID       string     `json:"id,omitempty"`
+	Name     string     `json:"name,omitempty"`
+	Value    string     `json:"token,omitempty"`
+	Created  *time.Time `json:"created,omitempty"`
+	LastUsed *time.Time `json:"last_used,omitempty"`
+	// IsValid nil if the API didn't return the validity of the token.
+	IsValid *bool `json:"is_valid,omitempty"`
+
+	// Extra the fields returned by the API but not modeled by the library.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Token) UnmarshalJSON(data []byte) error {
+	type alias Token
+
+	var a alias
+
+	extra, err := unmarshalWithExtra(data, &a)
+	if err != nil {
+		return err
+	}
+
+	*t = Token(a)
+	t.Extra = extra
+
+	return nil
+}
+
+func (t *Token) extraFields() map[string]json.RawMessage {
+	return t.Extra
 }
 
 // TokensService handles communication with the tokens related methods of the deSEC API.
@@ -30,29 +58,9 @@ func (s *TokensService) GetAll(ctx context.Context) ([]Token, error) {
 		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodGet, endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
-	}
+	tokens, _, err := do[[]Token](ctx, s.client, http.MethodGet, endpoint, nil)
 
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, handleError(resp)
-	}
-
-	var tokens []Token
-	err = handleResponse(resp, &tokens)
-	if err != nil {
-		return nil, err
-	}
-
-	return tokens, nil
+	return tokens, err
 }
 
 // Create creates additional tokens.
@@ -63,24 +71,7 @@ func (s *TokensService) Create(ctx context.Context, name string) (*Token, error)
 		return nil, fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodPost, endpoint, Token{Name: name})
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusCreated {
-		return nil, handleError(resp)
-	}
-
-	var token Token
-	err = handleResponse(resp, &token)
+	token, _, err := do[Token](ctx, s.client, http.MethodPost, endpoint, Token{Name: name})
 	if err != nil {
 		return nil, err
 	}
@@ -96,21 +87,7 @@ func (s *TokensService) Delete(ctx context.Context, tokenID string) error {
 		return fmt.Errorf("failed to create endpoint: %w", err)
 	}
 
-	req, err := s.client.newRequest(ctx, http.MethodDelete, endpoint, nil)
-	if err != nil {
-		return err
-	}
+	_, _, err = do[struct{}](ctx, s.client, http.MethodDelete, endpoint, nil)
 
-	resp, err := s.client.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to call API: %w", err)
-	}
-
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != http.StatusNoContent {
-		return handleError(resp)
-	}
-
-	return nil
+	return err
 }
@@ -2,10 +2,12 @@ package desec
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,6 +28,17 @@ func TestTokensService_Create(t *testing.T) {
 			return
 		}
 
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if strings.TrimSpace(string(body)) != `{"name":"my new token"}` {
+			http.Error(rw, fmt.Sprintf("invalid body: %s", body), http.StatusBadRequest)
+			return
+		}
+
 		rw.WriteHeader(http.StatusCreated)
 		file, err := os.Open("./fixtures/tokens_create.json")
 		if err != nil {
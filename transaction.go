@@ -0,0 +1,142 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ChangeAction the kind of change applied to a RRSet.
+type ChangeAction string
+
+const (
+	// ChangeCreate creates a RRSet.
+	ChangeCreate ChangeAction = "create"
+	// ChangeUpdate replaces a RRSet.
+	ChangeUpdate ChangeAction = "update"
+	// ChangeDelete deletes a RRSet.
+	ChangeDelete ChangeAction = "delete"
+)
+
+// RRSetChange a change to apply to a RRSet.
+type RRSetChange struct {
+	Action ChangeAction
+	RRSet  RRSet
+}
+
+// ApplyError an error that occurred during ApplyAtomic.
+type ApplyError struct {
+	// Index of the change that failed.
+	Index int
+	// Change the change that failed.
+	Change RRSetChange
+	// Err the error returned by the failed change.
+	Err error
+	// RollbackErr the error returned while restoring the prior state, if any.
+	RollbackErr error
+}
+
+func (e *ApplyError) Error() string {
+	msg := fmt.Sprintf("change %d (%s %s/%s) failed: %v", e.Index, e.Change.Action, e.Change.RRSet.SubName, e.Change.RRSet.Type, e.Err)
+
+	if e.RollbackErr != nil {
+		msg += fmt.Sprintf(": rollback failed: %v", e.RollbackErr)
+	}
+
+	return msg
+}
+
+// Unwrap unwraps error.
+func (e *ApplyError) Unwrap() []error {
+	if e.RollbackErr == nil {
+		return []error{e.Err}
+	}
+
+	return []error{e.Err, e.RollbackErr}
+}
+
+// ApplyAtomic applies changes one by one and, if a change fails,
+// restores the prior state of the RRSets already modified.
+//
+// The deSEC API is not transactional across requests,
+// the rollback is a best effort: the returned ApplyError contains the rollback error if the restoration fails.
+func (s *RecordsService) ApplyAtomic(ctx context.Context, domainName string, changes []RRSetChange) error {
+	current, err := s.GetAll(ctx, domainName, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get prior state: %w", err)
+	}
+
+	prior := NewZone(current)
+
+	for i, change := range changes {
+		err = s.applyChange(ctx, domainName, change)
+		if err == nil {
+			continue
+		}
+
+		applyErr := &ApplyError{Index: i, Change: change, Err: err}
+
+		// An RRSet changed several times is restored once, to its prior state.
+		restore := make([]RRSet, 0, i)
+		seen := make(map[RRSetKey]struct{}, i)
+
+		for _, applied := range changes[:i] {
+			key := applied.RRSet.Key()
+			if _, ok := seen[key]; ok {
+				continue
+			}
+
+			seen[key] = struct{}{}
+
+			restore = append(restore, priorState(prior, applied.RRSet))
+		}
+
+		if len(restore) > 0 {
+			// Uses the context without cancellation: the rollback must happen even if the context has been canceled.
+			_, applyErr.RollbackErr = s.BulkUpdate(context.WithoutCancel(ctx), FullResource, domainName, restore)
+		}
+
+		return applyErr
+	}
+
+	return nil
+}
+
+func (s *RecordsService) applyChange(ctx context.Context, domainName string, change RRSetChange) error {
+	rrSet := change.RRSet
+	rrSet.Domain = domainName
+
+	switch change.Action {
+	case ChangeCreate:
+		_, err := s.Create(ctx, rrSet)
+		return err
+
+	case ChangeUpdate:
+		_, err := s.Replace(ctx, domainName, rrSet.SubName, rrSet.Type, rrSet)
+		return err
+
+	case ChangeDelete:
+		return s.Delete(ctx, domainName, rrSet.SubName, rrSet.Type)
+
+	default:
+		return errors.New("unknown change action: " + string(change.Action))
+	}
+}
+
+// priorState returns the RRSet to send to restore the prior state.
+// An RRSet that did not exist is restored with empty records (i.e. deleted).
+func priorState(prior Zone, rrSet RRSet) RRSet {
+	if p, ok := prior.Get(rrSet.SubName, rrSet.Type); ok {
+		return RRSet{SubName: p.SubName, Type: p.Type, TTL: p.TTL, Records: p.Records}
+	}
+
+	return RRSet{SubName: normalizeSubName(rrSet.SubName), Type: rrSet.Type, TTL: rrSet.TTL, Records: []string{}}
+}
+
+func normalizeSubName(subName string) string {
+	if subName == ApexZone {
+		return ""
+	}
+
+	return subName
+}
@@ -0,0 +1,203 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsService_ApplyAtomic(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		file, err := os.Open("./fixtures/records_getall.json")
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		_, err = io.Copy(rw, file)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/@/A/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut {
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{}`))
+	})
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/_acme-challenge/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, `{"detail":"fail"}`, http.StatusBadRequest)
+	})
+
+	err := client.Records.ApplyAtomic(context.Background(), "example.dedyn.io", []RRSetChange{
+		{Action: ChangeUpdate, RRSet: RRSet{SubName: "", Type: "A", TTL: 60, Records: []string{"10.10.10.11"}}},
+		{Action: ChangeDelete, RRSet: RRSet{SubName: "_acme-challenge", Type: "TXT"}},
+	})
+	require.Error(t, err)
+
+	var applyErr *ApplyError
+	require.ErrorAs(t, err, &applyErr)
+
+	assert.Equal(t, 1, applyErr.Index)
+
+	// The rollback calls the bulk endpoint with PUT, which is not allowed by the test handler.
+	require.Error(t, applyErr.RollbackErr)
+}
+
+func TestRecordsService_ApplyAtomic_rollback(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var restored []RRSet
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			file, err := os.Open("./fixtures/records_getall.json")
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			defer func() { _ = file.Close() }()
+
+			_, _ = io.Copy(rw, file)
+
+		case http.MethodPost:
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{}`))
+
+		case http.MethodPut:
+			err := json.NewDecoder(req.Body).Decode(&restored)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			_, _ = rw.Write([]byte(`[]`))
+
+		default:
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/@/A/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{}`))
+	})
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/@/NS/", func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, `{"detail":"fail"}`, http.StatusBadRequest)
+	})
+
+	err := client.Records.ApplyAtomic(context.Background(), "example.dedyn.io", []RRSetChange{
+		{Action: ChangeCreate, RRSet: RRSet{SubName: "www", Type: "A", TTL: 3600, Records: []string{"10.10.10.12"}}},
+		{Action: ChangeUpdate, RRSet: RRSet{SubName: "@", Type: "A", TTL: 60, Records: []string{"10.10.10.11"}}},
+		{Action: ChangeDelete, RRSet: RRSet{SubName: "", Type: "NS"}},
+	})
+
+	var applyErr *ApplyError
+	require.ErrorAs(t, err, &applyErr)
+
+	assert.Equal(t, 2, applyErr.Index)
+	require.NoError(t, applyErr.RollbackErr)
+
+	expected := []RRSet{
+		{SubName: "www", Type: "A", TTL: 3600, Records: []string{}},
+		{SubName: "", Type: "A", TTL: 60, Records: []string{"10.10.10.10"}},
+	}
+	assert.Equal(t, expected, restored)
+}
+
+func TestRecordsService_ApplyAtomic_rollback_sameRRSet(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var restored []RRSet
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			file, err := os.Open("./fixtures/records_getall.json")
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			defer func() { _ = file.Close() }()
+
+			_, _ = io.Copy(rw, file)
+
+		case http.MethodPost:
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{}`))
+
+		case http.MethodPut:
+			err := json.NewDecoder(req.Body).Decode(&restored)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			_, _ = rw.Write([]byte(`[]`))
+
+		default:
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/@/A/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{}`))
+	})
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/@/NS/", func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, `{"detail":"fail"}`, http.StatusBadRequest)
+	})
+
+	err := client.Records.ApplyAtomic(context.Background(), "example.dedyn.io", []RRSetChange{
+		{Action: ChangeUpdate, RRSet: RRSet{SubName: "@", Type: "A", TTL: 60, Records: []string{"10.10.10.11"}}},
+		{Action: ChangeUpdate, RRSet: RRSet{SubName: "", Type: "A", TTL: 300, Records: []string{"10.10.10.12"}}},
+		{Action: ChangeDelete, RRSet: RRSet{SubName: "", Type: "NS"}},
+	})
+
+	var applyErr *ApplyError
+	require.ErrorAs(t, err, &applyErr)
+
+	assert.Equal(t, 2, applyErr.Index)
+	require.NoError(t, applyErr.RollbackErr)
+
+	expected := []RRSet{
+		{SubName: "", Type: "A", TTL: 60, Records: []string{"10.10.10.10"}},
+	}
+	assert.Equal(t, expected, restored)
+}
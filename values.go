@@ -0,0 +1,127 @@
+package desec
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// AddValues adds values to a RRSet (read-modify-write).
+// The RRSet is created with the default TTL (ClientOptions.DefaultTTL, WithDefaultTTL, or 3600) if it doesn't exist.
+// Returns the current RRSet, unchanged if all the values are already present.
+func (s *RecordsService) AddValues(ctx context.Context, domainName, subName, recordType string, values ...string) (*RRSet, error) {
+	return s.modifyValues(ctx, domainName, subName, recordType, func(records []string) []string {
+		for _, value := range values {
+			if !slices.Contains(records, value) {
+				records = append(records, value)
+			}
+		}
+
+		return records
+	})
+}
+
+// RemoveValues removes values from a RRSet (read-modify-write).
+// The RRSet is deleted if no value remains.
+// Returns the current RRSet, or nil if the RRSet doesn't exist (anymore).
+func (s *RecordsService) RemoveValues(ctx context.Context, domainName, subName, recordType string, values ...string) (*RRSet, error) {
+	return s.modifyValues(ctx, domainName, subName, recordType, func(records []string) []string {
+		return slices.DeleteFunc(records, func(record string) bool {
+			return slices.Contains(values, record)
+		})
+	})
+}
+
+// modifyValues applies a modification to the records of a RRSet.
+// When ClientOptions.ConflictRetries is set, the RRSet is re-fetched before the write, the response of the write is compared
+// with the written records, and the modification is retried if the RRSet has been changed concurrently.
+// The deSEC API has no conditional writes: the detection is best-effort,
+// a concurrent write between the check and the write can still be overwritten.
+func (s *RecordsService) modifyValues(ctx context.Context, domainName, subName, recordType string, modify func([]string) []string) (*RRSet, error) {
+	for attempt := 0; ; attempt++ {
+		rrSet, err := s.modifyValuesOnce(ctx, domainName, subName, recordType, modify)
+		if err == nil {
+			return rrSet, nil
+		}
+
+		var conflictErr *ConflictError
+		if !errors.As(err, &conflictErr) || attempt >= s.client.conflictRetries {
+			return nil, err
+		}
+	}
+}
+
+func (s *RecordsService) modifyValuesOnce(ctx context.Context, domainName, subName, recordType string, modify func([]string) []string) (*RRSet, error) {
+	current, err := s.getOrNil(ctx, domainName, subName, recordType)
+	if err != nil {
+		return nil, err
+	}
+
+	rrSet := RRSet{Domain: domainName, SubName: normalizeSubName(subName), Type: recordType, TTL: s.client.effectiveDefaultTTL(ctx)}
+	if current != nil {
+		rrSet.TTL = current.TTL
+		rrSet.Records = current.Records
+	}
+
+	rrSet.Records = modify(slices.Clone(rrSet.Records))
+
+	if current != nil && slices.Equal(rrSet.Records, current.Records) {
+		return current, nil
+	}
+
+	if current == nil && len(rrSet.Records) == 0 {
+		return nil, nil
+	}
+
+	if s.client.conflictRetries > 0 {
+		err = s.checkUnchanged(ctx, current, rrSet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(rrSet.Records) == 0 {
+		return nil, s.Delete(ctx, domainName, subName, recordType)
+	}
+
+	written, err := s.upsert(ctx, rrSet)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.client.conflictRetries > 0 && written != nil && !sameRecords(*written, rrSet) {
+		return nil, &ConflictError{Domain: rrSet.Domain, SubName: rrSet.SubName, Type: rrSet.Type}
+	}
+
+	return written, nil
+}
+
+// checkUnchanged re-fetches the RRSet and returns a ConflictError if it differs from the previously read one.
+func (s *RecordsService) checkUnchanged(ctx context.Context, previous *RRSet, rrSet RRSet) error {
+	latest, err := s.getOrNil(ctx, rrSet.Domain, rrSet.SubName, rrSet.Type)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case previous == nil && latest == nil:
+		return nil
+	case previous == nil, latest == nil, rrSetChanged(*previous, *latest):
+		return &ConflictError{Domain: rrSet.Domain, SubName: rrSet.SubName, Type: rrSet.Type}
+	default:
+		return nil
+	}
+}
+
+func (s *RecordsService) getOrNil(ctx context.Context, domainName, subName, recordType string) (*RRSet, error) {
+	rrSet, err := s.Get(ctx, domainName, subName, recordType)
+	if err != nil {
+		if errors.As(err, new(*NotFoundError)) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return rrSet, nil
+}
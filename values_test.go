@@ -0,0 +1,257 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupValuesTest(t *testing.T, current string) (*Client, *[]RRSet, *bool) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	var (
+		written []RRSet
+		deleted bool
+	)
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/_acme-challenge/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			if current == "" {
+				http.Error(rw, `{"detail":"Not found."}`, http.StatusNotFound)
+				return
+			}
+
+			_, _ = rw.Write([]byte(current))
+
+		case http.MethodDelete:
+			deleted = true
+			rw.WriteHeader(http.StatusNoContent)
+
+		default:
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut {
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		err := json.NewDecoder(req.Body).Decode(&written)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(rw).Encode(written)
+	})
+
+	return client, &written, &deleted
+}
+
+func TestRecordsService_AddValues(t *testing.T) {
+	client, written, _ := setupValuesTest(t, `{"subname":"_acme-challenge","type":"TXT","records":["\"a\""],"ttl":300}`)
+
+	rrSet, err := client.Records.AddValues(context.Background(), "example.dedyn.io", "_acme-challenge", "TXT", `"a"`, `"b"`)
+	require.NoError(t, err)
+
+	expected := []RRSet{{Domain: "example.dedyn.io", SubName: "_acme-challenge", Type: "TXT", TTL: 300, Records: []string{`"a"`, `"b"`}}}
+	assert.Equal(t, expected, *written)
+	assert.Equal(t, []string{`"a"`, `"b"`}, rrSet.Records)
+}
+
+func TestRecordsService_AddValues_notFound(t *testing.T) {
+	client, written, _ := setupValuesTest(t, "")
+
+	_, err := client.Records.AddValues(context.Background(), "example.dedyn.io", "_acme-challenge", "TXT", `"a"`)
+	require.NoError(t, err)
+
+	expected := []RRSet{{Domain: "example.dedyn.io", SubName: "_acme-challenge", Type: "TXT", TTL: fallbackTTL, Records: []string{`"a"`}}}
+	assert.Equal(t, expected, *written)
+}
+
+func TestRecordsService_AddValues_notFoundDefaultTTL(t *testing.T) {
+	client, written, _ := setupValuesTest(t, "")
+	client.defaultTTL = 7200
+
+	_, err := client.Records.AddValues(context.Background(), "example.dedyn.io", "_acme-challenge", "TXT", `"a"`)
+	require.NoError(t, err)
+
+	expected := []RRSet{{Domain: "example.dedyn.io", SubName: "_acme-challenge", Type: "TXT", TTL: 7200, Records: []string{`"a"`}}}
+	assert.Equal(t, expected, *written)
+
+	_, err = client.Records.AddValues(WithDefaultTTL(context.Background(), 600), "example.dedyn.io", "_acme-challenge", "TXT", `"a"`)
+	require.NoError(t, err)
+
+	expected[0].TTL = 600
+	assert.Equal(t, expected, *written)
+}
+
+func TestRecordsService_AddValues_unchanged(t *testing.T) {
+	client, written, _ := setupValuesTest(t, `{"subname":"_acme-challenge","type":"TXT","records":["\"a\""],"ttl":300}`)
+
+	rrSet, err := client.Records.AddValues(context.Background(), "example.dedyn.io", "_acme-challenge", "TXT", `"a"`)
+	require.NoError(t, err)
+
+	assert.Nil(t, *written)
+	assert.Equal(t, []string{`"a"`}, rrSet.Records)
+}
+
+func TestRecordsService_RemoveValues(t *testing.T) {
+	client, written, deleted := setupValuesTest(t, `{"subname":"_acme-challenge","type":"TXT","records":["\"a\"","\"b\""],"ttl":300}`)
+
+	rrSet, err := client.Records.RemoveValues(context.Background(), "example.dedyn.io", "_acme-challenge", "TXT", `"a"`)
+	require.NoError(t, err)
+
+	expected := []RRSet{{Domain: "example.dedyn.io", SubName: "_acme-challenge", Type: "TXT", TTL: 300, Records: []string{`"b"`}}}
+	assert.Equal(t, expected, *written)
+	assert.Equal(t, []string{`"b"`}, rrSet.Records)
+	assert.False(t, *deleted)
+}
+
+func TestRecordsService_RemoveValues_empty(t *testing.T) {
+	client, written, deleted := setupValuesTest(t, `{"subname":"_acme-challenge","type":"TXT","records":["\"a\""],"ttl":300}`)
+
+	rrSet, err := client.Records.RemoveValues(context.Background(), "example.dedyn.io", "_acme-challenge", "TXT", `"a"`)
+	require.NoError(t, err)
+
+	assert.Nil(t, rrSet)
+	assert.Nil(t, *written)
+	assert.True(t, *deleted)
+}
+
+func TestRecordsService_RemoveValues_notFound(t *testing.T) {
+	client, _, deleted := setupValuesTest(t, "")
+
+	rrSet, err := client.Records.RemoveValues(context.Background(), "example.dedyn.io", "_acme-challenge", "TXT", `"a"`)
+	require.NoError(t, err)
+
+	assert.Nil(t, rrSet)
+	assert.False(t, *deleted)
+}
+
+func TestRecordsService_AddValues_conflict(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	opts := NewDefaultClientOptions()
+	opts.ConflictRetries = 1
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	// The RRSet is modified by a concurrent writer between the first read and the check.
+	responses := []string{
+		`{"subname":"_acme-challenge","type":"TXT","records":["\"a\""],"ttl":300,"touched":"2020-05-06T11:46:07.641885Z"}`,
+		`{"subname":"_acme-challenge","type":"TXT","records":["\"a\"","\"c\""],"ttl":300,"touched":"2020-05-07T11:46:07.641885Z"}`,
+	}
+
+	var calls int
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/_acme-challenge/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(responses[min(calls, len(responses)-1)]))
+		calls++
+	})
+
+	var written []RRSet
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(&written)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(rw).Encode(written)
+	})
+
+	_, err := client.Records.AddValues(context.Background(), "example.dedyn.io", "_acme-challenge", "TXT", `"b"`)
+	require.NoError(t, err)
+
+	require.Len(t, written, 1)
+	assert.Equal(t, []string{`"a"`, `"b"`, `"c"`}, written[0].Records)
+	assert.Equal(t, 4, calls)
+}
+
+func TestRecordsService_AddValues_conflictExhausted(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	opts := NewDefaultClientOptions()
+	opts.ConflictRetries = 2
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	var calls int
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/_acme-challenge/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		_, _ = fmt.Fprintf(rw, `{"subname":"_acme-challenge","type":"TXT","records":["\"%d\""],"ttl":300}`, calls)
+	})
+
+	_, err := client.Records.AddValues(context.Background(), "example.dedyn.io", "_acme-challenge", "TXT", `"b"`)
+	require.ErrorAs(t, err, new(*ConflictError))
+
+	assert.Equal(t, 6, calls)
+}
+
+func TestRecordsService_AddValues_conflictOnWrite(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	opts := NewDefaultClientOptions()
+	opts.ConflictRetries = 1
+
+	client := New("token", opts)
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/_acme-challenge/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"subname":"_acme-challenge","type":"TXT","records":["\"a\""],"ttl":300}`))
+	})
+
+	var writes int
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		var written []RRSet
+
+		err := json.NewDecoder(req.Body).Decode(&written)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		writes++
+
+		// The first write is interleaved with a concurrent write: the response doesn't match the written records.
+		if writes == 1 {
+			written[0].Records = []string{`"a"`, `"c"`}
+		}
+
+		_ = json.NewEncoder(rw).Encode(written)
+	})
+
+	rrSet, err := client.Records.AddValues(context.Background(), "example.dedyn.io", "_acme-challenge", "TXT", `"b"`)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, writes)
+	assert.Equal(t, []string{`"a"`, `"b"`}, rrSet.Records)
+}
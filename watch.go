@@ -0,0 +1,140 @@
+package desec
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// WatchEventType the type of change observed by Watch.
+type WatchEventType string
+
+const (
+	// RRSetAdded a RRSet has been created.
+	RRSetAdded WatchEventType = "added"
+	// RRSetModified a RRSet has been modified.
+	RRSetModified WatchEventType = "modified"
+	// RRSetRemoved a RRSet has been deleted.
+	RRSetRemoved WatchEventType = "removed"
+)
+
+// WatchEvent a change observed by Watch.
+type WatchEvent struct {
+	Type   WatchEventType
+	Domain string
+	Before *RRSet
+	After  *RRSet
+
+	// Err is set when a poll failed, the other fields are empty.
+	Err error
+}
+
+// Watch polls the RRSets of a domain and emits a WatchEvent for each change.
+// The first poll is used as reference, it doesn't emit events.
+// The events of a poll are sorted by subname and type.
+// The channel is closed when the context is canceled.
+// The events can be posted to a webhook with WebhookNotifier.Forward.
+func (s *RecordsService) Watch(ctx context.Context, domainName string, interval time.Duration) (<-chan WatchEvent, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid interval: must be positive: %s", interval)
+	}
+
+	rrSets, err := s.GetAll(ctx, domainName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get initial state: %w", err)
+	}
+
+	events := make(chan WatchEvent)
+
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		previous := NewZone(rrSets)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			rrSets, err := s.GetAll(ctx, domainName, nil)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+
+				if !sendEvent(ctx, events, WatchEvent{Domain: domainName, Err: err}) {
+					return
+				}
+
+				continue
+			}
+
+			current := NewZone(rrSets)
+
+			for _, event := range diffRRSets(domainName, previous, current) {
+				if !sendEvent(ctx, events, event) {
+					return
+				}
+			}
+
+			previous = current
+		}
+	}()
+
+	return events, nil
+}
+
+func sendEvent(ctx context.Context, events chan<- WatchEvent, event WatchEvent) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case events <- event:
+		return true
+	}
+}
+
+// diffRRSets returns the events of the changes between two polls, sorted by key.
+func diffRRSets(domainName string, previous, current Zone) []WatchEvent {
+	keys := current.Keys()
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.SortFunc(keys, compareRRSetKeys)
+
+	var events []WatchEvent
+
+	for _, key := range keys {
+		before, inPrevious := previous[key]
+		after, inCurrent := current[key]
+
+		switch {
+		case !inPrevious:
+			events = append(events, WatchEvent{Type: RRSetAdded, Domain: domainName, After: &after})
+
+		case !inCurrent:
+			events = append(events, WatchEvent{Type: RRSetRemoved, Domain: domainName, Before: &before})
+
+		case rrSetChanged(before, after):
+			events = append(events, WatchEvent{Type: RRSetModified, Domain: domainName, Before: &before, After: &after})
+		}
+	}
+
+	return events
+}
+
+func rrSetChanged(before, after RRSet) bool {
+	if before.Touched != nil && after.Touched != nil && !before.Touched.Equal(*after.Touched) {
+		return true
+	}
+
+	return before.TTL != after.TTL || !slices.Equal(before.Records, after.Records)
+}
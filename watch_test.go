@@ -0,0 +1,118 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordsService_Watch(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	responses := []string{
+		`[{"subname":"","type":"A","records":["10.10.10.10"],"ttl":60,"touched":"2020-05-06T11:46:07.641885Z"},
+		  {"subname":"www","type":"A","records":["10.10.10.10"],"ttl":60,"touched":"2020-05-06T11:46:07.641885Z"}]`,
+		`[{"subname":"","type":"A","records":["10.10.10.11"],"ttl":60,"touched":"2020-05-07T11:46:07.641885Z"},
+		  {"subname":"_acme-challenge","type":"TXT","records":["\"txt\""],"ttl":300,"touched":"2020-05-07T11:46:07.641885Z"}]`,
+	}
+
+	var calls atomic.Int32
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, "invalid method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		i := min(int(calls.Add(1))-1, len(responses)-1)
+
+		_, _ = rw.Write([]byte(responses[i]))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	events, err := client.Records.Watch(ctx, "example.dedyn.io", 10*time.Millisecond)
+	require.NoError(t, err)
+
+	received := map[WatchEventType]WatchEvent{}
+	for len(received) < 3 {
+		select {
+		case event := <-events:
+			require.NoError(t, event.Err)
+			received[event.Type] = event
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	cancel()
+
+	require.Contains(t, received, RRSetModified)
+	assert.Equal(t, []string{"10.10.10.10"}, received[RRSetModified].Before.Records)
+	assert.Equal(t, []string{"10.10.10.11"}, received[RRSetModified].After.Records)
+
+	require.Contains(t, received, RRSetAdded)
+	assert.Equal(t, "_acme-challenge", received[RRSetAdded].After.SubName)
+	assert.Nil(t, received[RRSetAdded].Before)
+
+	require.Contains(t, received, RRSetRemoved)
+	assert.Equal(t, "www", received[RRSetRemoved].Before.SubName)
+	assert.Nil(t, received[RRSetRemoved].After)
+}
+
+func TestRecordsService_Watch_invalidInterval(t *testing.T) {
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = "http://desec.invalid/"
+
+	_, err := client.Records.Watch(context.Background(), "example.dedyn.io", 0)
+	require.EqualError(t, err, "invalid interval: must be positive: 0s")
+}
+
+func Test_diffRRSets_order(t *testing.T) {
+	previous := NewZone([]RRSet{
+		{SubName: "", Type: "A", TTL: 60, Records: []string{"10.10.10.10"}},
+		{SubName: "b", Type: "A", TTL: 60, Records: []string{"10.10.10.10"}},
+		{SubName: "d", Type: "TXT", TTL: 60, Records: []string{`"txt"`}},
+	})
+
+	current := NewZone([]RRSet{
+		{SubName: "", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}},
+		{SubName: "a", Type: "AAAA", TTL: 60, Records: []string{"::1"}},
+		{SubName: "c", Type: "MX", TTL: 60, Records: []string{"10 mail.example.com."}},
+		{SubName: "c", Type: "A", TTL: 60, Records: []string{"10.10.10.10"}},
+	})
+
+	for range 10 {
+		var keys []string
+		for _, event := range diffRRSets("example.com", previous, current) {
+			rrSet := event.After
+			if rrSet == nil {
+				rrSet = event.Before
+			}
+
+			keys = append(keys, string(event.Type)+" "+rrSet.Key().String())
+		}
+
+		expected := []string{
+			"modified /A",
+			"added a/AAAA",
+			"removed b/A",
+			"added c/A",
+			"added c/MX",
+			"removed d/TXT",
+		}
+		assert.Equal(t, expected, keys)
+	}
+}
@@ -0,0 +1,139 @@
+package desec
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WebhookSignatureHeader the header of the signature of the webhook payloads: "sha256=" followed by
+// the hex encoded HMAC-SHA256 of the body with the secret of the WebhookNotifier.
+const WebhookSignatureHeader = "X-Desec-Signature"
+
+// WebhookPayload the body posted by WebhookNotifier for each change.
+type WebhookPayload struct {
+	Type   WatchEventType `json:"type"`
+	Domain string         `json:"domain"`
+	// RRSet the key of the RRSet: "<subname>/<type>", the subname is empty at the zone apex.
+	RRSet  string    `json:"rrset"`
+	Before *RRSet    `json:"before,omitempty"`
+	After  *RRSet    `json:"after,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+// WebhookNotifier posts the changes observed by Watch to a webhook (ex: to alert on unexpected zone edits).
+//
+//	events, err := client.Records.Watch(ctx, "example.com", time.Minute)
+//	// ...
+//	notifier := desec.NewWebhookNotifier("https://hooks.example.com/desec", secret)
+//	notifier.Forward(ctx, events)
+type WebhookNotifier struct {
+	URL string
+	// Secret the key of the signature of the payloads (WebhookSignatureHeader), no signature if empty.
+	Secret []byte
+
+	HTTPClient *http.Client
+
+	// OnError is called with the poll errors and the failed notifications (optional).
+	OnError func(err error)
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier.
+func NewWebhookNotifier(url string, secret []byte) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL:        url,
+		Secret:     secret,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Forward posts the changes received on the channel (Watch) until the channel is closed.
+// The errors are passed to OnError, they don't stop the forwarding.
+func (n *WebhookNotifier) Forward(ctx context.Context, events <-chan WatchEvent) {
+	for event := range events {
+		err := event.Err
+		if err == nil {
+			err = n.Notify(ctx, event)
+		}
+
+		if err != nil && n.OnError != nil {
+			n.OnError(err)
+		}
+	}
+}
+
+// Notify posts a change to the webhook, any non-2xx response is an error.
+func (n *WebhookNotifier) Notify(ctx context.Context, event WatchEvent) error {
+	payload := WebhookPayload{
+		Type:   event.Type,
+		Domain: event.Domain,
+		Before: event.Before,
+		After:  event.After,
+		Time:   time.Now().UTC(),
+	}
+
+	rrSet := event.After
+	if rrSet == nil {
+		rrSet = event.Before
+	}
+
+	if rrSet != nil {
+		payload.RRSet = rrSet.Key().String()
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if len(n.Secret) > 0 {
+		req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(n.Secret, body))
+	}
+
+	client := n.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodySnippet))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s %s/%s: unexpected status code %d", event.Type, event.Domain, payload.RRSet, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// SignWebhookPayload returns the signature of a webhook payload (WebhookSignatureHeader).
+func SignWebhookPayload(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature checks the signature of a webhook payload, in constant time.
+func VerifyWebhookSignature(secret, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignWebhookPayload(secret, body)), []byte(signature))
+}
@@ -0,0 +1,102 @@
+package desec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifier_Forward(t *testing.T) {
+	secret := []byte("secret")
+
+	var payloads []WebhookPayload
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if !VerifyWebhookSignature(secret, body, req.Header.Get(WebhookSignatureHeader)) {
+			http.Error(rw, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		var payload WebhookPayload
+
+		err = json.Unmarshal(body, &payload)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		payloads = append(payloads, payload)
+	}))
+	t.Cleanup(server.Close)
+
+	var errs []error
+
+	notifier := NewWebhookNotifier(server.URL, secret)
+	notifier.OnError = func(err error) { errs = append(errs, err) }
+
+	events := make(chan WatchEvent, 3)
+	events <- WatchEvent{
+		Type:   RRSetModified,
+		Domain: "example.com",
+		Before: &RRSet{SubName: "www", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}},
+		After:  &RRSet{SubName: "www", Type: "A", TTL: 3600, Records: []string{"10.10.10.11"}},
+	}
+	events <- WatchEvent{Domain: "example.com", Err: errors.New("poll failed")}
+	events <- WatchEvent{
+		Type:   RRSetRemoved,
+		Domain: "example.com",
+		Before: &RRSet{SubName: "", Type: "TXT", TTL: 3600, Records: []string{`"foo"`}},
+	}
+	close(events)
+
+	notifier.Forward(context.Background(), events)
+
+	require.Len(t, payloads, 2)
+
+	assert.Equal(t, RRSetModified, payloads[0].Type)
+	assert.Equal(t, "www/A", payloads[0].RRSet)
+	assert.Equal(t, []string{"10.10.10.10"}, payloads[0].Before.Records)
+	assert.Equal(t, []string{"10.10.10.11"}, payloads[0].After.Records)
+
+	assert.Equal(t, RRSetRemoved, payloads[1].Type)
+	assert.Equal(t, "/TXT", payloads[1].RRSet)
+	assert.Nil(t, payloads[1].After)
+
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "poll failed")
+}
+
+func TestWebhookNotifier_Notify_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "invalid signature", http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	notifier := NewWebhookNotifier(server.URL, []byte("other"))
+
+	err := notifier.Notify(context.Background(), WatchEvent{Type: RRSetAdded, Domain: "example.com", After: &RRSet{SubName: "www", Type: "A"}})
+	require.EqualError(t, err, "webhook added example.com/www/A: unexpected status code 401")
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"type":"added"}`)
+
+	signature := SignWebhookPayload([]byte("secret"), body)
+
+	assert.True(t, VerifyWebhookSignature([]byte("secret"), body, signature))
+	assert.False(t, VerifyWebhookSignature([]byte("other"), body, signature))
+	assert.False(t, VerifyWebhookSignature([]byte("secret"), []byte(`{}`), signature))
+}
@@ -0,0 +1,97 @@
+package desec
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// RRSetKey identifies an RRSet in a domain.
+type RRSetKey struct {
+	SubName string
+	Type    string
+}
+
+// NewRRSetKey creates a RRSetKey, the zone apex ("@") is normalized to "".
+func NewRRSetKey(subName, recordType string) RRSetKey {
+	return RRSetKey{SubName: normalizeSubName(subName), Type: recordType}
+}
+
+// String returns the key as "subname/TYPE" (ex: "www/A", "/MX" for the zone apex).
+func (k RRSetKey) String() string {
+	return k.SubName + "/" + k.Type
+}
+
+// Key returns the key of the RRSet.
+func (r RRSet) Key() RRSetKey {
+	return NewRRSetKey(r.SubName, r.Type)
+}
+
+// Zone the RRSets of a domain, indexed by key.
+type Zone map[RRSetKey]RRSet
+
+// NewZone creates a Zone from RRSets (ex: the result of RecordsService.GetAll).
+// If several RRSets have the same key, the last one wins.
+func NewZone(rrSets []RRSet) Zone {
+	zone := make(Zone, len(rrSets))
+
+	for _, rrSet := range rrSets {
+		zone.Insert(rrSet)
+	}
+
+	return zone
+}
+
+// Get returns the RRSet of a subname and a type.
+func (z Zone) Get(subName, recordType string) (RRSet, bool) {
+	rrSet, ok := z[NewRRSetKey(subName, recordType)]
+
+	return rrSet, ok
+}
+
+// Insert adds an RRSet to the zone, or replaces the RRSet with the same key.
+func (z Zone) Insert(rrSet RRSet) {
+	z[rrSet.Key()] = rrSet
+}
+
+// Remove removes the RRSet of a subname and a type.
+func (z Zone) Remove(subName, recordType string) {
+	delete(z, NewRRSetKey(subName, recordType))
+}
+
+// Keys returns the keys of the zone, sorted by subname and type.
+func (z Zone) Keys() []RRSetKey {
+	keys := make([]RRSetKey, 0, len(z))
+	for key := range z {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, compareRRSetKeys)
+
+	return keys
+}
+
+// compareRRSetKeys orders the keys by subname and type.
+func compareRRSetKeys(a, b RRSetKey) int {
+	return cmp.Or(cmp.Compare(a.SubName, b.SubName), cmp.Compare(a.Type, b.Type))
+}
+
+// RRSets returns the RRSets of the zone, sorted by subname and type.
+func (z Zone) RRSets() []RRSet {
+	rrSets := make([]RRSet, 0, len(z))
+	for _, key := range z.Keys() {
+		rrSets = append(rrSets, z[key])
+	}
+
+	return rrSets
+}
+
+// GetZone retrieves all the RRSets of a domain as a Zone.
+func (s *RecordsService) GetZone(ctx context.Context, domainName string) (Zone, error) {
+	rrSets, err := s.GetAll(ctx, domainName, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewZone(rrSets), nil
+}
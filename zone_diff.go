@@ -0,0 +1,209 @@
+package desec
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// unifiedContext the number of unchanged lines around the changes of a unified diff.
+const unifiedContext = 3
+
+// zoneLine a record of a zone file.
+type zoneLine struct {
+	subName string
+	rrType  string
+	text    string
+}
+
+func compareZoneLines(a, b zoneLine) int {
+	return cmp.Or(
+		cmp.Compare(a.subName, b.subName),
+		cmp.Compare(a.rrType, b.rrType),
+		cmp.Compare(a.text, b.text),
+	)
+}
+
+// UnifiedZoneDiff renders the differences between the live RRSets of a domain and the desired RRSets
+// as a unified diff of zone files, for a human review (ex: in a CI pipeline, before Sync).
+// The records are sorted by subname and type, the RRSets managed by deSEC (apex NS, DNSSEC) are ignored.
+// Returns an empty string if there is no difference.
+func UnifiedZoneDiff(domainName string, live, desired []RRSet) (string, error) {
+	from, err := zoneLines(domainName, live)
+	if err != nil {
+		return "", fmt.Errorf("live zone: %w", err)
+	}
+
+	to, err := zoneLines(domainName, desired)
+	if err != nil {
+		return "", fmt.Errorf("desired zone: %w", err)
+	}
+
+	ops := diffZoneLines(from, to)
+	if !slices.ContainsFunc(ops, func(op diffOp) bool { return op.kind != ' ' }) {
+		return "", nil
+	}
+
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintf(&sb, "--- live/%s\n+++ desired/%s\n", domainName, domainName)
+
+	writeHunks(&sb, ops)
+
+	return sb.String(), nil
+}
+
+// DiffZonefile reads a BIND zone file (ParseZonefile), fetches the live RRSets of the domain,
+// and renders the differences as a unified diff (UnifiedZoneDiff).
+func (s *RecordsService) DiffZonefile(ctx context.Context, domainName string, r io.Reader) (string, error) {
+	desired, err := ParseZonefile(domainName, r)
+	if err != nil {
+		return "", err
+	}
+
+	live, err := s.GetAll(ctx, domainName, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current state: %w", err)
+	}
+
+	return UnifiedZoneDiff(domainName, live, desired)
+}
+
+// zoneLines renders the records of the RRSets as sorted zone file lines.
+func zoneLines(domainName string, rrSets []RRSet) ([]zoneLine, error) {
+	var lines []zoneLine
+
+	for _, rrSet := range rrSets {
+		subName := normalizeSubName(rrSet.SubName)
+
+		if IsManagedType(rrSet.Type) || (subName == "" && rrSet.Type == RRTypeNS) {
+			continue
+		}
+
+		rrSet.Domain = domainName
+
+		rrs, err := RRSetToRRs(rrSet)
+		if err != nil {
+			return nil, fmt.Errorf("%s/%s: %w", subName, rrSet.Type, err)
+		}
+
+		for _, rr := range rrs {
+			lines = append(lines, zoneLine{subName: subName, rrType: rrSet.Type, text: rr.String()})
+		}
+	}
+
+	slices.SortFunc(lines, compareZoneLines)
+
+	return slices.CompactFunc(lines, func(a, b zoneLine) bool { return compareZoneLines(a, b) == 0 }), nil
+}
+
+// diffOp a line of a diff: ' ' unchanged, '-' removed, '+' added.
+type diffOp struct {
+	kind byte
+	text string
+}
+
+// diffZoneLines diffs two sorted lists of lines by merging them.
+func diffZoneLines(from, to []zoneLine) []diffOp {
+	var ops []diffOp
+
+	i, j := 0, 0
+
+	for i < len(from) || j < len(to) {
+		switch {
+		case j == len(to) || (i < len(from) && compareZoneLines(from[i], to[j]) < 0):
+			ops = append(ops, diffOp{kind: '-', text: from[i].text})
+			i++
+		case i == len(from) || compareZoneLines(from[i], to[j]) > 0:
+			ops = append(ops, diffOp{kind: '+', text: to[j].text})
+			j++
+		default:
+			ops = append(ops, diffOp{kind: ' ', text: from[i].text})
+			i++
+			j++
+		}
+	}
+
+	return ops
+}
+
+// writeHunks writes the changes with their context as unified diff hunks.
+func writeHunks(sb *strings.Builder, ops []diffOp) {
+	// The positions of the lines in the "from" and "to" files, before each operation.
+	fromLine := make([]int, len(ops)+1)
+	toLine := make([]int, len(ops)+1)
+
+	for k, op := range ops {
+		fromLine[k+1], toLine[k+1] = fromLine[k], toLine[k]
+
+		if op.kind != '+' {
+			fromLine[k+1]++
+		}
+
+		if op.kind != '-' {
+			toLine[k+1]++
+		}
+	}
+
+	for k := 0; k < len(ops); {
+		if ops[k].kind == ' ' {
+			k++
+			continue
+		}
+
+		start := max(k-unifiedContext, 0)
+
+		// The hunk ends after the context of the last change closer than 2*context lines to the previous one.
+		end := k
+
+		for unchanged := 0; end < len(ops) && unchanged <= 2*unifiedContext; end++ {
+			if ops[end].kind == ' ' {
+				unchanged++
+			} else {
+				unchanged = 0
+			}
+		}
+
+		end = lastChange(ops, start, end) + 1
+		end = min(end+unifiedContext, len(ops))
+
+		_, _ = fmt.Fprintf(sb, "@@ -%s +%s @@\n",
+			hunkRange(fromLine[start], fromLine[end]-fromLine[start]),
+			hunkRange(toLine[start], toLine[end]-toLine[start]))
+
+		for _, op := range ops[start:end] {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.text)
+			sb.WriteByte('\n')
+		}
+
+		k = end
+	}
+}
+
+// lastChange returns the index of the last change of ops[start:end].
+func lastChange(ops []diffOp, start, end int) int {
+	for k := end - 1; k >= start; k-- {
+		if ops[k].kind != ' ' {
+			return k
+		}
+	}
+
+	return start
+}
+
+// hunkRange formats the range of a hunk, the lines start at 1 (0 for an empty range at the beginning).
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
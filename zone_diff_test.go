@@ -0,0 +1,85 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedZoneDiff(t *testing.T) {
+	var live, desired []RRSet
+
+	for _, subName := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"} {
+		live = append(live, RRSet{SubName: subName, Type: "A", TTL: 3600, Records: []string{"10.0.0.1"}})
+
+		switch subName {
+		case "b":
+			desired = append(desired, RRSet{SubName: subName, Type: "A", TTL: 3600, Records: []string{"10.0.0.2"}})
+		case "j":
+		default:
+			desired = append(desired, RRSet{SubName: subName, Type: "A", TTL: 3600, Records: []string{"10.0.0.1"}})
+		}
+	}
+
+	// Managed by deSEC: ignored.
+	live = append(live, RRSet{SubName: "", Type: "NS", TTL: 3600, Records: []string{"ns1.desec.io."}})
+
+	diff, err := UnifiedZoneDiff("example.com", live, desired)
+	require.NoError(t, err)
+
+	expected := `--- live/example.com
++++ desired/example.com
+@@ -1,5 +1,5 @@
+ a.example.com.	3600	IN	A	10.0.0.1
+-b.example.com.	3600	IN	A	10.0.0.1
++b.example.com.	3600	IN	A	10.0.0.2
+ c.example.com.	3600	IN	A	10.0.0.1
+ d.example.com.	3600	IN	A	10.0.0.1
+ e.example.com.	3600	IN	A	10.0.0.1
+@@ -7,4 +7,3 @@
+ g.example.com.	3600	IN	A	10.0.0.1
+ h.example.com.	3600	IN	A	10.0.0.1
+ i.example.com.	3600	IN	A	10.0.0.1
+-j.example.com.	3600	IN	A	10.0.0.1
+`
+	assert.Equal(t, expected, diff)
+}
+
+func TestUnifiedZoneDiff_noDifference(t *testing.T) {
+	live := []RRSet{{SubName: "www", Type: "AAAA", TTL: 3600, Records: []string{"2001:db8::1"}}}
+	desired := []RRSet{{SubName: "www", Type: "AAAA", TTL: 3600, Records: []string{"2001:0db8:0::1"}}}
+
+	diff, err := UnifiedZoneDiff("example.com", live, desired)
+	require.NoError(t, err)
+
+	assert.Empty(t, diff)
+}
+
+func TestRecordsService_DiffZonefile(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("GET /domains/example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`[{"subname":"","type":"NS","ttl":3600,"records":["ns1.desec.io."]},{"subname":"old","type":"A","ttl":3600,"records":["10.10.10.10"]}]`))
+	})
+
+	diff, err := client.Records.DiffZonefile(context.Background(), "example.com", strings.NewReader("$TTL 3600\nwww IN A 10.10.10.11\n"))
+	require.NoError(t, err)
+
+	expected := `--- live/example.com
++++ desired/example.com
+@@ -1 +1 @@
+-old.example.com.	3600	IN	A	10.10.10.10
++www.example.com.	3600	IN	A	10.10.10.11
+`
+	assert.Equal(t, expected, diff)
+}
@@ -0,0 +1,77 @@
+package desec
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRRSetKey(t *testing.T) {
+	assert.Equal(t, RRSetKey{SubName: "", Type: "A"}, NewRRSetKey("@", "A"))
+	assert.Equal(t, RRSetKey{SubName: "www", Type: "A"}, NewRRSetKey("www", "A"))
+
+	assert.Equal(t, "/MX", NewRRSetKey("@", "MX").String())
+	assert.Equal(t, "www/A", RRSet{SubName: "www", Type: "A"}.Key().String())
+}
+
+func TestZone(t *testing.T) {
+	zone := NewZone([]RRSet{
+		{SubName: "www", Type: "A", TTL: 3600, Records: []string{"10.10.10.10"}},
+		{SubName: "@", Type: "MX", TTL: 3600, Records: []string{"10 mail.example.com."}},
+		{SubName: "www", Type: "A", TTL: 60, Records: []string{"10.10.10.11"}},
+	})
+
+	require.Len(t, zone, 2)
+
+	rrSet, ok := zone.Get("www", "A")
+	require.True(t, ok)
+	assert.Equal(t, 60, rrSet.TTL)
+
+	_, ok = zone.Get("", "MX")
+	assert.True(t, ok)
+
+	_, ok = zone.Get("www", "AAAA")
+	assert.False(t, ok)
+
+	zone.Insert(RRSet{SubName: "api", Type: "CNAME", TTL: 3600, Records: []string{"www.example.com."}})
+
+	assert.Equal(t, []RRSetKey{{SubName: "", Type: "MX"}, {SubName: "api", Type: "CNAME"}, {SubName: "www", Type: "A"}}, zone.Keys())
+
+	zone.Remove("@", "MX")
+
+	rrSets := zone.RRSets()
+	require.Len(t, rrSets, 2)
+	assert.Equal(t, "api", rrSets[0].SubName)
+	assert.Equal(t, "www", rrSets[1].SubName)
+}
+
+func TestRecordsService_GetZone(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("token", NewDefaultClientOptions())
+	client.BaseURL = server.URL
+
+	mux.HandleFunc("/domains/example.dedyn.io/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, "unexpected method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`[{"subname":"","type":"A","ttl":3600,"records":["10.10.10.10"]},{"subname":"www","type":"A","ttl":3600,"records":["10.10.10.11"]}]`))
+	})
+
+	zone, err := client.Records.GetZone(context.Background(), "example.dedyn.io")
+	require.NoError(t, err)
+
+	assert.Equal(t, []RRSetKey{{SubName: "", Type: "A"}, {SubName: "www", Type: "A"}}, zone.Keys())
+
+	rrSet, ok := zone.Get("@", "A")
+	require.True(t, ok)
+	assert.Equal(t, []string{"10.10.10.10"}, rrSet.Records)
+}
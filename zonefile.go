@@ -0,0 +1,42 @@
+package desec
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// FormatZonefile renders RRSets as a BIND zone file.
+// The RRSets are sorted by owner name and type, the owner names are fully qualified.
+func FormatZonefile(domainName string, rrSets []RRSet) (string, error) {
+	sorted := slices.Clone(rrSets)
+	slices.SortStableFunc(sorted, func(a, b RRSet) int {
+		return cmp.Or(
+			cmp.Compare(normalizeSubName(a.SubName), normalizeSubName(b.SubName)),
+			cmp.Compare(a.Type, b.Type),
+		)
+	})
+
+	var sb strings.Builder
+
+	_, _ = fmt.Fprintf(&sb, "$ORIGIN %s\n", dns.Fqdn(domainName))
+
+	for _, rrSet := range sorted {
+		rrSet.Domain = domainName
+
+		rrs, err := RRSetToRRs(rrSet)
+		if err != nil {
+			return "", fmt.Errorf("%s/%s: %w", rrSet.SubName, rrSet.Type, err)
+		}
+
+		for _, rr := range rrs {
+			sb.WriteString(rr.String())
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String(), nil
+}
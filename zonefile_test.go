@@ -0,0 +1,38 @@
+package desec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatZonefile(t *testing.T) {
+	rrSets := []RRSet{
+		{SubName: "www", Type: "CNAME", TTL: 3600, Records: []string{"example.dedyn.io."}},
+		{SubName: "_acme-challenge", Type: "TXT", TTL: 300, Records: []string{`"foo bar"`}},
+		{SubName: "", Type: "MX", TTL: 3600, Records: []string{"10 mx.example.com."}},
+		{SubName: "", Type: "A", TTL: 3600, Records: []string{"10.10.10.10", "10.10.10.11"}},
+	}
+
+	zone, err := FormatZonefile("example.dedyn.io", rrSets)
+	require.NoError(t, err)
+
+	expected := "$ORIGIN example.dedyn.io.\n" +
+		"example.dedyn.io.\t3600\tIN\tA\t10.10.10.10\n" +
+		"example.dedyn.io.\t3600\tIN\tA\t10.10.10.11\n" +
+		"example.dedyn.io.\t3600\tIN\tMX\t10 mx.example.com.\n" +
+		"_acme-challenge.example.dedyn.io.\t300\tIN\tTXT\t\"foo bar\"\n" +
+		"www.example.dedyn.io.\t3600\tIN\tCNAME\texample.dedyn.io.\n"
+
+	assert.Equal(t, expected, zone)
+}
+
+func TestFormatZonefile_invalid(t *testing.T) {
+	rrSets := []RRSet{
+		{SubName: "", Type: "A", TTL: 3600, Records: []string{"invalid"}},
+	}
+
+	_, err := FormatZonefile("example.dedyn.io", rrSets)
+	require.Error(t, err)
+}
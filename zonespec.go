@@ -0,0 +1,131 @@
+package desec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ZoneSpec a declarative zone: the desired RRSets of a domain (Sync).
+//
+//	domain: example.com
+//	ttl: 3600
+//	rrsets:
+//	  - subname: www
+//	    type: A
+//	    records: [192.0.2.1, 192.0.2.2]
+//	  - subname: "@"
+//	    type: MX
+//	    ttl: 300
+//	    records: ["10 mx.example.com."]
+type ZoneSpec struct {
+	// Domain the domain of the zone (optional).
+	Domain string
+	// RRSets the desired RRSets, the TTLs are set.
+	RRSets []RRSet
+}
+
+type zoneSpecFile struct {
+	Domain string              `json:"domain" yaml:"domain"`
+	TTL    int                 `json:"ttl" yaml:"ttl"`
+	RRSets []zoneSpecFileRRSet `json:"rrsets" yaml:"rrsets"`
+}
+
+type zoneSpecFileRRSet struct {
+	SubName string   `json:"subname" yaml:"subname"`
+	Type    string   `json:"type" yaml:"type"`
+	TTL     int      `json:"ttl" yaml:"ttl"`
+	Records []string `json:"records" yaml:"records"`
+}
+
+// ZoneSpecError the RRSets of a zone spec are invalid.
+type ZoneSpecError struct {
+	// Findings the errors found by Lint.
+	Findings []LintFinding
+}
+
+func (e ZoneSpecError) Error() string {
+	messages := make([]string, 0, len(e.Findings))
+	for _, finding := range e.Findings {
+		messages = append(messages, fmt.Sprintf("%s/%s: %s", finding.SubName, finding.Type, finding.Message))
+	}
+
+	return "invalid zone spec: " + strings.Join(messages, "; ")
+}
+
+// LoadZoneSpec reads a zone spec file (YAML or JSON).
+func LoadZoneSpec(path string) (*ZoneSpec, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	return ParseZoneSpec(file)
+}
+
+// ParseZoneSpec parses and validates a zone spec (YAML or JSON).
+// The RRSets without TTL get the TTL of the spec, or 3600.
+// The RRSets are checked with Lint: a ZoneSpecError is returned if errors are found.
+func ParseZoneSpec(r io.Reader) (*ZoneSpec, error) {
+	// JSON is a subset of YAML: the YAML decoder reads both formats.
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	var file zoneSpecFile
+
+	err := decoder.Decode(&file)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse zone spec: %w", err)
+	}
+
+	defaultTTL := file.TTL
+	if defaultTTL == 0 {
+		defaultTTL = fallbackTTL
+	}
+
+	spec := &ZoneSpec{Domain: file.Domain}
+
+	for i, item := range file.RRSets {
+		if item.Type == "" {
+			return nil, fmt.Errorf("rrsets[%d]: missing type", i)
+		}
+
+		if len(item.Records) == 0 {
+			return nil, fmt.Errorf("rrsets[%d] (%s/%s): missing records", i, item.SubName, item.Type)
+		}
+
+		rrSet := RRSet{
+			SubName: normalizeSubName(item.SubName),
+			Type:    strings.ToUpper(item.Type),
+			TTL:     item.TTL,
+			Records: item.Records,
+		}
+
+		if rrSet.TTL == 0 {
+			rrSet.TTL = defaultTTL
+		}
+
+		spec.RRSets = append(spec.RRSets, rrSet)
+	}
+
+	report := Lint(spec.RRSets)
+	if !report.OK() {
+		specErr := &ZoneSpecError{}
+
+		for _, finding := range report.Findings {
+			if finding.Severity == SeverityError {
+				specErr.Findings = append(specErr.Findings, finding)
+			}
+		}
+
+		return nil, specErr
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,93 @@
+package desec
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseZoneSpec_yaml(t *testing.T) {
+	data := `
+domain: example.com
+ttl: 600
+rrsets:
+  - subname: www
+    type: a
+    records: [192.0.2.1, 192.0.2.2]
+  - subname: "@"
+    type: MX
+    ttl: 300
+    records: ["10 mx.example.com."]
+`
+
+	spec, err := ParseZoneSpec(strings.NewReader(data))
+	require.NoError(t, err)
+
+	expected := &ZoneSpec{
+		Domain: "example.com",
+		RRSets: []RRSet{
+			{SubName: "www", Type: "A", TTL: 600, Records: []string{"192.0.2.1", "192.0.2.2"}},
+			{SubName: "", Type: "MX", TTL: 300, Records: []string{"10 mx.example.com."}},
+		},
+	}
+	assert.Equal(t, expected, spec)
+}
+
+func TestLoadZoneSpec_json(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "zone.json")
+
+	err := os.WriteFile(path, []byte(`{"rrsets":[{"subname":"www","type":"AAAA","records":["2001:db8::1"]}]}`), 0o600)
+	require.NoError(t, err)
+
+	spec, err := LoadZoneSpec(path)
+	require.NoError(t, err)
+
+	expected := &ZoneSpec{
+		RRSets: []RRSet{
+			{SubName: "www", Type: "AAAA", TTL: fallbackTTL, Records: []string{"2001:db8::1"}},
+		},
+	}
+	assert.Equal(t, expected, spec)
+}
+
+func TestParseZoneSpec_errors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		data     string
+		expected string
+	}{
+		{
+			desc:     "unknown field",
+			data:     `{"rrsets":[{"subname":"www","type":"A","value":"192.0.2.1"}]}`,
+			expected: `failed to parse zone spec: yaml: unmarshal errors:` + "\n" + `  line 1: field value not found in type desec.zoneSpecFileRRSet`,
+		},
+		{
+			desc:     "missing type",
+			data:     `{"rrsets":[{"subname":"www","records":["192.0.2.1"]}]}`,
+			expected: "rrsets[0]: missing type",
+		},
+		{
+			desc:     "missing records",
+			data:     `{"rrsets":[{"subname":"www","type":"A"}]}`,
+			expected: "rrsets[0] (www/A): missing records",
+		},
+		{
+			desc:     "lint",
+			data:     `{"rrsets":[{"subname":"www","type":"CNAME","records":["example.net."]},{"subname":"www","type":"A","records":["192.0.2.1"]}]}`,
+			expected: "invalid zone spec: www/CNAME: CNAME cannot coexist with other types: A",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseZoneSpec(strings.NewReader(test.data))
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}